@@ -64,7 +64,9 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			upgradeClient.ProposalHandler, paramsclient.ProposalHandler,
-			dexClient.DelistProposalHandler, distr.ProposalHandler,
+			dexClient.DelistProposalHandler, dexClient.AuctionTypeProposalHandler, dexClient.PriceBandProposalHandler,
+			dexClient.ListProposalHandler,
+			distr.ProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -307,7 +309,7 @@ func (p *ProtocolV0) produceKeepers() {
 
 	p.orderKeeper = order.NewKeeper(
 		p.tokenKeeper, p.supplyKeeper, p.dexKeeper, orderSubspace, auth.FeeCollectorName,
-		p.keys[order.OrderStoreKey], p.cdc, appConfig.BackendConfig.EnableBackend, orderMetrics,
+		p.keys[order.OrderStoreKey], p.tkeys[order.TStoreKey], p.cdc, appConfig.BackendConfig.EnableBackend, orderMetrics,
 	)
 
 	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.cdc, p.keys[ammswap.StoreKey], swapSubSpace)
@@ -487,7 +489,7 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 
 		wrongMsgRes := sdk.Result{
 			Code: sdk.CodeUnknownRequest,
-			Log:  "It is not allowed that a transaction with more than one message contains placeOrder or cancelOrder message",
+			Log:  "It is not allowed that a transaction with more than one message contains placeOrder, cancelOrder or amendOrder message",
 		}
 
 		for _, msg := range msgs {
@@ -504,6 +506,18 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 					break
 				}
 				res = order.ValidateMsgCancelOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgCancelAllOrders:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+					break
+				}
+				res = order.ValidateMsgCancelAllOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgAmendOrders:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+					break
+				}
+				res = order.ValidateMsgAmendOrders(newCtx, orderKeeper, assertedMsg)
 			}
 
 			if !res.IsOK() {
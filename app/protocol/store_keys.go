@@ -41,7 +41,7 @@ var (
 		ammswap.StoreKey,
 	)
 
-	transientStoreKeysMap = sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
+	transientStoreKeysMap = sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey, order.TStoreKey)
 )
 
 // GetMainStoreKey gets the main store key
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/okex/okchain/x/order/backtest"
+)
+
+const (
+	flagGenesis     = "genesis"
+	flagOutput      = "output"
+	flagStartHeight = "start-height"
+)
+
+func backtestCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backtest [order-flow-file]",
+		Short: "Replay the order flow against the exported state offline with the match engines",
+		Long: `Replay the order flow against the exported genesis state in memory with the real order handler and match
+engines, and write the match results, deals and fees of each block as a line of JSON.
+
+The order flow file contains a block per line, e.g.
+{"time":"2020-06-01T00:00:00Z","msgs":[{"type":"okchain/order/MsgNew","value":{...}}]}
+The time of a block is optional. The signatures and the gas fees of the msgs are not taken into account.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			genesisFile := viper.GetString(flagGenesis)
+			if genesisFile == "" {
+				genesisFile = ctx.Config.GenesisFile()
+			}
+			genDoc, err := tmtypes.GenesisDocFromFile(genesisFile)
+			if err != nil {
+				return err
+			}
+			var appState map[string]json.RawMessage
+			if err = json.Unmarshal(genDoc.AppState, &appState); err != nil {
+				return err
+			}
+
+			logger := log.NewFilter(log.NewTMLogger(log.NewSyncWriter(os.Stderr)), log.AllowError())
+			simulator, err := backtest.NewSimulator(appState, viper.GetInt64(flagStartHeight), genDoc.GenesisTime,
+				logger)
+			if err != nil {
+				return err
+			}
+
+			input, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer input.Close()
+
+			output := os.Stdout
+			if path := viper.GetString(flagOutput); path != "" {
+				if output, err = os.Create(path); err != nil {
+					return err
+				}
+				defer output.Close()
+			}
+			return runBacktest(simulator, input, output)
+		},
+	}
+	cmd.Flags().String(flagGenesis, "", "Exported genesis file to start from, the genesis file of the node home as default")
+	cmd.Flags().StringP(flagOutput, "o", "", "File to write the results into, stdout as default")
+	cmd.Flags().Int64(flagStartHeight, 1, "Height of the first replayed block")
+	return cmd
+}
+
+// runBacktest delivers the blocks read from the order flow one by one, and writes their results
+func runBacktest(simulator *backtest.Simulator, input io.Reader, output io.Writer) error {
+	reader := bufio.NewReader(input)
+	encoder := json.NewEncoder(output)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var block backtest.Block
+			if err := simulator.Codec().UnmarshalJSON(line, &block); err != nil {
+				return fmt.Errorf("invalid block in line %d of the order flow: %v", lineNum, err)
+			}
+			if err := encoder.Encode(simulator.DeliverBlock(block)); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
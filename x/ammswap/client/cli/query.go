@@ -2,11 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/ammswap/types"
 	"github.com/spf13/cobra"
 )
@@ -25,25 +27,31 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	swapQueryCmd.AddCommand(
 		flags.GetCommands(
 			GetCmdSwapTokenPair(queryRoute, cdc),
+			GetCmdSwapRoute(queryRoute, cdc),
+			GetCmdSwapQuote(queryRoute, cdc),
+			GetCmdSwapQuoteExactOutput(queryRoute, cdc),
+			GetCmdRedeemableAssets(queryRoute, cdc),
+			GetCmdTWAP(queryRoute, cdc),
 		)...,
 	)
 
 	return swapQueryCmd
 }
 
-//GetCmdSwapTokenPair query exchange with token name
+//GetCmdSwapTokenPair query exchange with base token and quote token
 func GetCmdSwapTokenPair(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "exchange [token]",
-		Short: "exchange with token name",
-		Args:  cobra.ExactArgs(1),
+		Use:   "exchange [base-token] [quote-token]",
+		Short: "exchange with base token and quote token",
+		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			tokenName := args[0]
+			baseToken, quoteToken := args[0], args[1]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QuerySwapTokenPair, tokenName), nil)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QuerySwapTokenPair,
+				baseToken, quoteToken), nil)
 			if err != nil {
-				fmt.Printf("exchange - %s doesn't exist. error:%s \n", tokenName, err.Error())
+				fmt.Printf("exchange - %s_%s doesn't exist. error:%s \n", baseToken, quoteToken, err.Error())
 				return nil
 			}
 
@@ -52,3 +60,135 @@ func GetCmdSwapTokenPair(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdSwapRoute queries the best route to swap the amount of token sold for the token bought
+func GetCmdSwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "route [sell-amount] [buy-token]",
+		Short: "the route to buy the most of the token with the amount sold",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			soldTokenAmount, boughtToken := args[0], args[1]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QuerySwapRoute,
+				soldTokenAmount, boughtToken), nil)
+			if err != nil {
+				return err
+			}
+
+			var route types.SwapRoute
+			cdc.MustUnmarshalJSON(res, &route)
+			return cliCtx.PrintOutput(route)
+		},
+	}
+}
+
+// GetCmdSwapQuote queries the expected result of selling the amount of token for the token bought
+func GetCmdSwapQuote(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "quote [sell-amount] [buy-token]",
+		Short: "the amount bought, fees, price impact and pools after selling the amount of token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			soldTokenAmount, boughtToken := args[0], args[1]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QuerySwapQuote,
+				soldTokenAmount, boughtToken), nil)
+			if err != nil {
+				return err
+			}
+
+			var quote types.SwapQuote
+			cdc.MustUnmarshalJSON(res, &quote)
+			return cliCtx.PrintOutput(quote)
+		},
+	}
+}
+
+// GetCmdSwapQuoteExactOutput queries the expected result of buying exactly the amount of token with the token sold
+func GetCmdSwapQuoteExactOutput(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "quote-exact-output [buy-amount] [sell-token]",
+		Short: "the amount sold, fees, price impact and pools after buying exactly the amount of token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			boughtTokenAmount, soldToken := args[0], args[1]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute,
+				types.QuerySwapQuoteExactOutput, boughtTokenAmount, soldToken), nil)
+			if err != nil {
+				return err
+			}
+
+			var quote types.SwapQuote
+			cdc.MustUnmarshalJSON(res, &quote)
+			return cliCtx.PrintOutput(quote)
+		},
+	}
+}
+
+// GetCmdRedeemableAssets queries the amounts of the base token and the quote token withdrawn by burning the liquidity
+func GetCmdRedeemableAssets(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redeemable-assets [base-token] [quote-token] [liquidity]",
+		Short: "the amounts of base token and quote token withdrawn by burning the liquidity of pool token",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			baseToken, quoteToken, liquidity := args[0], args[1], args[2]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s/%s", queryRoute,
+				types.QueryRedeemableAssets, baseToken, quoteToken, liquidity), nil)
+			if err != nil {
+				return err
+			}
+
+			var assets sdk.DecCoins
+			cdc.MustUnmarshalJSON(res, &assets)
+			return cliCtx.PrintOutput(assets)
+		},
+	}
+}
+
+// GetCmdTWAP queries the time-weighted average prices of the exchange with base token and quote token
+func GetCmdTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var startTime, endTime int64
+	var window string
+	cmd := &cobra.Command{
+		Use:   "twap [base-token] [quote-token]",
+		Short: "time-weighted average prices of the exchange with base token and quote token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			baseToken, quoteToken := args[0], args[1]
+
+			var route string
+			if startTime != 0 || endTime != 0 {
+				route = fmt.Sprintf("custom/%s/%s/%s/%s/%d/%d", queryRoute, types.QueryTWAP, baseToken, quoteToken,
+					startTime, endTime)
+			} else {
+				duration, err := time.ParseDuration(window)
+				if err != nil {
+					return fmt.Errorf("invalid window: %s", window)
+				}
+				route = fmt.Sprintf("custom/%s/%s/%s/%s/%d", queryRoute, types.QueryTWAP, baseToken, quoteToken,
+					int64(duration.Seconds()))
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var twap types.TWAP
+			cdc.MustUnmarshalJSON(res, &twap)
+			return cliCtx.PrintOutput(twap)
+		},
+	}
+	cmd.Flags().Int64VarP(&startTime, "start", "", 0, "Start time of the average in unix seconds, used with --end")
+	cmd.Flags().Int64VarP(&endTime, "end", "", 0, "End time of the average in unix seconds, used with --start")
+	cmd.Flags().StringVarP(&window, "window", "w", "1h", "Duration until the latest block over which the prices are averaged, such as \"30m\" or \"24h\"")
+	return cmd
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -28,6 +29,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdTokenSwapExactOutput(cdc),
+		getCmdMultiHopSwap(cdc),
 	)...)
 
 	return txCmd
@@ -138,6 +141,7 @@ $ okexchaincli tx swap remove-liquidity --max-base-amount 10eth-355 --quote-amou
 func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 	// flags
 	var token string
+	var quoteToken string
 	cmd := &cobra.Command{
 		Use:   "create-pair",
 		Short: "create token pair",
@@ -145,7 +149,8 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 			fmt.Sprintf(`create token pair.
 
 Example:
-$ okexchaincli tx swap create-pair --max-base-amount 10eth-355 --quote-amount 100okt --min-liquidity 0.001
+$ okexchaincli tx swap create-pair --token eth-355 --quote-token okt
+$ okexchaincli tx swap create-pair --token usdk-017 --quote-token usdt-a2b
 
 `),
 		),
@@ -153,13 +158,14 @@ $ okexchaincli tx swap create-pair --max-base-amount 10eth-355 --quote-amount 10
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
-			msg := types.NewMsgCreateExchange(token, cliCtx.FromAddress)
+			msg := types.NewMsgCreateExchange(token, quoteToken, cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 
-	cmd.Flags().StringVarP(&token, "token", "t", "", "Create exchange by token name")
+	cmd.Flags().StringVarP(&token, "token", "t", "", "Create exchange by base token name")
+	cmd.Flags().StringVarP(&quoteToken, "quote-token", "q", common.NativeToken, "Create exchange by quote token name")
 	return cmd
 }
 
@@ -224,3 +230,131 @@ $ okexchaincli tx swap token --max-base-amount 10eth-355 --quote-amount 100okt -
 		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
 	return cmd
 }
+
+func getCmdTokenSwapExactOutput(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var boughtTokenAmount string
+	var maxSoldTokenAmount string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "token-exact-output",
+		Short: "swap token for exactly the amount to buy",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token for exactly the amount to buy, with at most the amount to sell.
+
+Example:
+$ okexchaincli tx swap token-exact-output --buy-amount 10eth-355 --max-sell-amount 100okt
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			boughtTokenAmount, err := sdk.ParseDecCoin(boughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			maxSoldTokenAmount, err := sdk.ParseDecCoin(maxSoldTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSwapTokenExactOutput(boughtTokenAmount, maxSoldTokenAmount,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&boughtTokenAmount, "buy-amount", "", "",
+		"Amount expected to buy")
+	cmd.Flags().StringVarP(&maxSoldTokenAmount, "max-sell-amount", "", "",
+		"Maximum amount expected to sell")
+	cmd.Flags().StringVarP(&recipient, "recipient", "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, "deadline", "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	return cmd
+}
+
+func getCmdMultiHopSwap(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var soldTokenAmount string
+	var minBoughtTokenAmount string
+	var path string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "multi-hop",
+		Short: "swap token through the pools along the path",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token through the pools of every two adjacent tokens in the path one by one, it fails as a whole
+if the amount bought at the end of the path is less than the minimum.
+
+Example:
+$ okexchaincli tx swap multi-hop --sell-amount 10eth-355 --min-buy-amount 100usdk-017 --path eth-355,okt,usdk-017
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmount, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := sdk.ParseDecCoin(minBoughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgMultiHopSwap(soldTokenAmount, minBoughtTokenAmount, strings.Split(path, ","),
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&soldTokenAmount, "sell-amount", "", "",
+		"Amount expected to sell")
+	cmd.Flags().StringVarP(&minBoughtTokenAmount, "min-buy-amount", "", "",
+		"Minimum amount expected to buy at the end of the path")
+	cmd.Flags().StringVarP(&path, "path", "", "",
+		"Tokens to swap through separated by commas, from the token to sell to the token to buy")
+	cmd.Flags().StringVarP(&recipient, "recipient", "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, "deadline", "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	return cmd
+}
@@ -7,29 +7,50 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/gorilla/mux"
 	"github.com/okex/okchain/x/ammswap/types"
 	"github.com/okex/okchain/x/common"
 )
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
-	r.HandleFunc("/ammswap/exchange", swapExchangeHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/ammswap/exchange/{base}/{quote}", swapExchangeHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/ammswap/route/{sell_amount}/{buy_token}", swapRouteHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/ammswap/swap/exact_output", swapExactOutputHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/ammswap/quote/{sell_amount}/{buy_token}",
+		swapQuoteHandler(cliCtx, types.QuerySwapQuote, "sell_amount", "buy_token")).Methods("GET")
+	r.HandleFunc("/ammswap/quote_exact_output/{buy_amount}/{sell_token}",
+		swapQuoteHandler(cliCtx, types.QuerySwapQuoteExactOutput, "buy_amount", "sell_token")).Methods("GET")
+	r.HandleFunc("/ammswap/redeemable_assets/{base}/{quote}/{liquidity}",
+		redeemableAssetsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/ammswap/twap/{base}/{quote}", twapHandler(cliCtx)).Methods("GET")
+}
+
+// SwapExactOutputRequest defines the properties of a swap request for exactly the amount to buy
+type SwapExactOutputRequest struct {
+	BaseReq            rest.BaseReq   `json:"base_req" yaml:"base_req"`
+	BoughtTokenAmount  sdk.DecCoin    `json:"bought_token_amount" yaml:"bought_token_amount"`
+	MaxSoldTokenAmount sdk.DecCoin    `json:"max_sold_token_amount" yaml:"max_sold_token_amount"`
+	Deadline           int64          `json:"deadline" yaml:"deadline"`   // unix time
+	Recipient          sdk.AccAddress `json:"recipient" yaml:"recipient"` // in bech32, the sender as default
 }
 
 func swapExchangeHandler(cliCtx context.CLIContext) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		tokenName := vars["token"]
+		baseToken, quoteToken := vars["base"], vars["quote"]
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ammswap/swapTokenPair/%s", tokenName), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ammswap/swapTokenPair/%s/%s", baseToken, quoteToken),
+			nil)
 		if err != nil {
 			common.HandleErrorMsg(w, cliCtx, err.Error())
 			return
 		}
 
 		exchange := types.SwapTokenPair{}
-		codec.Cdc.MustUnmarshalJSON(res, exchange)
+		codec.Cdc.MustUnmarshalJSON(res, &exchange)
 		response := common.GetBaseResponse(exchange)
 		resBytes, err := json.Marshal(response)
 		if err != nil {
@@ -39,3 +60,144 @@ func swapExchangeHandler(cliCtx context.CLIContext) func(http.ResponseWriter, *h
 		rest.PostProcessResponse(w, cliCtx, resBytes)
 	}
 }
+
+func swapRouteHandler(cliCtx context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		soldTokenAmount, boughtToken := vars["sell_amount"], vars["buy_token"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ammswap/swapRoute/%s/%s", soldTokenAmount,
+			boughtToken), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		route := types.SwapRoute{}
+		codec.Cdc.MustUnmarshalJSON(res, &route)
+		response := common.GetBaseResponse(route)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func swapExactOutputHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SwapExactOutputRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid address: %s", req.BaseReq.From))
+			return
+		}
+		recipient := req.Recipient
+		if recipient.Empty() {
+			recipient = fromAddr
+		}
+
+		msg := types.NewMsgSwapTokenExactOutput(req.BoughtTokenAmount, req.MaxSoldTokenAmount, req.Deadline,
+			recipient, fromAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func swapQuoteHandler(cliCtx context.CLIContext, queryPath, amountVar, tokenVar string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		tokenAmount, token := vars[amountVar], vars[tokenVar]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ammswap/%s/%s/%s", queryPath, tokenAmount, token),
+			nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		quote := types.SwapQuote{}
+		codec.Cdc.MustUnmarshalJSON(res, &quote)
+		response := common.GetBaseResponse(quote)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func redeemableAssetsHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		baseToken, quoteToken, liquidity := vars["base"], vars["quote"], vars["liquidity"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ammswap/redeemableAssets/%s/%s/%s", baseToken,
+			quoteToken, liquidity), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var assets sdk.DecCoins
+		codec.Cdc.MustUnmarshalJSON(res, &assets)
+		response := common.GetBaseResponse(assets)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+// twapHandler queries the time-weighted average prices either between the start and end query params in unix seconds,
+// or over the window query param in seconds until the latest block
+func twapHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		baseToken, quoteToken := vars["base"], vars["quote"]
+		startTime, endTime := r.URL.Query().Get("start"), r.URL.Query().Get("end")
+		window := r.URL.Query().Get("window")
+
+		var route string
+		if startTime != "" || endTime != "" {
+			route = fmt.Sprintf("custom/ammswap/twap/%s/%s/%s/%s", baseToken, quoteToken, startTime, endTime)
+		} else {
+			if window == "" {
+				window = "3600"
+			}
+			route = fmt.Sprintf("custom/ammswap/twap/%s/%s/%s", baseToken, quoteToken, window)
+		}
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var twap types.TWAP
+		codec.Cdc.MustUnmarshalJSON(res, &twap)
+		response := common.GetBaseResponse(twap)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
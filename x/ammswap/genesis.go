@@ -9,8 +9,9 @@ import (
 
 // GenesisState stores genesis data, all slashing state that must be provided at genesis
 type GenesisState struct {
-	Params               Params          `json:"params"`
-	SwapTokenPairRecords []SwapTokenPair `json:"swap_token_pair_records"`
+	Params               Params                   `json:"params"`
+	SwapTokenPairRecords []SwapTokenPair          `json:"swap_token_pair_records"`
+	PriceObservations    []types.PriceObservation `json:"price_observations"`
 }
 
 // nolint
@@ -20,6 +21,8 @@ func NewGenesisState(swapTokenPairRecords []SwapTokenPair) GenesisState {
 
 // ValidateGenesis validates the format of the specified genesisState
 func ValidateGenesis(data GenesisState) error {
+	tokenPairs := make(map[string]bool)
+	poolTokens := make(map[string]bool)
 	for _, record := range data.SwapTokenPairRecords {
 		if !record.QuotePooledCoin.IsValid() {
 			return fmt.Errorf("invalid SwapTokenPairRecord: QuotePooledCoin: %s", record.QuotePooledCoin.String())
@@ -27,9 +30,40 @@ func ValidateGenesis(data GenesisState) error {
 		if !record.BasePooledCoin.IsValid() {
 			return fmt.Errorf("invalid SwapTokenPairRecord: BasePooledCoin: %s", record.BasePooledCoin)
 		}
+		if record.BasePooledCoin.Denom == record.QuotePooledCoin.Denom {
+			return fmt.Errorf("invalid SwapTokenPairRecord: %s. Error: the same base and quote token",
+				record.TokenPairName())
+		}
 		if !types.ValidatePoolTokenName(record.PoolTokenName) {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
+
+		// only one exchange is allowed between two tokens, and each one has its own pool token
+		reversedPair := record.QuotePooledCoin.Denom + "_" + record.BasePooledCoin.Denom
+		if tokenPairs[record.TokenPairName()] || tokenPairs[reversedPair] {
+			return fmt.Errorf("invalid SwapTokenPairRecord: %s. Error: duplicate token pair", record.TokenPairName())
+		}
+		if poolTokens[record.PoolTokenName] {
+			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: duplicate PoolToken",
+				record.PoolTokenName)
+		}
+		tokenPairs[record.TokenPairName()] = true
+		poolTokens[record.PoolTokenName] = true
+	}
+
+	for _, observation := range data.PriceObservations {
+		if !tokenPairs[observation.TokenPairName] {
+			return fmt.Errorf("invalid PriceObservation: %s. Error: token pair not found", observation.TokenPairName)
+		}
+		if observation.Time <= 0 {
+			return fmt.Errorf("invalid PriceObservation: %s. Error: invalid time %d", observation.TokenPairName,
+				observation.Time)
+		}
+		if observation.PriceCumulative.IsNil() || observation.PriceCumulative.IsNegative() ||
+			observation.InversePriceCumulative.IsNil() || observation.InversePriceCumulative.IsNegative() {
+			return fmt.Errorf("invalid PriceObservation: %s. Error: invalid price cumulative",
+				observation.TokenPairName)
+		}
 	}
 	return nil
 }
@@ -48,6 +82,9 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	for _, record := range data.SwapTokenPairRecords {
 		keeper.SetSwapTokenPair(ctx, record.TokenPairName(), record)
 	}
+	for _, observation := range data.PriceObservations {
+		keeper.SetPriceObservation(ctx, observation)
+	}
 }
 
 // ExportGenesis exports genesis from keeper
@@ -60,6 +97,16 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		records = append(records, tokenPair)
 
 	}
+	iterator.Close()
+
+	var observations []types.PriceObservation
+	observationIterator := k.GetPriceObservationsIterator(ctx)
+	defer observationIterator.Close()
+	for ; observationIterator.Valid(); observationIterator.Next() {
+		var observation types.PriceObservation
+		types.ModuleCdc.MustUnmarshalBinaryLengthPrefixed(observationIterator.Value(), &observation)
+		observations = append(observations, observation)
+	}
 	params := k.GetParams(ctx)
-	return GenesisState{SwapTokenPairRecords: records, Params: params}
+	return GenesisState{SwapTokenPairRecords: records, PriceObservations: observations, Params: params}
 }
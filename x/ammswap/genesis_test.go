@@ -42,6 +42,60 @@ func TestValidateGenesis(t *testing.T) {
 	}
 	err = ValidateGenesis(defaultGenesisState)
 	require.NotNil(t, err)
+
+	// the same base and quote token
+	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
+		*types.NewSwapTokenPair(testSwapTokenPair.BasePooledCoin, testSwapTokenPair.BasePooledCoin, testSwapTokenPair.PoolTokenName),
+	}
+	err = ValidateGenesis(defaultGenesisState)
+	require.NotNil(t, err)
+
+	// duplicate token pair in the reversed order
+	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
+		testSwapTokenPair,
+		*types.NewSwapTokenPair(testSwapTokenPair.BasePooledCoin, testSwapTokenPair.QuotePooledCoin, types.PoolTokenPrefix+types.TestBasePooledToken2),
+	}
+	err = ValidateGenesis(defaultGenesisState)
+	require.NotNil(t, err)
+
+	// duplicate pool token
+	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
+		testSwapTokenPair,
+		*types.NewSwapTokenPair(testSwapTokenPair.QuotePooledCoin, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.ZeroDec()), testSwapTokenPair.PoolTokenName),
+	}
+	err = ValidateGenesis(defaultGenesisState)
+	require.NotNil(t, err)
+
+	// token pair of two tokens other than the native token
+	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
+		testSwapTokenPair,
+		*types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.ZeroDec()), testSwapTokenPair.BasePooledCoin, types.PoolTokenPrefix+types.TestBasePooledToken+"-a1b"),
+	}
+	err = ValidateGenesis(defaultGenesisState)
+	require.Nil(t, err)
+
+	// price observations of the existing token pairs
+	defaultGenesisState.PriceObservations = []types.PriceObservation{
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 1000,
+			PriceCumulative: sdk.ZeroDec(), InversePriceCumulative: sdk.ZeroDec()},
+	}
+	err = ValidateGenesis(defaultGenesisState)
+	require.Nil(t, err)
+	invalidObservations := []types.PriceObservation{
+		{TokenPairName: types.TestBasePooledToken3 + "_" + types.TestQuotePooledToken, Time: 1000,
+			PriceCumulative: sdk.ZeroDec(), InversePriceCumulative: sdk.ZeroDec()},
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 0,
+			PriceCumulative: sdk.ZeroDec(), InversePriceCumulative: sdk.ZeroDec()},
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 1000,
+			PriceCumulative: sdk.NewDec(-1), InversePriceCumulative: sdk.ZeroDec()},
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 1000,
+			PriceCumulative: sdk.ZeroDec(), InversePriceCumulative: sdk.Dec{}},
+	}
+	for _, observation := range invalidObservations {
+		defaultGenesisState.PriceObservations = []types.PriceObservation{observation}
+		err = ValidateGenesis(defaultGenesisState)
+		require.NotNil(t, err)
+	}
 }
 
 func TestInitAndExportGenesis(t *testing.T) {
@@ -56,6 +110,12 @@ func TestInitAndExportGenesis(t *testing.T) {
 	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
 		testSwapTokenPair,
 	}
+	defaultGenesisState.PriceObservations = []types.PriceObservation{
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 1000,
+			PriceCumulative: sdk.ZeroDec(), InversePriceCumulative: sdk.ZeroDec()},
+		{TokenPairName: testSwapTokenPair.TokenPairName(), Time: 1100,
+			PriceCumulative: sdk.NewDec(200), InversePriceCumulative: sdk.NewDec(50)},
+	}
 	InitGenesis(ctx, keeper, defaultGenesisState)
 	exportedGenesis := ExportGenesis(ctx, keeper)
 	require.Equal(t, defaultGenesisState, exportedGenesis)
@@ -2,7 +2,9 @@ package ammswap
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/okex/okchain/x/ammswap/keeper"
 	"github.com/okex/okchain/x/ammswap/types"
 	"github.com/okex/okchain/x/common"
 	"github.com/okex/okchain/x/common/perf"
@@ -37,6 +39,16 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgTokenToTokenExchange(ctx, k, msg)
 			}
+		case types.MsgMultiHopSwap:
+			name = "handleMsgMultiHopSwap"
+			handlerFun = func() sdk.Result {
+				return handleMsgMultiHopSwap(ctx, k, msg)
+			}
+		case types.MsgSwapTokenExactOutput:
+			name = "handleMsgSwapTokenExactOutput"
+			handlerFun = func() sdk.Result {
+				return handleMsgSwapTokenExactOutput(ctx, k, msg)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -48,49 +60,59 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgTokenToTokenExchange(ctx sdk.Context, k Keeper, msg types.MsgTokenToNativeToken) sdk.Result {
-	if msg.SoldTokenAmount.Denom != sdk.DefaultBondDenom && msg.MinBoughtTokenAmount.Denom != sdk.DefaultBondDenom {
-		return handleMsgTokenToToken(ctx, k, msg)
-	}
-	return handleMsgTokenToNativeToken(ctx, k, msg)
+	path := k.GetSwapPath(ctx, msg.SoldTokenAmount.Denom, msg.MinBoughtTokenAmount.Denom)
+	return swapAlongPath(ctx, k, msg.SoldTokenAmount, msg.MinBoughtTokenAmount, path, msg.Deadline, msg.Recipient,
+		msg.Sender)
+}
+
+func handleMsgSwapTokenExactOutput(ctx sdk.Context, k Keeper, msg types.MsgSwapTokenExactOutput) sdk.Result {
+	path := k.GetSwapPath(ctx, msg.MaxSoldTokenAmount.Denom, msg.BoughtTokenAmount.Denom)
+	return swapAlongPathExactOutput(ctx, k, msg.MaxSoldTokenAmount, msg.BoughtTokenAmount, path, msg.Deadline,
+		msg.Recipient, msg.Sender)
 }
 
 func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExchange) sdk.Result {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
-	err := k.IsTokenExist(ctx, msg.Token)
-	if err != nil {
-		return sdk.Result{
-			Code: sdk.CodeInternal,
-			Log:  err.Error(),
+	quoteTokenName := msg.GetQuoteToken()
+	tokens := []string{msg.Token}
+	if quoteTokenName != common.NativeToken {
+		tokens = append(tokens, quoteTokenName)
+	}
+	for _, token := range tokens {
+		if err := k.IsTokenExist(ctx, token); err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
 		}
 	}
 
-	tokenPair := msg.Token + "_" + common.NativeToken
+	tokenPair := msg.GetSwapTokenPair()
 
-	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPair)
-	if err == nil {
+	// only one exchange is allowed between two tokens
+	if _, err := k.GetSwapTokenPairOfTokens(ctx, msg.Token, quoteTokenName); err == nil {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  "Failed: exchange already exists",
 		}
 	}
 
-	poolName := types.PoolTokenPrefix + msg.Token
-	baseToken := sdk.NewDecCoinFromDec(msg.Token, sdk.ZeroDec())
-	quoteToken := sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
-	poolToken, err := k.GetPoolTokenInfo(ctx, poolName)
-	if err == nil {
+	poolName, valid := k.GeneratePoolTokenName(ctx, msg.Token, quoteTokenName)
+	if !valid {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  "Failed: pool token already exists",
 		}
 	}
 	k.NewPoolToken(ctx, poolName)
-	event = event.AppendAttributes(sdk.NewAttribute("pool-token", poolToken.OriginalSymbol))
-	swapTokenPair.BasePooledCoin = baseToken
-	swapTokenPair.QuotePooledCoin = quoteToken
-	swapTokenPair.PoolTokenName = poolName
+	event = event.AppendAttributes(sdk.NewAttribute("pool-token", poolName))
 
-	k.SetSwapTokenPair(ctx, tokenPair, swapTokenPair)
+	baseToken := sdk.NewDecCoinFromDec(msg.Token, sdk.ZeroDec())
+	quoteToken := sdk.NewDecCoinFromDec(quoteTokenName, sdk.ZeroDec())
+	swapTokenPair := types.NewSwapTokenPair(quoteToken, baseToken, poolName)
+	swapTokenPair.LastUpdateTime = ctx.BlockTime().Unix()
+	k.SetSwapTokenPair(ctx, tokenPair, *swapTokenPair)
+	k.ObservePrice(ctx, *swapTokenPair)
 
 	event = event.AppendAttributes(sdk.NewAttribute("token-pair", tokenPair))
 	ctx.EventManager().EmitEvent(event)
@@ -126,7 +148,7 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 		baseTokens.Amount = msg.MaxBaseAmount.Amount
 		liquidity = sdk.NewDec(1)
 	} else if swapTokenPair.BasePooledCoin.IsPositive() && swapTokenPair.QuotePooledCoin.IsPositive() {
-		baseTokens.Amount = mulAndQuo(msg.QuoteAmount.Amount, swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount)
+		baseTokens.Amount = keeper.MulAndQuo(msg.QuoteAmount.Amount, swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount)
 
 		totalSupply := k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
 		if totalSupply.IsZero() {
@@ -135,7 +157,7 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 				Log:  fmt.Sprintf("unexpected totalSupply in pool token %s", poolToken.String()),
 			}
 		}
-		liquidity = mulAndQuo(msg.QuoteAmount.Amount, totalSupply, swapTokenPair.QuotePooledCoin.Amount)
+		liquidity = keeper.MulAndQuo(msg.QuoteAmount.Amount, totalSupply, swapTokenPair.QuotePooledCoin.Amount)
 
 	} else {
 		return sdk.Result{
@@ -172,9 +194,11 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 		}
 	}
 	// update swapTokenPair
+	swapTokenPair.UpdatePriceCumulative(ctx.BlockTime().Unix())
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.QuoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(baseTokens)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
+	k.ObservePrice(ctx, swapTokenPair)
 
 	// update poolToken
 	poolCoins := sdk.NewDecCoinFromDec(poolToken.Symbol, liquidity)
@@ -210,19 +234,14 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 	}
 
 	liquidity := msg.Liquidity
-	poolTokenAmount := k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
-	if poolTokenAmount.LT(liquidity) {
+	baseAmount, quoteAmount, err := k.GetRedeemableAssets(ctx, swapTokenPair, liquidity)
+	if err != nil {
 		return sdk.Result{
 			Code: sdk.CodeInsufficientCoins,
 			Log:  "insufficient pool token",
 		}
 	}
 
-	baseDec := mulAndQuo(swapTokenPair.BasePooledCoin.Amount, liquidity, poolTokenAmount)
-	quoteDec := mulAndQuo(swapTokenPair.QuotePooledCoin.Amount, liquidity, poolTokenAmount)
-	baseAmount := sdk.NewDecCoinFromDec(swapTokenPair.BasePooledCoin.Denom, baseDec)
-	quoteAmount := sdk.NewDecCoinFromDec(swapTokenPair.QuotePooledCoin.Denom, quoteDec)
-
 	if baseAmount.IsLT(msg.MinBaseAmount) {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
@@ -250,9 +269,11 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 		}
 	}
 	// update swapTokenPair
+	swapTokenPair.UpdatePriceCumulative(ctx.BlockTime().Unix())
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(quoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(baseAmount)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
+	k.ObservePrice(ctx, swapTokenPair)
 
 	// update poolToken
 	poolCoins := sdk.NewDecCoinFromDec(swapTokenPair.PoolTokenName, liquidity)
@@ -270,167 +291,113 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
-func handleMsgTokenToNativeToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToNativeToken) sdk.Result {
-	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+func handleMsgMultiHopSwap(ctx sdk.Context, k Keeper, msg types.MsgMultiHopSwap) sdk.Result {
+	return swapAlongPath(ctx, k, msg.SoldTokenAmount, msg.MinBoughtTokenAmount, msg.Path, msg.Deadline,
+		msg.Recipient, msg.Sender)
+}
 
-	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
-		sdk.DecCoins{msg.SoldTokenAmount}); err != nil {
+// swapAlongPath swaps the sold token in the pools of every two adjacent tokens in the path one by one. All the hops
+// are calculated before any coin is transferred, and they are applied as a whole or not at all
+func swapAlongPath(ctx sdk.Context, k Keeper, soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, path []string,
+	deadline int64, recipient, sender sdk.AccAddress) sdk.Result {
+	if deadline < ctx.BlockTime().Unix() {
 		return sdk.Result{
-			Code: sdk.CodeInsufficientCoins,
-			Log:  err.Error(),
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
 		}
 	}
-	if msg.Deadline < ctx.BlockTime().Unix() {
+	if err := common.HasSufficientCoins(sender, k.GetTokenKeeper().GetCoins(ctx, sender),
+		sdk.DecCoins{soldTokenAmount}); err != nil {
 		return sdk.Result{
-			Code: sdk.CodeInternal,
-			Log:  "Failed: block time exceeded deadline",
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
 		}
 	}
-	swapTokenPair, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPair())
+	swapTokenPairs, tokenBuy, err := k.CalculateSwapRoute(ctx, soldTokenAmount, path)
 	if err != nil {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  err.Error(),
 		}
 	}
-	params := k.GetParams(ctx)
-	tokenBuy := calculateTokenToBuy(swapTokenPair, msg, params)
-	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+	if tokenBuy.Amount.LT(minBoughtTokenAmount.Amount) {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
-			Log:  fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s", msg.MinBoughtTokenAmount, tokenBuy),
+			Log:  fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s", minBoughtTokenAmount, tokenBuy),
 		}
 	}
 
-	res := swapTokenNativeToken(ctx, k, swapTokenPair, tokenBuy, msg)
-	if !res.IsOK() {
-		return res
-	}
-	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
-	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
-	ctx.EventManager().EmitEvent(event)
-	return sdk.Result{Events: ctx.EventManager().Events()}
+	return settleSwap(ctx, k, swapTokenPairs, soldTokenAmount, tokenBuy, path, recipient, sender)
 }
 
-func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToNativeToken) sdk.Result {
-	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
-
-	if msg.Deadline < ctx.BlockTime().Unix() {
+// swapAlongPathExactOutput buys exactly the bought token in the pools of every two adjacent tokens in the path, with
+// at most the amount of the sold token. The hops are applied as a whole or not at all, the same as swapAlongPath
+func swapAlongPathExactOutput(ctx sdk.Context, k Keeper, maxSoldTokenAmount, boughtTokenAmount sdk.DecCoin,
+	path []string, deadline int64, recipient, sender sdk.AccAddress) sdk.Result {
+	if deadline < ctx.BlockTime().Unix() {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  "Failed: block time exceeded deadline",
 		}
 	}
-	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
-		sdk.DecCoins{msg.SoldTokenAmount}); err != nil {
-		return sdk.Result{
-			Code: sdk.CodeInsufficientCoins,
-			Log:  err.Error(),
-		}
-	}
-	tokenPairOne := msg.SoldTokenAmount.Denom + "_" + sdk.DefaultBondDenom
-	swapTokenPairOne, err := k.GetSwapTokenPair(ctx, tokenPairOne)
+	swapTokenPairs, tokenSold, err := k.CalculateSwapRouteExactOutput(ctx, boughtTokenAmount, path)
 	if err != nil {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  err.Error(),
 		}
 	}
-	tokenPairTwo := msg.MinBoughtTokenAmount.Denom + "_" + sdk.DefaultBondDenom
-	swapTokenPairTwo, err := k.GetSwapTokenPair(ctx, tokenPairTwo)
-	if err != nil {
+	if tokenSold.Amount.GT(maxSoldTokenAmount.Amount) {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
-			Log:  err.Error(),
+			Log:  fmt.Sprintf("Failed: expected maximum token to sell is %s but need %s", maxSoldTokenAmount, tokenSold),
 		}
 	}
-
-	nativeAmount := sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.MustNewDecFromStr("0"))
-	params := k.GetParams(ctx)
-	msgOne := msg
-	msgOne.MinBoughtTokenAmount = nativeAmount
-	tokenNative := calculateTokenToBuy(swapTokenPairOne, msgOne, params)
-
-	msgTwo := msg
-	msgTwo.SoldTokenAmount = tokenNative
-	tokenBuy := calculateTokenToBuy(swapTokenPairOne, msgTwo, params)
-
-	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+	if err := common.HasSufficientCoins(sender, k.GetTokenKeeper().GetCoins(ctx, sender),
+		sdk.DecCoins{tokenSold}); err != nil {
 		return sdk.Result{
-			Code: sdk.CodeInternal,
-			Log:  fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s", msg.MinBoughtTokenAmount, tokenBuy),
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
 		}
 	}
 
-	res := swapTokenNativeToken(ctx, k, swapTokenPairOne, tokenNative, msgOne)
-	if !res.IsOK() {
-		return res
-	}
-	//TODO if fail,revert last swap
-	res = swapTokenNativeToken(ctx, k, swapTokenPairTwo, tokenBuy, msgTwo)
-	if !res.IsOK() {
-		return res
-	}
-
-	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
-	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
-	ctx.EventManager().EmitEvent(event)
-	return sdk.Result{Events: ctx.EventManager().Events()}
+	return settleSwap(ctx, k, swapTokenPairs, tokenSold, boughtTokenAmount, path, recipient, sender)
 }
 
-//calculate the amount to buy
-func calculateTokenToBuy(swapTokenPair SwapTokenPair, msg types.MsgTokenToNativeToken, params types.Params) sdk.DecCoin {
-	var inputReserve, outputReserve sdk.Dec
-	if msg.SoldTokenAmount.Denom == sdk.DefaultBondDenom {
-		inputReserve = swapTokenPair.QuotePooledCoin.Amount
-		outputReserve = swapTokenPair.BasePooledCoin.Amount
-	} else {
-		inputReserve = swapTokenPair.BasePooledCoin.Amount
-		outputReserve = swapTokenPair.QuotePooledCoin.Amount
-	}
-	tokenBuyAmt := getInputPrice(msg.SoldTokenAmount.Amount, inputReserve, outputReserve, params.FeeRate)
-	tokenBuy := sdk.NewDecCoinFromDec(msg.MinBoughtTokenAmount.Denom, tokenBuyAmt)
-
-	return tokenBuy
-}
+// settleSwap transfers the sold token from the sender and the bought token to the recipient, and updates the pools
+// swapped in. Nothing is changed if any of them fails
+func settleSwap(ctx sdk.Context, k Keeper, swapTokenPairs []SwapTokenPair, tokenSold, tokenBuy sdk.DecCoin,
+	path []string, recipient, sender sdk.AccAddress) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
-func swapTokenNativeToken(
-	ctx sdk.Context, k Keeper, swapTokenPair SwapTokenPair, tokenBuy sdk.DecCoin,
-	msg types.MsgTokenToNativeToken,
-) sdk.Result {
-	// transfer coins
-	err := k.SendCoinsToPool(ctx, sdk.DecCoins{msg.SoldTokenAmount}, msg.Sender)
-	if err != nil {
+	// all the pools share the module account, so the intermediate tokens never leave it
+	cacheCtx, writeCache := ctx.CacheContext()
+	if err := k.SendCoinsToPool(cacheCtx, sdk.DecCoins{tokenSold}, sender); err != nil {
 		return sdk.Result{
 			Code: sdk.CodeInsufficientCoins,
 			Log:  "insufficient Coins",
 		}
 	}
-
-	err = k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{tokenBuy}, msg.Recipient)
-	if err != nil {
+	if err := k.SendCoinsFromPoolToAccount(cacheCtx, sdk.DecCoins{tokenBuy}, recipient); err != nil {
 		return sdk.Result{
 			Code: sdk.CodeInsufficientCoins,
 			Log:  "insufficient Coins",
 		}
 	}
-
-	// update swapTokenPair
-	if msg.SoldTokenAmount.Denom == sdk.DefaultBondDenom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
-	} else {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+	for _, swapTokenPair := range swapTokenPairs {
+		k.SetSwapTokenPair(cacheCtx, swapTokenPair.TokenPairName(), swapTokenPair)
+		k.ObservePrice(cacheCtx, swapTokenPair)
 	}
-	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
-	return sdk.Result{}
-}
+	writeCache()
 
-func getInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
-	inputAmountWithFee := inputAmount.Mul(sdk.OneDec().Sub(feeRate).Mul(sdk.NewDec(1000)))
-	denominator := inputReserve.Mul(sdk.NewDec(1000)).Add(inputAmountWithFee)
-	return mulAndQuo(inputAmountWithFee, outputReserve, denominator)
+	event = event.AppendAttributes(
+		sdk.NewAttribute("sold_token_amount", tokenSold.String()),
+		sdk.NewAttribute("bought_token_amount", tokenBuy.String()),
+		sdk.NewAttribute("recipient", recipient.String()),
+		sdk.NewAttribute("path", strings.Join(path, ",")),
+	)
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 func coinSort(coins sdk.DecCoins) sdk.DecCoins {
@@ -443,14 +410,3 @@ func coinSort(coins sdk.DecCoins) sdk.DecCoins {
 	newCoins = newCoins.Sort()
 	return newCoins
 }
-
-var (
-	// 10^8
-	auxiliaryDec = sdk.NewDec(100000000)
-)
-
-// mulAndQuo returns a * b / c
-func mulAndQuo(a, b, c sdk.Dec) sdk.Dec {
-	a = a.Mul(auxiliaryDec)
-	return a.Mul(b).Quo(c).Quo(auxiliaryDec)
-}
@@ -23,7 +23,7 @@ func TestHandleMsgCreateExchange(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
 
 	// test case1: token is not exist
 	result := handler(ctx, msg)
@@ -46,6 +46,7 @@ func TestHandleMsgCreateExchange(t *testing.T) {
 	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
 
 	expectSwapTokenPair := types.GetTestSwapTokenPair()
+	expectSwapTokenPair.LastUpdateTime = ctx.BlockTime().Unix()
 	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, types.TestSwapTokenPairName)
 	require.Nil(t, err)
 	require.EqualValues(t, expectSwapTokenPair, swapTokenPair)
@@ -77,7 +78,7 @@ func TestHandleMsgAddLiquidity(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 
 	result := handler(ctx, msg)
@@ -127,7 +128,7 @@ func TestHandleMsgRemoveLiquidity(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 
 	result := handler(ctx, msg)
@@ -188,8 +189,8 @@ func TestHandleMsgTokenToTokenExchange(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
-	msgCreateExchange2 := types.NewMsgCreateExchange(secondTestToken.Symbol, addrKeysSlice[0].Address)
+	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
+	msgCreateExchange2 := types.NewMsgCreateExchange(secondTestToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 	mapp.tokenKeeper.NewToken(ctx, secondTestToken)
 
@@ -260,13 +261,189 @@ func TestHandleMsgTokenToTokenExchange(t *testing.T) {
 	}
 }
 
-func TestGetInputPrice(t *testing.T) {
-	defaultFeeRate := sdk.NewDecWithPrec(3, 3)
-	inputAmount := sdk.NewDecWithPrec(1, 8)
-	inputReserve := sdk.NewDec(100)
-	outputReserve := sdk.NewDec(100)
-	res := getInputPrice(inputAmount, inputReserve, outputReserve, defaultFeeRate)
-	require.Equal(t, inputAmount, res)
+func TestHandleMsgTokenToTokenPool(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	// test case1: the quote token is not exist
+	msgCreateExchange := types.NewMsgCreateExchange(types.TestBasePooledToken, types.TestBasePooledToken2, addr)
+	result := handler(ctx, msgCreateExchange)
+	require.Equal(t, sdk.CodeInternal, result.Code)
+
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.TestBasePooledToken))
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.TestBasePooledToken2))
+	result = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken, types.TestQuotePooledToken, addr))
+	require.Equal(t, "", result.Log)
+
+	// test case2: success
+	result = handler(ctx, msgCreateExchange)
+	require.Equal(t, "", result.Log)
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, msgCreateExchange.GetSwapTokenPair())
+	require.Nil(t, err)
+	require.Equal(t, types.TestBasePooledToken2, swapTokenPair.QuotePooledCoin.Denom)
+	require.NotEqual(t, types.PoolTokenPrefix+types.TestBasePooledToken, swapTokenPair.PoolTokenName)
+	require.True(t, mapp.tokenKeeper.TokenExist(ctx, swapTokenPair.PoolTokenName))
+
+	// test case3: the exchange of the two tokens already exists, no matter which one is the base token
+	result = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken2, types.TestBasePooledToken, addr))
+	require.Equal(t, sdk.CodeInternal, result.Code)
+
+	maxBaseAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10000))
+	quoteAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10000))
+	result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), maxBaseAmount, quoteAmount, deadLine, addr))
+	require.Equal(t, "", result.Log)
+
+	// test case4: swap in the pool of the two tokens directly, in both directions
+	nativePair, err := keeper.GetSwapTokenPair(ctx, types.TestSwapTokenPairName)
+	require.Nil(t, err)
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(2))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1))
+	result = handler(ctx, types.NewMsgTokenToNativeToken(soldTokenAmount, minBoughtTokenAmount, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	soldTokenAmount = sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1))
+	minBoughtTokenAmount = sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDecWithPrec(5, 1))
+	result = handler(ctx, types.NewMsgTokenToNativeToken(soldTokenAmount, minBoughtTokenAmount, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+
+	swapTokenPair, err = keeper.GetSwapTokenPair(ctx, msgCreateExchange.GetSwapTokenPair())
+	require.Nil(t, err)
+	require.True(t, swapTokenPair.QuotePooledCoin.Amount.GT(quoteAmount.Amount))
+	unchangedNativePair, err := keeper.GetSwapTokenPair(ctx, types.TestSwapTokenPairName)
+	require.Nil(t, err)
+	require.Equal(t, nativePair, unchangedNativePair)
+}
+
+func TestHandleMsgMultiHopSwap(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	for _, tokenName := range []string{types.TestBasePooledToken, types.TestBasePooledToken2} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(tokenName))
+		result := handler(ctx, types.NewMsgCreateExchange(tokenName, types.TestQuotePooledToken, addr))
+		require.Equal(t, "", result.Log)
+		maxBaseAmount := sdk.NewDecCoinFromDec(tokenName, sdk.NewDec(10000))
+		quoteAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(10000))
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), maxBaseAmount, quoteAmount, deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.TestBasePooledToken3))
+
+	path := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100))
+	_, expectedTokenBuy, err := keeper.CalculateSwapRoute(ctx, soldTokenAmount, path)
+	require.Nil(t, err)
+	tooLargeMinBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, expectedTokenBuy.Amount.Add(sdk.OneDec()))
+	unknownPath := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken3}
+	unknownMinBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.ZeroDec())
+
+	// test case1: none of the hops is applied if the final amount is less than the minimum, or any pool is missing
+	coins := mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins()
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, types.TestSwapTokenPairName)
+	require.Nil(t, err)
+	failedMsgs := []types.MsgMultiHopSwap{
+		types.NewMsgMultiHopSwap(soldTokenAmount, tooLargeMinBoughtTokenAmount, path, deadLine, addr, addr),
+		types.NewMsgMultiHopSwap(soldTokenAmount, unknownMinBoughtTokenAmount, unknownPath, deadLine, addr, addr),
+		types.NewMsgMultiHopSwap(soldTokenAmount, expectedTokenBuy, path, 0, addr, addr),
+	}
+	for _, msg := range failedMsgs {
+		result := handler(ctx, msg)
+		require.Equal(t, sdk.CodeInternal, result.Code)
+	}
+	require.Equal(t, coins, mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins())
+	unchangedSwapTokenPair, err := keeper.GetSwapTokenPair(ctx, types.TestSwapTokenPairName)
+	require.Nil(t, err)
+	require.Equal(t, swapTokenPair, unchangedSwapTokenPair)
+
+	// test case2: success
+	result := handler(ctx, types.NewMsgMultiHopSwap(soldTokenAmount, expectedTokenBuy, path, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	expectCoins := coins.Sub(sdk.DecCoins{soldTokenAmount}).Add(sdk.DecCoins{expectedTokenBuy})
+	require.Equal(t, expectCoins, mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins())
+	swapTokenPair2, err := keeper.GetSwapTokenPair(ctx, types.TestBasePooledToken2+"_"+types.TestQuotePooledToken)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10000).Sub(expectedTokenBuy.Amount), swapTokenPair2.BasePooledCoin.Amount)
+}
+
+func TestHandleMsgSwapTokenExactOutput(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	for _, tokenName := range []string{types.TestBasePooledToken, types.TestBasePooledToken2} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(tokenName))
+		result := handler(ctx, types.NewMsgCreateExchange(tokenName, types.TestQuotePooledToken, addr))
+		require.Equal(t, "", result.Log)
+		maxBaseAmount := sdk.NewDecCoinFromDec(tokenName, sdk.NewDec(10000))
+		quoteAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(10000))
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), maxBaseAmount, quoteAmount, deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+
+	boughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(11))
+	tooLittleMaxSoldTokenAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(10))
+	tooLargeBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(20000))
+	insufficientMaxSoldTokenAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100000000))
+	largeBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(9000))
+	unknownBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(1))
+	boughtTokenAmount2 := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10))
+	maxSoldTokenAmount2 := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(11))
+	tooLittleMaxSoldTokenAmount2 := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+
+	tests := []struct {
+		testCase           string
+		boughtTokenAmount  sdk.DecCoin
+		maxSoldTokenAmount sdk.DecCoin
+		deadLine           int64
+		exceptResultCode   sdk.CodeType
+	}{
+		{"(tokenToNativeToken) success", boughtTokenAmount, maxSoldTokenAmount, deadLine, 0},
+		{"(tokenToToken) success", boughtTokenAmount2, maxSoldTokenAmount2, deadLine, 0},
+		{"(tokenToNativeToken) blockTime exceeded deadline", boughtTokenAmount, maxSoldTokenAmount, 0, sdk.CodeInternal},
+		{"(tokenToNativeToken) the amount to sell exceeds the maximum", boughtTokenAmount, tooLittleMaxSoldTokenAmount, deadLine, sdk.CodeInternal},
+		{"(tokenToToken) the amount to sell exceeds the maximum", boughtTokenAmount2, tooLittleMaxSoldTokenAmount2, deadLine, sdk.CodeInternal},
+		{"(tokenToNativeToken) insufficient liquidity", tooLargeBoughtTokenAmount, insufficientMaxSoldTokenAmount, deadLine, sdk.CodeInternal},
+		{"(tokenToNativeToken) insufficient coins to sell", largeBoughtTokenAmount, insufficientMaxSoldTokenAmount, deadLine, sdk.CodeInsufficientCoins},
+		{"(tokenToNativeToken) unknown swapTokenPair", unknownBoughtTokenAmount, maxSoldTokenAmount, deadLine, sdk.CodeInternal},
+	}
+
+	for _, testCase := range tests {
+		coins := mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins()
+		msg := types.NewMsgSwapTokenExactOutput(testCase.boughtTokenAmount, testCase.maxSoldTokenAmount, testCase.deadLine, addr, addr)
+		result := handler(ctx, msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, testCase.testCase)
+
+		// exactly the amount to buy is received on success, otherwise nothing is changed
+		newCoins := mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins()
+		if result.IsOK() {
+			require.Equal(t, coins.AmountOf(testCase.boughtTokenAmount.Denom).Add(testCase.boughtTokenAmount.Amount),
+				newCoins.AmountOf(testCase.boughtTokenAmount.Denom), testCase.testCase)
+			require.True(t, coins.AmountOf(testCase.maxSoldTokenAmount.Denom).Sub(testCase.maxSoldTokenAmount.Amount).LTE(
+				newCoins.AmountOf(testCase.maxSoldTokenAmount.Denom)), testCase.testCase)
+		} else {
+			require.Equal(t, coins, newCoins, testCase.testCase)
+		}
+	}
 }
 
 func TestRandomData(t *testing.T) {
@@ -280,7 +457,7 @@ func TestRandomData(t *testing.T) {
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
-	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addrKeysSlice[0].Address)
 	result := handler(ctx, msgCreateExchange)
 	require.Equal(t, "", result.Log)
 	addr := addrKeysSlice[0].Address
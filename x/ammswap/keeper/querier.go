@@ -1,7 +1,9 @@
 package keeper
 
 import (
-	"github.com/okex/okchain/x/common"
+	"fmt"
+	"strconv"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -14,6 +16,16 @@ func NewQuerier(k Keeper) sdk.Querier {
 		switch path[0] {
 		case types.QuerySwapTokenPair:
 			return querySwapTokenPair(ctx, path[1:], req, k)
+		case types.QuerySwapRoute:
+			return querySwapRoute(ctx, path[1:], req, k)
+		case types.QuerySwapQuote:
+			return querySwapQuote(ctx, path[1:], req, k)
+		case types.QuerySwapQuoteExactOutput:
+			return querySwapQuoteExactOutput(ctx, path[1:], req, k)
+		case types.QueryRedeemableAssets:
+			return queryRedeemableAssets(ctx, path[1:], req, k)
+		case types.QueryTWAP:
+			return queryTWAP(ctx, path[1:], req, k)
 
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown swap query endpoint")
@@ -21,10 +33,13 @@ func NewQuerier(k Keeper) sdk.Querier {
 	}
 }
 
-// nolint
+// querySwapTokenPair queries the SwapTokenPair with the base token and the quote token in the path
 func querySwapTokenPair(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
 	err sdk.Error) {
-	tokenPairName := path[0] + "_" + common.NativeToken
+	if len(path) < 2 {
+		return nil, sdk.ErrUnknownRequest("both base token and quote token are required")
+	}
+	tokenPairName := path[0] + "_" + path[1]
 	tokenPair, error := keeper.GetSwapTokenPair(ctx, tokenPairName)
 	if error != nil {
 		return nil, sdk.ErrUnknownRequest(error.Error())
@@ -32,3 +47,136 @@ func querySwapTokenPair(ctx sdk.Context, path []string, req abci.RequestQuery, k
 	bz := keeper.cdc.MustMarshalJSON(tokenPair)
 	return bz, nil
 }
+
+// querySwapRoute queries the best route to swap the amount of token sold for the token bought in the path
+func querySwapRoute(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	soldTokenAmount, boughtToken, err := parseSwapQueryPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	route, routeErr := keeper.GetBestSwapRoute(ctx, soldTokenAmount, boughtToken)
+	if routeErr != nil {
+		return nil, sdk.ErrUnknownRequest(routeErr.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(route)
+	return bz, nil
+}
+
+// querySwapQuote quotes the swap of the amount of token sold in the path for the token bought, in the same pools as
+// MsgTokenToNativeToken
+func querySwapQuote(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	tokenSold, boughtToken, err := parseSwapQueryPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	swapPath := keeper.GetSwapPath(ctx, tokenSold.Denom, boughtToken)
+	swapTokenPairs, tokenBuy, calcErr := keeper.CalculateSwapRoute(ctx, tokenSold, swapPath)
+	if calcErr != nil {
+		return nil, sdk.ErrUnknownRequest(calcErr.Error())
+	}
+	quote, quoteErr := keeper.GetSwapQuote(ctx, swapPath, tokenSold, tokenBuy, swapTokenPairs)
+	if quoteErr != nil {
+		return nil, sdk.ErrUnknownRequest(quoteErr.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(quote)
+	return bz, nil
+}
+
+// querySwapQuoteExactOutput quotes the swap for exactly the amount of token bought in the path with the token sold,
+// in the same pools as MsgSwapTokenExactOutput
+func querySwapQuoteExactOutput(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	tokenBuy, soldToken, err := parseSwapQueryPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	swapPath := keeper.GetSwapPath(ctx, soldToken, tokenBuy.Denom)
+	swapTokenPairs, tokenSold, calcErr := keeper.CalculateSwapRouteExactOutput(ctx, tokenBuy, swapPath)
+	if calcErr != nil {
+		return nil, sdk.ErrUnknownRequest(calcErr.Error())
+	}
+	quote, quoteErr := keeper.GetSwapQuote(ctx, swapPath, tokenSold, tokenBuy, swapTokenPairs)
+	if quoteErr != nil {
+		return nil, sdk.ErrUnknownRequest(quoteErr.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(quote)
+	return bz, nil
+}
+
+// queryRedeemableAssets queries the amounts of the base token and the quote token withdrawn by burning the liquidity
+// of the pool token, with the base token, the quote token and the liquidity in the path
+func queryRedeemableAssets(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	if len(path) < 3 {
+		return nil, sdk.ErrUnknownRequest("base token, quote token and liquidity are required")
+	}
+	liquidity, decErr := sdk.NewDecFromStr(path[2])
+	if decErr != nil || !liquidity.IsPositive() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid liquidity: %s", path[2]))
+	}
+	swapTokenPair, pairErr := keeper.GetSwapTokenPair(ctx, path[0]+"_"+path[1])
+	if pairErr != nil {
+		return nil, sdk.ErrUnknownRequest(pairErr.Error())
+	}
+
+	baseAmount, quoteAmount, redeemErr := keeper.GetRedeemableAssets(ctx, swapTokenPair, liquidity)
+	if redeemErr != nil {
+		return nil, sdk.ErrUnknownRequest(redeemErr.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(sdk.DecCoins{baseAmount, quoteAmount})
+	return bz, nil
+}
+
+// queryTWAP queries the time-weighted average prices of the pool of the base token and the quote token in the path,
+// either between the start time and the end time, or over the window in seconds until the current block time
+func queryTWAP(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var startTime, endTime int64
+	switch len(path) {
+	case 3:
+		window, parseErr := strconv.ParseInt(path[2], 10, 64)
+		if parseErr != nil || window <= 0 || window > types.MaxTWAPWindow {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid window: %s", path[2]))
+		}
+		endTime = ctx.BlockTime().Unix()
+		startTime = endTime - window
+	case 4:
+		var parseErr error
+		if startTime, parseErr = strconv.ParseInt(path[2], 10, 64); parseErr != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid start time: %s", path[2]))
+		}
+		if endTime, parseErr = strconv.ParseInt(path[3], 10, 64); parseErr != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid end time: %s", path[3]))
+		}
+	default:
+		return nil, sdk.ErrUnknownRequest("base token, quote token and either the window or the start and end time " +
+			"are required")
+	}
+
+	twap, twapErr := keeper.GetTWAP(ctx, path[0], path[1], startTime, endTime)
+	if twapErr != nil {
+		return nil, sdk.ErrUnknownRequest(twapErr.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(twap)
+	return bz, nil
+}
+
+// parseSwapQueryPath parses the amount of one token and the other token of the swap in the path
+func parseSwapQueryPath(path []string) (sdk.DecCoin, string, sdk.Error) {
+	if len(path) < 2 {
+		return sdk.DecCoin{}, "", sdk.ErrUnknownRequest("both the token amount and the other token are required")
+	}
+	tokenAmount, err := sdk.ParseDecCoin(path[0])
+	if err != nil || !tokenAmount.IsPositive() {
+		return sdk.DecCoin{}, "", sdk.ErrUnknownRequest(fmt.Sprintf("invalid token amount: %s", path[0]))
+	}
+	token := path[1]
+	if sdk.ValidateDenom(token) != nil || token == tokenAmount.Denom {
+		return sdk.DecCoin{}, "", sdk.ErrUnknownRequest(fmt.Sprintf("invalid token: %s", token))
+	}
+	return tokenAmount, token, nil
+}
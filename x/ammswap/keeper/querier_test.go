@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply"
@@ -17,6 +18,7 @@ func TestNewQuerier(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	keeper.SetParams(ctx, types.DefaultParams())
 
 	// querier with wrong path
 	querier := NewQuerier(keeper)
@@ -26,11 +28,16 @@ func TestNewQuerier(t *testing.T) {
 	require.Nil(t, tokenpair)
 
 	// querier with wrong token
-	path := []string{types.QuerySwapTokenPair, common.TestToken}
+	path := []string{types.QuerySwapTokenPair, common.TestToken, common.NativeToken}
 	tokenpair, err = querier(ctx, path, abci.RequestQuery{})
 	require.NotNil(t, err)
 	require.Nil(t, tokenpair)
 
+	// querier without quote token
+	tokenpair, err = querier(ctx, []string{types.QuerySwapTokenPair, common.TestToken}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	require.Nil(t, tokenpair)
+
 	// add new tokenpair and querier
 	tokenPair := common.TestToken + "_" + common.NativeToken
 	swapTokenPair := initTokenPair(common.TestToken)
@@ -44,6 +51,29 @@ func TestNewQuerier(t *testing.T) {
 	keeper.cdc.MustUnmarshalJSON(tokenpair, result)
 	require.EqualValues(t, result.BasePooledCoin.Denom, common.TestToken)
 
+	// querier the route to swap
+	routePath := []string{types.QuerySwapRoute, "1" + common.NativeToken, common.TestToken}
+	res, err := querier(ctx, routePath, abci.RequestQuery{})
+	require.NotNil(t, err)
+	require.Nil(t, res)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(100)
+	keeper.SetSwapTokenPair(ctx, tokenPair, swapTokenPair)
+	res, err = querier(ctx, routePath, abci.RequestQuery{})
+	require.Nil(t, err)
+	route := types.SwapRoute{}
+	keeper.cdc.MustUnmarshalJSON(res, &route)
+	require.Equal(t, []string{common.NativeToken, common.TestToken}, route.Path)
+	require.True(t, route.BoughtTokenAmount.IsPositive())
+
+	// querier the route with invalid amount or token
+	_, err = querier(ctx, []string{types.QuerySwapRoute, "abc", common.TestToken}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{types.QuerySwapRoute, "1" + common.NativeToken, common.NativeToken}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{types.QuerySwapRoute, "1" + common.NativeToken}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
 	// delete tokenpair and querier
 	keeper.DeleteSwapTokenPair(ctx, tokenPair)
 	tokenpair, err = querier(ctx, path, abci.RequestQuery{})
@@ -64,3 +94,146 @@ func initTokenPair(token string) types.SwapTokenPair {
 
 	return swapTokenPair
 }
+
+func TestQuerySwapQuote(t *testing.T) {
+	mapp, addrKeysSlice := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	keeper.SetParams(ctx, types.DefaultParams())
+	feeRate := types.DefaultParams().FeeRate
+	querier := NewQuerier(keeper)
+
+	xxbPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(2000)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	yybPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(1000)), types.PoolTokenPrefix+types.TestBasePooledToken2)
+	for _, swapTokenPair := range []types.SwapTokenPair{xxbPair, yybPair} {
+		keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	}
+
+	// quote the swap in the pool of xxb
+	path := []string{types.QuerySwapQuote, "100" + types.TestQuotePooledToken, types.TestBasePooledToken}
+	res, err := querier(ctx, path, abci.RequestQuery{})
+	require.Nil(t, err)
+	var quote types.SwapQuote
+	keeper.cdc.MustUnmarshalJSON(res, &quote)
+	expectedAmount := GetInputPrice(sdk.NewDec(100), xxbPair.QuotePooledCoin.Amount, xxbPair.BasePooledCoin.Amount, feeRate)
+	require.Equal(t, []string{types.TestQuotePooledToken, types.TestBasePooledToken}, quote.Path)
+	require.Equal(t, sdk.NewDecCoinFromDec(types.TestBasePooledToken, expectedAmount), quote.BoughtTokenAmount)
+	require.Equal(t, sdk.DecCoins{sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100).Mul(feeRate))}, quote.Fees)
+	spotAmount := sdk.NewDec(200)
+	require.Equal(t, spotAmount.Sub(expectedAmount).Quo(spotAmount), quote.PriceImpact)
+	require.Equal(t, 1, len(quote.SwapTokenPairs))
+	require.Equal(t, sdk.NewDec(1100), quote.SwapTokenPairs[0].QuotePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(2000).Sub(expectedAmount), quote.SwapTokenPairs[0].BasePooledCoin.Amount)
+
+	// the pools are not changed by the query
+	storedPair, getErr := keeper.GetSwapTokenPair(ctx, xxbPair.TokenPairName())
+	require.Nil(t, getErr)
+	require.Equal(t, xxbPair, storedPair)
+
+	// quote the swap for exactly the amount to buy through the native token, the fee is paid in both pools
+	path = []string{types.QuerySwapQuoteExactOutput, "10" + types.TestBasePooledToken2, types.TestBasePooledToken}
+	res, err = querier(ctx, path, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(res, &quote)
+	_, expectedTokenSold, calcErr := keeper.CalculateSwapRouteExactOutput(ctx,
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10)),
+		[]string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2})
+	require.Nil(t, calcErr)
+	require.Equal(t, []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}, quote.Path)
+	require.Equal(t, expectedTokenSold, quote.SoldTokenAmount)
+	require.Equal(t, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10)), quote.BoughtTokenAmount)
+	require.Equal(t, 2, len(quote.Fees))
+	require.True(t, quote.PriceImpact.IsPositive())
+	require.Equal(t, 2, len(quote.SwapTokenPairs))
+
+	// invalid quotes
+	invalidPaths := [][]string{
+		{types.QuerySwapQuote, "100" + types.TestQuotePooledToken},
+		{types.QuerySwapQuote, "abc", types.TestBasePooledToken},
+		{types.QuerySwapQuote, "100" + types.TestQuotePooledToken, types.TestBasePooledToken3},
+		{types.QuerySwapQuoteExactOutput, "2000" + types.TestBasePooledToken, types.TestQuotePooledToken},
+	}
+	for _, invalidPath := range invalidPaths {
+		_, err = querier(ctx, invalidPath, abci.RequestQuery{})
+		require.NotNil(t, err)
+	}
+
+	// query the assets redeemable by burning the pool token
+	addr := addrKeysSlice[0].Address
+	keeper.NewPoolToken(ctx, xxbPair.PoolTokenName)
+	require.Nil(t, keeper.MintPoolCoinsToUser(ctx, sdk.DecCoins{sdk.NewDecCoinFromDec(xxbPair.PoolTokenName, sdk.NewDec(100))}, addr))
+	path = []string{types.QueryRedeemableAssets, types.TestBasePooledToken, types.TestQuotePooledToken, "10"}
+	res, err = querier(ctx, path, abci.RequestQuery{})
+	require.Nil(t, err)
+	var assets sdk.DecCoins
+	keeper.cdc.MustUnmarshalJSON(res, &assets)
+	expectedAssets := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(200)),
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)),
+	}
+	require.Equal(t, expectedAssets, assets)
+
+	invalidPaths = [][]string{
+		{types.QueryRedeemableAssets, types.TestBasePooledToken, types.TestQuotePooledToken},
+		{types.QueryRedeemableAssets, types.TestBasePooledToken, types.TestQuotePooledToken, "abc"},
+		{types.QueryRedeemableAssets, types.TestBasePooledToken, types.TestQuotePooledToken, "1000"},
+		{types.QueryRedeemableAssets, types.TestBasePooledToken3, types.TestQuotePooledToken, "10"},
+	}
+	for _, invalidPath := range invalidPaths {
+		_, err = querier(ctx, invalidPath, abci.RequestQuery{})
+		require.NotNil(t, err)
+	}
+}
+
+func TestQueryTWAP(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	keeper.SetParams(ctx, types.DefaultParams())
+	querier := NewQuerier(keeper)
+
+	swapTokenPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(200)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	swapTokenPair.LastUpdateTime = ctx.BlockTime().Unix()
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	keeper.ObservePrice(ctx, swapTokenPair)
+	ctx = ctx.WithBlockTime(time.Unix(1600, 0))
+
+	// query with the start and end time
+	path := []string{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken, "1000", "1300"}
+	res, err := querier(ctx, path, abci.RequestQuery{})
+	require.Nil(t, err)
+	var twap types.TWAP
+	keeper.cdc.MustUnmarshalJSON(res, &twap)
+	require.Equal(t, int64(1000), twap.StartTime)
+	require.Equal(t, int64(1300), twap.EndTime)
+	require.Equal(t, sdk.NewDec(2), twap.Price)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), twap.InversePrice)
+
+	// query with the window until the block time
+	path = []string{types.QueryTWAP, types.TestQuotePooledToken, types.TestBasePooledToken, "600"}
+	res, err = querier(ctx, path, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(res, &twap)
+	require.Equal(t, int64(1000), twap.StartTime)
+	require.Equal(t, int64(1600), twap.EndTime)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), twap.Price)
+
+	// invalid queries
+	invalidPaths := [][]string{
+		{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken},
+		{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken, "0"},
+		{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken, "700"},
+		{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken, "a", "1300"},
+		{types.QueryTWAP, types.TestBasePooledToken, types.TestQuotePooledToken, "1000", "1700"},
+	}
+	for _, path := range invalidPaths {
+		_, err = querier(ctx, path, abci.RequestQuery{})
+		require.NotNil(t, err)
+	}
+}
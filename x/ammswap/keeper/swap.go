@@ -1,8 +1,13 @@
 package keeper
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 // IsTokenExist check token is exist
@@ -19,3 +24,288 @@ func (k Keeper) IsTokenExist(ctx sdk.Context, token string) error {
 	return nil
 
 }
+
+// GetSwapTokenPairOfTokens gets the SwapTokenPair between the two tokens, no matter which one is the base token
+func (k Keeper) GetSwapTokenPairOfTokens(ctx sdk.Context, tokenA, tokenB string) (types.SwapTokenPair, error) {
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenA+"_"+tokenB)
+	if err == nil {
+		return swapTokenPair, nil
+	}
+	return k.GetSwapTokenPair(ctx, tokenB+"_"+tokenA)
+}
+
+// GeneratePoolTokenName generates an unused name of the pool token of the token pair.
+// The pool token of the pair against the native token is named after the base token, e.g. ammswap-xxb. The pool
+// token of other pairs is named after the base token with a suffix from the hash of the pair, e.g. ammswap-xxb-1a2,
+// since the pool token has to be a valid denomination
+func (k Keeper) GeneratePoolTokenName(ctx sdk.Context, baseToken, quoteToken string) (name string, valid bool) {
+	if quoteToken == common.NativeToken {
+		name = types.PoolTokenPrefix + baseToken
+		return name, !k.tokenKeeper.TokenExist(ctx, name)
+	}
+
+	symbol := strings.Split(baseToken, "-")[0]
+	hash := fmt.Sprintf("%x", tmhash.Sum([]byte(baseToken+"_"+quoteToken)))
+	for i := len(hash)/3 - 1; i >= 0; i-- {
+		name = types.PoolTokenPrefix + symbol + "-" + hash[3*i:3*i+3]
+		if types.ValidatePoolTokenName(name) && !k.tokenKeeper.TokenExist(ctx, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// GetSwapPath returns the path to swap between the two tokens, it goes through the pools against the native token if
+// there isn't any pool of the two tokens
+func (k Keeper) GetSwapPath(ctx sdk.Context, soldToken, boughtToken string) []string {
+	_, err := k.GetSwapTokenPairOfTokens(ctx, soldToken, boughtToken)
+	if err != nil && soldToken != common.NativeToken && boughtToken != common.NativeToken {
+		return []string{soldToken, common.NativeToken, boughtToken}
+	}
+	return []string{soldToken, boughtToken}
+}
+
+// CalculateSwapRoute calculates the amount bought by swapping the sold token along the path of different tokens, in
+// the pools of every two adjacent tokens one by one. Each hop is calculated against the reserves left by the previous
+// hops, and the pools with the reserves after swapping are returned in the order of the path
+func (k Keeper) CalculateSwapRoute(ctx sdk.Context, soldTokenAmount sdk.DecCoin, path []string) (
+	[]types.SwapTokenPair, sdk.DecCoin, error) {
+	if len(path) < 2 || path[0] != soldTokenAmount.Denom {
+		return nil, sdk.DecCoin{}, fmt.Errorf("invalid swap path: %s", strings.Join(path, ","))
+	}
+
+	feeRate := k.GetParams(ctx).FeeRate
+	blockTime := ctx.BlockTime().Unix()
+	swapTokenPairs := make([]types.SwapTokenPair, 0, len(path)-1)
+	tokenAmount := soldTokenAmount
+	for i := 1; i < len(path); i++ {
+		swapTokenPair, err := k.GetSwapTokenPairOfTokens(ctx, path[i-1], path[i])
+		if err != nil {
+			return nil, sdk.DecCoin{}, fmt.Errorf("failed to find the exchange of %s and %s: %s", path[i-1], path[i],
+				err.Error())
+		}
+		swapTokenPair.UpdatePriceCumulative(blockTime)
+		tokenAmount = swapInPool(&swapTokenPair, tokenAmount, feeRate)
+		if !tokenAmount.IsPositive() {
+			return nil, sdk.DecCoin{}, fmt.Errorf("insufficient liquidity in the exchange %s",
+				swapTokenPair.TokenPairName())
+		}
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+	}
+	return swapTokenPairs, tokenAmount, nil
+}
+
+// CalculateSwapRouteExactOutput calculates the amount of the sold token needed to buy exactly the bought token along
+// the path of different tokens. The hops are calculated backwards from the last pool, and the pools with the reserves
+// after swapping are returned in the order of the path
+func (k Keeper) CalculateSwapRouteExactOutput(ctx sdk.Context, boughtTokenAmount sdk.DecCoin, path []string) (
+	[]types.SwapTokenPair, sdk.DecCoin, error) {
+	if len(path) < 2 || path[len(path)-1] != boughtTokenAmount.Denom {
+		return nil, sdk.DecCoin{}, fmt.Errorf("invalid swap path: %s", strings.Join(path, ","))
+	}
+
+	feeRate := k.GetParams(ctx).FeeRate
+	blockTime := ctx.BlockTime().Unix()
+	swapTokenPairs := make([]types.SwapTokenPair, len(path)-1)
+	tokenAmount := boughtTokenAmount
+	for i := len(path) - 1; i > 0; i-- {
+		swapTokenPair, err := k.GetSwapTokenPairOfTokens(ctx, path[i-1], path[i])
+		if err != nil {
+			return nil, sdk.DecCoin{}, fmt.Errorf("failed to find the exchange of %s and %s: %s", path[i-1], path[i],
+				err.Error())
+		}
+		swapTokenPair.UpdatePriceCumulative(blockTime)
+		if tokenAmount, err = swapInPoolExactOutput(&swapTokenPair, tokenAmount, feeRate); err != nil {
+			return nil, sdk.DecCoin{}, err
+		}
+		swapTokenPairs[i-1] = swapTokenPair
+	}
+	return swapTokenPairs, tokenAmount, nil
+}
+
+// GetBestSwapRoute finds the route over the existing pools to buy the most of the bought token with the sold token,
+// within MaxSwapPathLength tokens. The shorter route is preferred if the same amount is bought
+func (k Keeper) GetBestSwapRoute(ctx sdk.Context, soldTokenAmount sdk.DecCoin, boughtToken string) (types.SwapRoute,
+	error) {
+	// the tokens which each token can be swapped with directly
+	adjacentTokens := make(map[string][]string)
+	iterator := k.GetSwapTokenPairsIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var swapTokenPair types.SwapTokenPair
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &swapTokenPair)
+		baseToken, quoteToken := swapTokenPair.BasePooledCoin.Denom, swapTokenPair.QuotePooledCoin.Denom
+		adjacentTokens[baseToken] = append(adjacentTokens[baseToken], quoteToken)
+		adjacentTokens[quoteToken] = append(adjacentTokens[quoteToken], baseToken)
+	}
+
+	// search the paths in the order of length, so that a longer route replaces the best one only if it buys more
+	var bestRoute *types.SwapRoute
+	paths := [][]string{{soldTokenAmount.Denom}}
+	for len(paths) > 0 {
+		var nextPaths [][]string
+		for _, path := range paths {
+			for _, token := range adjacentTokens[path[len(path)-1]] {
+				if containsToken(path, token) {
+					continue
+				}
+				newPath := append(append(make([]string, 0, len(path)+1), path...), token)
+				if token != boughtToken {
+					if len(newPath) < types.MaxSwapPathLength {
+						nextPaths = append(nextPaths, newPath)
+					}
+					continue
+				}
+				_, boughtTokenAmount, err := k.CalculateSwapRoute(ctx, soldTokenAmount, newPath)
+				if err == nil && (bestRoute == nil || boughtTokenAmount.Amount.GT(bestRoute.BoughtTokenAmount.Amount)) {
+					bestRoute = &types.SwapRoute{
+						Path:              newPath,
+						SoldTokenAmount:   soldTokenAmount,
+						BoughtTokenAmount: boughtTokenAmount,
+					}
+				}
+			}
+		}
+		paths = nextPaths
+	}
+
+	if bestRoute == nil {
+		return types.SwapRoute{}, fmt.Errorf("no swap route from %s to %s", soldTokenAmount.Denom, boughtToken)
+	}
+	return *bestRoute, nil
+}
+
+// GetSwapQuote quotes the swap of the amount sold for the amount bought along the path, with the fee paid in each pool,
+// the price impact and the pools after the swap calculated by CalculateSwapRoute or CalculateSwapRouteExactOutput
+func (k Keeper) GetSwapQuote(ctx sdk.Context, path []string, tokenSold, tokenBuy sdk.DecCoin,
+	swapTokenPairs []types.SwapTokenPair) (types.SwapQuote, error) {
+	feeRate := k.GetParams(ctx).FeeRate
+	fees := sdk.DecCoins{}
+	// the amount bought at the spot price of the pools before the swap, without any fee
+	spotAmount := tokenSold.Amount
+	for i, swapTokenPair := range swapTokenPairs {
+		oldSwapTokenPair, err := k.GetSwapTokenPairOfTokens(ctx, path[i], path[i+1])
+		if err != nil {
+			return types.SwapQuote{}, err
+		}
+		inputReserve, outputReserve := oldSwapTokenPair.QuotePooledCoin, oldSwapTokenPair.BasePooledCoin
+		inputAmount := swapTokenPair.QuotePooledCoin.Amount.Sub(inputReserve.Amount)
+		if path[i] == oldSwapTokenPair.BasePooledCoin.Denom {
+			inputReserve, outputReserve = outputReserve, inputReserve
+			inputAmount = swapTokenPair.BasePooledCoin.Amount.Sub(inputReserve.Amount)
+		}
+		if !inputReserve.IsPositive() {
+			return types.SwapQuote{}, fmt.Errorf("insufficient liquidity in the exchange %s",
+				oldSwapTokenPair.TokenPairName())
+		}
+		fees = fees.Add(sdk.DecCoins{sdk.NewDecCoinFromDec(path[i], inputAmount.Mul(feeRate))})
+		spotAmount = MulAndQuo(spotAmount, outputReserve.Amount, inputReserve.Amount)
+	}
+
+	priceImpact := sdk.ZeroDec()
+	if spotAmount.IsPositive() {
+		priceImpact = spotAmount.Sub(tokenBuy.Amount).Quo(spotAmount)
+	}
+	return types.SwapQuote{
+		Path:              path,
+		SoldTokenAmount:   tokenSold,
+		BoughtTokenAmount: tokenBuy,
+		Fees:              fees,
+		PriceImpact:       priceImpact,
+		SwapTokenPairs:    swapTokenPairs,
+	}, nil
+}
+
+// GetRedeemableAssets returns the amounts of the base token and the quote token withdrawn by burning the liquidity of
+// the pool token
+func (k Keeper) GetRedeemableAssets(ctx sdk.Context, swapTokenPair types.SwapTokenPair, liquidity sdk.Dec) (
+	baseAmount, quoteAmount sdk.DecCoin, err error) {
+	poolTokenAmount := k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
+	if poolTokenAmount.LT(liquidity) {
+		return baseAmount, quoteAmount, fmt.Errorf("insufficient pool token: %s%s in total",
+			poolTokenAmount, swapTokenPair.PoolTokenName)
+	}
+
+	baseDec := MulAndQuo(swapTokenPair.BasePooledCoin.Amount, liquidity, poolTokenAmount)
+	quoteDec := MulAndQuo(swapTokenPair.QuotePooledCoin.Amount, liquidity, poolTokenAmount)
+	baseAmount = sdk.NewDecCoinFromDec(swapTokenPair.BasePooledCoin.Denom, baseDec)
+	quoteAmount = sdk.NewDecCoinFromDec(swapTokenPair.QuotePooledCoin.Denom, quoteDec)
+	return baseAmount, quoteAmount, nil
+}
+
+// swapInPool swaps the sold token for the other token of the pool, and updates the reserves of the pool
+func swapInPool(swapTokenPair *types.SwapTokenPair, soldTokenAmount sdk.DecCoin, feeRate sdk.Dec) sdk.DecCoin {
+	if soldTokenAmount.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		boughtAmount := GetInputPrice(soldTokenAmount.Amount, swapTokenPair.QuotePooledCoin.Amount,
+			swapTokenPair.BasePooledCoin.Amount, feeRate)
+		tokenBuy := sdk.NewDecCoinFromDec(swapTokenPair.BasePooledCoin.Denom, boughtAmount)
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
+		return tokenBuy
+	}
+
+	boughtAmount := GetInputPrice(soldTokenAmount.Amount, swapTokenPair.BasePooledCoin.Amount,
+		swapTokenPair.QuotePooledCoin.Amount, feeRate)
+	tokenBuy := sdk.NewDecCoinFromDec(swapTokenPair.QuotePooledCoin.Denom, boughtAmount)
+	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldTokenAmount)
+	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
+	return tokenBuy
+}
+
+// swapInPoolExactOutput swaps the other token of the pool for exactly the bought token, and updates the reserves of the
+// pool. It returns the amount of token sold
+func swapInPoolExactOutput(swapTokenPair *types.SwapTokenPair, boughtTokenAmount sdk.DecCoin, feeRate sdk.Dec) (
+	sdk.DecCoin, error) {
+	inputCoin, outputCoin := &swapTokenPair.QuotePooledCoin, &swapTokenPair.BasePooledCoin
+	if boughtTokenAmount.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		inputCoin, outputCoin = outputCoin, inputCoin
+	}
+	if !boughtTokenAmount.Amount.LT(outputCoin.Amount) {
+		return sdk.DecCoin{}, fmt.Errorf("insufficient liquidity in the exchange %s to buy %s",
+			swapTokenPair.TokenPairName(), boughtTokenAmount)
+	}
+
+	soldAmount := GetOutputPrice(boughtTokenAmount.Amount, inputCoin.Amount, outputCoin.Amount, feeRate)
+	if !soldAmount.IsPositive() {
+		return sdk.DecCoin{}, fmt.Errorf("insufficient liquidity in the exchange %s", swapTokenPair.TokenPairName())
+	}
+	tokenSold := sdk.NewDecCoinFromDec(inputCoin.Denom, soldAmount)
+	*inputCoin = inputCoin.Add(tokenSold)
+	*outputCoin = outputCoin.Sub(boughtTokenAmount)
+	return tokenSold, nil
+}
+
+func containsToken(path []string, token string) bool {
+	for _, t := range path {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInputPrice returns the amount of the output token bought with inputAmount, charged with feeRate
+func GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := inputAmount.Mul(sdk.OneDec().Sub(feeRate).Mul(sdk.NewDec(1000)))
+	denominator := inputReserve.Mul(sdk.NewDec(1000)).Add(inputAmountWithFee)
+	return MulAndQuo(inputAmountWithFee, outputReserve, denominator)
+}
+
+// GetOutputPrice returns the amount of the input token needed to buy outputAmount, charged with feeRate. It's the
+// inverse of GetInputPrice and rounded up, so that the product of the reserves never decreases
+func GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
+	numerator := inputReserve.Mul(outputAmount)
+	denominator := outputReserve.Sub(outputAmount).Mul(sdk.OneDec().Sub(feeRate))
+	return numerator.QuoRoundUp(denominator)
+}
+
+var (
+	// 10^8
+	auxiliaryDec = sdk.NewDec(100000000)
+)
+
+// MulAndQuo returns a * b / c
+func MulAndQuo(a, b, c sdk.Dec) sdk.Dec {
+	a = a.Mul(auxiliaryDec)
+	return a.Mul(b).Quo(c).Quo(auxiliaryDec)
+}
@@ -58,3 +58,129 @@ func genToken(mapp *TestInput, ctx sdk.Context, tokennames []string, tokentypes
 		mapp.tokenKeeper.NewToken(ctx, tok)
 	}
 }
+
+func TestGetInputPrice(t *testing.T) {
+	defaultFeeRate := sdk.NewDecWithPrec(3, 3)
+	inputAmount := sdk.NewDecWithPrec(1, 8)
+	inputReserve := sdk.NewDec(100)
+	outputReserve := sdk.NewDec(100)
+	res := GetInputPrice(inputAmount, inputReserve, outputReserve, defaultFeeRate)
+	require.Equal(t, inputAmount, res)
+}
+
+func TestKeeper_SwapRoute(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper.SetParams(ctx, types.DefaultParams())
+	feeRate := types.DefaultParams().FeeRate
+
+	// the pools against the native token are deep, while the pool of xxb and yyb is shallow
+	xxbPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1000)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	yybPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(1000)), types.PoolTokenPrefix+types.TestBasePooledToken2)
+	directPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10)), types.PoolTokenPrefix+types.TestBasePooledToken+"-a1b")
+	for _, swapTokenPair := range []types.SwapTokenPair{xxbPair, yybPair, directPair} {
+		keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	}
+
+	// the second hop is calculated against the pool of yyb and the native token
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+	path := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}
+	swapTokenPairs, tokenBuy, err := keeper.CalculateSwapRoute(ctx, soldTokenAmount, path)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(swapTokenPairs))
+	nativeAmount := GetInputPrice(soldTokenAmount.Amount, xxbPair.BasePooledCoin.Amount, xxbPair.QuotePooledCoin.Amount, feeRate)
+	expectedAmount := GetInputPrice(nativeAmount, yybPair.QuotePooledCoin.Amount, yybPair.BasePooledCoin.Amount, feeRate)
+	require.Equal(t, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, expectedAmount), tokenBuy)
+	require.Equal(t, xxbPair.BasePooledCoin.Add(soldTokenAmount), swapTokenPairs[0].BasePooledCoin)
+	require.Equal(t, yybPair.BasePooledCoin.Sub(tokenBuy), swapTokenPairs[1].BasePooledCoin)
+
+	// the pools in the store are not changed by the calculation
+	storedPair, err := keeper.GetSwapTokenPair(ctx, xxbPair.TokenPairName())
+	require.Nil(t, err)
+	require.Equal(t, xxbPair, storedPair)
+
+	// invalid path and unknown pool
+	_, _, err = keeper.CalculateSwapRoute(ctx, soldTokenAmount, []string{types.TestBasePooledToken})
+	require.NotNil(t, err)
+	_, _, err = keeper.CalculateSwapRoute(ctx, soldTokenAmount, []string{types.TestBasePooledToken2, types.TestQuotePooledToken})
+	require.NotNil(t, err)
+	_, _, err = keeper.CalculateSwapRoute(ctx, soldTokenAmount, []string{types.TestBasePooledToken, types.TestBasePooledToken3})
+	require.NotNil(t, err)
+
+	// the route through the deep pools buys more with a large amount
+	route, err := keeper.GetBestSwapRoute(ctx, soldTokenAmount, types.TestBasePooledToken2)
+	require.Nil(t, err)
+	require.Equal(t, path, route.Path)
+	require.Equal(t, tokenBuy, route.BoughtTokenAmount)
+
+	// the direct route buys more with a small amount, since the fee is charged only once
+	smallAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDecWithPrec(1, 3))
+	route, err = keeper.GetBestSwapRoute(ctx, smallAmount, types.TestBasePooledToken2)
+	require.Nil(t, err)
+	require.Equal(t, []string{types.TestBasePooledToken, types.TestBasePooledToken2}, route.Path)
+
+	// no route to the token without any pool
+	_, err = keeper.GetBestSwapRoute(ctx, soldTokenAmount, types.TestBasePooledToken3)
+	require.NotNil(t, err)
+}
+
+func TestGetOutputPrice(t *testing.T) {
+	defaultFeeRate := sdk.NewDecWithPrec(3, 3)
+	outputAmount := sdk.NewDec(10)
+	inputReserve := sdk.NewDec(1000)
+	outputReserve := sdk.NewDec(2000)
+	inputAmount := GetOutputPrice(outputAmount, inputReserve, outputReserve, defaultFeeRate)
+	require.True(t, inputAmount.IsPositive())
+
+	// selling the amount calculated buys at least the output amount, since it's rounded up
+	require.True(t, GetInputPrice(inputAmount, inputReserve, outputReserve, defaultFeeRate).GTE(outputAmount))
+	lessInputAmount := inputAmount.Sub(sdk.NewDecWithPrec(1, 8))
+	require.True(t, GetInputPrice(lessInputAmount, inputReserve, outputReserve, defaultFeeRate).LT(outputAmount))
+}
+
+func TestKeeper_CalculateSwapRouteExactOutput(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper.SetParams(ctx, types.DefaultParams())
+	feeRate := types.DefaultParams().FeeRate
+
+	xxbPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(2000)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	yybPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(3000)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(1000)), types.PoolTokenPrefix+types.TestBasePooledToken2)
+	for _, swapTokenPair := range []types.SwapTokenPair{xxbPair, yybPair} {
+		keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	}
+
+	// the hops are calculated backwards from the pool of yyb
+	boughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10))
+	path := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}
+	swapTokenPairs, tokenSold, err := keeper.CalculateSwapRouteExactOutput(ctx, boughtTokenAmount, path)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(swapTokenPairs))
+	nativeAmount := GetOutputPrice(boughtTokenAmount.Amount, yybPair.QuotePooledCoin.Amount, yybPair.BasePooledCoin.Amount, feeRate)
+	expectedAmount := GetOutputPrice(nativeAmount, xxbPair.BasePooledCoin.Amount, xxbPair.QuotePooledCoin.Amount, feeRate)
+	require.Equal(t, sdk.NewDecCoinFromDec(types.TestBasePooledToken, expectedAmount), tokenSold)
+	require.Equal(t, xxbPair.BasePooledCoin.Add(tokenSold), swapTokenPairs[0].BasePooledCoin)
+	require.Equal(t, xxbPair.QuotePooledCoin.Amount.Sub(nativeAmount), swapTokenPairs[0].QuotePooledCoin.Amount)
+	require.Equal(t, yybPair.QuotePooledCoin.Amount.Add(nativeAmount), swapTokenPairs[1].QuotePooledCoin.Amount)
+	require.Equal(t, yybPair.BasePooledCoin.Sub(boughtTokenAmount), swapTokenPairs[1].BasePooledCoin)
+
+	// the amount to buy has to be less than the reserve
+	allBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, yybPair.BasePooledCoin.Amount)
+	_, _, err = keeper.CalculateSwapRouteExactOutput(ctx, allBoughtTokenAmount, path)
+	require.NotNil(t, err)
+
+	// invalid path and unknown pool
+	_, _, err = keeper.CalculateSwapRouteExactOutput(ctx, boughtTokenAmount, []string{types.TestBasePooledToken2})
+	require.NotNil(t, err)
+	_, _, err = keeper.CalculateSwapRouteExactOutput(ctx, boughtTokenAmount, []string{types.TestBasePooledToken, types.TestBasePooledToken2})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+)
+
+// SetPriceObservation sets the price observation of the token pair at its time
+func (k Keeper) SetPriceObservation(ctx sdk.Context, observation types.PriceObservation) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(observation)
+	store.Set(types.GetPriceObservationKey(observation.TokenPairName, observation.Time), bz)
+}
+
+// GetPriceObservationsIterator gets an iterator over all the price observations, in the order of token pair and time
+func (k Keeper) GetPriceObservationsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.PriceObservationPrefixKey)
+}
+
+// ObservePrice records the price accumulators of the pool at its last update time, and prunes the observations which
+// are no longer needed for any window within MaxTWAPWindow
+func (k Keeper) ObservePrice(ctx sdk.Context, swapTokenPair types.SwapTokenPair) {
+	if swapTokenPair.LastUpdateTime <= 0 {
+		return
+	}
+	observation := types.NewPriceObservation(swapTokenPair)
+	k.SetPriceObservation(ctx, observation)
+
+	// the last observation before the window is kept to calculate the accumulators at the beginning of the window
+	cutoff := observation.Time - types.MaxTWAPWindow
+	if cutoff <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceObservationsKey(observation.TokenPairName),
+		types.GetPriceObservationKey(observation.TokenPairName, cutoff))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for i := 0; i < len(keys)-1; i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetTWAP returns the time-weighted average prices of the pool of the two tokens between startTime and endTime in
+// unix seconds. A price set within a block only takes effect from the block on, so it can't be manipulated within a
+// block
+func (k Keeper) GetTWAP(ctx sdk.Context, baseToken, quoteToken string, startTime, endTime int64) (types.TWAP, error) {
+	if startTime <= 0 {
+		return types.TWAP{}, fmt.Errorf("invalid start time %d", startTime)
+	}
+	if startTime >= endTime {
+		return types.TWAP{}, fmt.Errorf("start time %d should be earlier than end time %d", startTime, endTime)
+	}
+	if blockTime := ctx.BlockTime().Unix(); endTime > blockTime {
+		return types.TWAP{}, fmt.Errorf("end time %d should not be later than the block time %d", endTime, blockTime)
+	}
+	swapTokenPair, err := k.GetSwapTokenPairOfTokens(ctx, baseToken, quoteToken)
+	if err != nil {
+		return types.TWAP{}, fmt.Errorf("failed to find the exchange of %s and %s: %s", baseToken, quoteToken,
+			err.Error())
+	}
+
+	startCumulative, startInverseCumulative, err := k.getPriceCumulativeAt(ctx, swapTokenPair, startTime)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+	endCumulative, endInverseCumulative, err := k.getPriceCumulativeAt(ctx, swapTokenPair, endTime)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+	duration := sdk.NewDec(endTime - startTime)
+	price := endCumulative.Sub(startCumulative).Quo(duration)
+	inversePrice := endInverseCumulative.Sub(startInverseCumulative).Quo(duration)
+	if swapTokenPair.BasePooledCoin.Denom != baseToken {
+		price, inversePrice = inversePrice, price
+	}
+	return types.TWAP{
+		BaseToken:    baseToken,
+		QuoteToken:   quoteToken,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Price:        price,
+		InversePrice: inversePrice,
+	}, nil
+}
+
+// getPriceCumulativeAt returns the price accumulators of the pool at the time. The prices stay the same between two
+// updates, so the accumulators are interpolated linearly between the observations around the time
+func (k Keeper) getPriceCumulativeAt(ctx sdk.Context, swapTokenPair types.SwapTokenPair, time int64) (sdk.Dec,
+	sdk.Dec, error) {
+	tokenPairName := swapTokenPair.TokenPairName()
+	if swapTokenPair.LastUpdateTime <= 0 {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("no price observation of %s", tokenPairName)
+	}
+	if time >= swapTokenPair.LastUpdateTime {
+		elapsed := sdk.NewDec(time - swapTokenPair.LastUpdateTime)
+		price, inversePrice := swapTokenPair.GetPrices()
+		return swapTokenPair.GetPriceCumulative().Add(price.Mul(elapsed)),
+			swapTokenPair.GetInversePriceCumulative().Add(inversePrice.Mul(elapsed)), nil
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetPriceObservationsKey(tokenPairName)
+	beforeIterator := store.ReverseIterator(prefix, types.GetPriceObservationKey(tokenPairName, time+1))
+	defer beforeIterator.Close()
+	if !beforeIterator.Valid() {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("no price observation of %s at %d", tokenPairName, time)
+	}
+	var before types.PriceObservation
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(beforeIterator.Value(), &before)
+	if before.Time == time {
+		return before.PriceCumulative, before.InversePriceCumulative, nil
+	}
+
+	// the pool itself is the latest observation
+	after := types.NewPriceObservation(swapTokenPair)
+	afterIterator := store.Iterator(types.GetPriceObservationKey(tokenPairName, time+1), sdk.PrefixEndBytes(prefix))
+	defer afterIterator.Close()
+	if afterIterator.Valid() {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(afterIterator.Value(), &after)
+	}
+
+	ratio := sdk.NewDec(time - before.Time).QuoInt64(after.Time - before.Time)
+	priceCumulative := before.PriceCumulative.Add(after.PriceCumulative.Sub(before.PriceCumulative).Mul(ratio))
+	inversePriceCumulative := before.InversePriceCumulative.Add(
+		after.InversePriceCumulative.Sub(before.InversePriceCumulative).Mul(ratio))
+	return priceCumulative, inversePriceCumulative, nil
+}
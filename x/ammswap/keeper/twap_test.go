@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestKeeper_GetTWAP(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	// the price of xxb is 2okt when the pool is created
+	swapTokenPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(200)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	swapTokenPair.LastUpdateTime = ctx.BlockTime().Unix()
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	keeper.ObservePrice(ctx, swapTokenPair)
+
+	swap := func(ctx sdk.Context, soldTokenAmount sdk.DecCoin, boughtToken string) types.SwapTokenPair {
+		swapTokenPairs, _, err := keeper.CalculateSwapRoute(ctx, soldTokenAmount,
+			[]string{soldTokenAmount.Denom, boughtToken})
+		require.Nil(t, err)
+		keeper.SetSwapTokenPair(ctx, swapTokenPairs[0].TokenPairName(), swapTokenPairs[0])
+		keeper.ObservePrice(ctx, swapTokenPairs[0])
+		return swapTokenPairs[0]
+	}
+
+	// the price of 2okt lasts for 100 seconds, and the swaps within the same block only accumulate it once
+	ctx = ctx.WithBlockTime(time.Unix(1100, 0))
+	swapTokenPair = swap(ctx, sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)),
+		types.TestBasePooledToken)
+	require.Equal(t, sdk.NewDec(200), swapTokenPair.PriceCumulative)
+	swapTokenPair = swap(ctx, sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(30)),
+		types.TestQuotePooledToken)
+	require.Equal(t, sdk.NewDec(200), swapTokenPair.PriceCumulative)
+	require.Equal(t, sdk.NewDec(50), swapTokenPair.InversePriceCumulative)
+	price, inversePrice := swapTokenPair.GetPrices()
+
+	ctx = ctx.WithBlockTime(time.Unix(1300, 0))
+	twap, err := keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 1000, 1300)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(200).Add(price.MulInt64(200)).QuoInt64(300), twap.Price)
+	require.Equal(t, sdk.NewDec(50).Add(inversePrice.MulInt64(200)).QuoInt64(300), twap.InversePrice)
+
+	// the accumulators between two observations are interpolated
+	twap, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 1050, 1100)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(2), twap.Price)
+	twap, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 1200, 1300)
+	require.Nil(t, err)
+	require.Equal(t, price, twap.Price)
+
+	// the prices are inverted for the reversed tokens
+	twap, err = keeper.GetTWAP(ctx, types.TestQuotePooledToken, types.TestBasePooledToken, 1050, 1100)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), twap.Price)
+	require.Equal(t, sdk.NewDec(2), twap.InversePrice)
+
+	// invalid windows
+	_, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 900, 1100)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 1100, 1100)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestQuotePooledToken, 1100, 1400)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, types.TestBasePooledToken, types.TestBasePooledToken2, 1000, 1100)
+	require.NotNil(t, err)
+}
+
+func TestKeeper_ObservePrice(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+
+	swapTokenPair := *types.NewSwapTokenPair(sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(200)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)), types.PoolTokenPrefix+types.TestBasePooledToken)
+	getObservationTimes := func() []int64 {
+		var times []int64
+		iterator := keeper.GetPriceObservationsIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var observation types.PriceObservation
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &observation)
+			times = append(times, observation.Time)
+		}
+		return times
+	}
+
+	// no observation before the pool is updated
+	keeper.ObservePrice(ctx, swapTokenPair)
+	require.Nil(t, getObservationTimes())
+
+	for _, blockTime := range []int64{1000, 1100, 1200, 1000 + types.MaxTWAPWindow + 150} {
+		swapTokenPair.UpdatePriceCumulative(blockTime)
+		keeper.ObservePrice(ctx, swapTokenPair)
+	}
+	// the latest observation before the window is kept
+	require.Equal(t, []int64{1100, 1200, 1000 + types.MaxTWAPWindow + 150}, getObservationTimes())
+}
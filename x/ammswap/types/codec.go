@@ -10,6 +10,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "okchain/ammswap/MsgRemoveLiquidity", nil)
 	cdc.RegisterConcrete(MsgCreateExchange{}, "okchain/ammswap/MsgCreateExchange", nil)
 	cdc.RegisterConcrete(MsgTokenToNativeToken{}, "okchain/ammswap/MsgSwapToken", nil)
+	cdc.RegisterConcrete(MsgMultiHopSwap{}, "okchain/ammswap/MsgMultiHopSwap", nil)
+	cdc.RegisterConcrete(MsgSwapTokenExactOutput{}, "okchain/ammswap/MsgSwapTokenExactOutput", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -15,14 +19,32 @@ const (
 
 	// QuerySwapTokenPair query endpoints supported by the swap Querier
 	QuerySwapTokenPair = "swapTokenPair"
+	QuerySwapRoute     = "swapRoute"
+
+	QuerySwapQuote            = "swapQuote"
+	QuerySwapQuoteExactOutput = "swapQuoteExactOutput"
+	QueryRedeemableAssets     = "redeemableAssets"
+	QueryTWAP                 = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PriceObservationPrefixKey to be used for KVStore
+	PriceObservationPrefixKey = []byte{0x02}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPriceObservationsKey returns the key prefix of the price observations of the token pair
+func GetPriceObservationsKey(tokenPairName string) []byte {
+	return append(append(PriceObservationPrefixKey, []byte(tokenPairName)...), '/')
+}
+
+// GetPriceObservationKey returns the key of the price observation of the token pair at the time
+func GetPriceObservationKey(tokenPairName string, time int64) []byte {
+	return append(GetPriceObservationsKey(tokenPairName), sdk.Uint64ToBigEndian(uint64(time))...)
+}
@@ -17,10 +17,11 @@ func TestMsgCreateExchange(t *testing.T) {
 	addr, err := hex.DecodeString(addrStr)
 	require.Nil(t, err)
 	testToken := InitPoolToken(TestBasePooledToken)
-	msg := NewMsgCreateExchange(testToken.Symbol, addr)
+	msg := NewMsgCreateExchange(testToken.Symbol, TestQuotePooledToken, addr)
 	require.Nil(t, msg.ValidateBasic())
 	require.Equal(t, RouterKey, msg.Route())
 	require.Equal(t, "create_exchange", msg.Type())
+	require.Equal(t, TestSwapTokenPairName, msg.GetSwapTokenPair())
 
 	bytesMsg := msg.GetSignBytes()
 	resMsg := &MsgCreateExchange{}
@@ -28,6 +29,12 @@ func TestMsgCreateExchange(t *testing.T) {
 	require.Nil(t, err)
 	resAddr := msg.GetSigners()[0]
 	require.EqualValues(t, addr, resAddr)
+
+	// the messages without the quote token create the exchange against the native token, and are signed as before
+	msg = NewMsgCreateExchange(testToken.Symbol, "", addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, TestSwapTokenPairName, msg.GetSwapTokenPair())
+	require.NotContains(t, string(msg.GetSignBytes()), "quote_token")
 }
 
 func TestMsgCreateExchangeInvalid(t *testing.T) {
@@ -36,15 +43,22 @@ func TestMsgCreateExchangeInvalid(t *testing.T) {
 	tests := []struct {
 		testCase         string
 		symbol           string
+		quoteSymbol      string
 		addr             sdk.AccAddress
 		exceptResultCode sdk.CodeType
 	}{
-		{"success", "xxx", addr, sdk.CodeOK},
-		{"nil addr", "xxx", nil, sdk.CodeInvalidAddress},
-		{"invalid token", "1ab", addr, sdk.CodeUnknownRequest},
+		{"success", "xxx", TestQuotePooledToken, addr, sdk.CodeOK},
+		{"success with the quote token other than native token", "xxx", "yyy", addr, sdk.CodeOK},
+		{"success without the quote token", "xxx", "", addr, sdk.CodeOK},
+		{"nil addr", "xxx", TestQuotePooledToken, nil, sdk.CodeInvalidAddress},
+		{"invalid token", "1ab", TestQuotePooledToken, addr, sdk.CodeUnknownRequest},
+		{"invalid quote token", "xxx", "1ab", addr, sdk.CodeUnknownRequest},
+		{"pool token as quote token", "xxx", PoolTokenPrefix + "yyy", addr, sdk.CodeUnknownRequest},
+		{"the same base and quote token", "xxx", "xxx", addr, sdk.CodeUnknownRequest},
+		{"native token without the quote token", TestQuotePooledToken, "", addr, sdk.CodeUnknownRequest},
 	}
 	for _, testCase := range tests {
-		msg := NewMsgCreateExchange(testCase.symbol, testCase.addr)
+		msg := NewMsgCreateExchange(testCase.symbol, testCase.quoteSymbol, testCase.addr)
 		err := msg.ValidateBasic()
 		if err == nil && testCase.exceptResultCode == sdk.CodeOK {
 			continue
@@ -88,6 +102,7 @@ func TestMsgAddLiquidityInvalid(t *testing.T) {
 	invalidQuoteAmount := sdk.NewDecCoinFromDec("bsa", sdk.NewDec(10000))
 	invalidQuoteAmount.Denom = "1dfdf"
 	notNativeQuoteAmount := sdk.NewDecCoinFromDec("abc", sdk.NewDec(10000))
+	sameQuoteAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(10000))
 	deadLine := time.Now().Unix()
 
 	tests := []struct {
@@ -103,7 +118,8 @@ func TestMsgAddLiquidityInvalid(t *testing.T) {
 		{"tokens must be positive", minLiquidity, maxBaseAmount, notPositiveQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MaxBaseAmount", minLiquidity, invalidMaxBaseAmount, quoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid QuoteAmount", minLiquidity, maxBaseAmount, invalidQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
-		{"quote token only supports native token", minLiquidity, maxBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
+		{"success with the quote token other than native token", minLiquidity, maxBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeOK},
+		{"the same base and quote token", minLiquidity, maxBaseAmount, sameQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"empty sender", minLiquidity, maxBaseAmount, quoteAmount, deadLine, nil, sdk.CodeInvalidAddress},
 	}
 	for _, testCase := range tests {
@@ -156,6 +172,7 @@ func TestMsgRemoveLiquidityInvalid(t *testing.T) {
 	invalidMinQuoteAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(1))
 	invalidMinQuoteAmount.Denom = "1sss"
 	notNativeQuoteAmount := sdk.NewDecCoinFromDec("sss", sdk.NewDec(1))
+	sameQuoteAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
 
 	tests := []struct {
 		testCase         string
@@ -171,7 +188,8 @@ func TestMsgRemoveLiquidityInvalid(t *testing.T) {
 		{"coins must be positive", notPositiveLiquidity, minBaseAmount, minQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MinBaseAmount", liquidity, invalidMinBaseAmount, minQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MinQuoteAmount", liquidity, minBaseAmount, invalidMinQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
-		{"quote token only supports native token", liquidity, minBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
+		{"success with the quote token other than native token", liquidity, minBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeOK},
+		{"the same base and quote token", liquidity, minBaseAmount, sameQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 	}
 	for _, testCase := range tests {
 		msg := NewMsgRemoveLiquidity(testCase.liquidity, testCase.minBaseAmount, testCase.minQuoteAmount, testCase.deadLine, testCase.addr)
@@ -218,6 +236,7 @@ func TestMsgTokenToNativeTokenInvalid(t *testing.T) {
 	invalidSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
 	invalidSoldTokenAmount.Denom = "1sdf"
 	notNativeSoldTokenAmount := sdk.NewDecCoinFromDec("abc", sdk.NewDec(2))
+	sameSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
 
 	tests := []struct {
 		testCase             string
@@ -231,7 +250,8 @@ func TestMsgTokenToNativeTokenInvalid(t *testing.T) {
 		{"success", minBoughtTokenAmount, soldTokenAmount, deadLine, addr, addr, sdk.CodeOK},
 		{"empty sender", minBoughtTokenAmount, soldTokenAmount, deadLine, addr, nil, sdk.CodeInvalidAddress},
 		{"empty recipient", minBoughtTokenAmount, soldTokenAmount, deadLine, nil, addr, sdk.CodeInvalidAddress},
-		{"both token to sell and token to buy do not contain native token", minBoughtTokenAmount, notNativeSoldTokenAmount, deadLine, addr, addr, sdk.CodeUnknownRequest},
+		{"success without native token", minBoughtTokenAmount, notNativeSoldTokenAmount, deadLine, addr, addr, sdk.CodeOK},
+		{"the same token to sell and token to buy", minBoughtTokenAmount, sameSoldTokenAmount, deadLine, addr, addr, sdk.CodeUnknownRequest},
 		{"invalid SoldTokenAmount", soldTokenAmount, invalidSoldTokenAmount, deadLine, addr, addr, sdk.CodeUnknownRequest},
 		{"invalid MinBoughtTokenAmount", invalidMinBoughtTokenAmount, soldTokenAmount, deadLine, addr, addr, sdk.CodeUnknownRequest},
 	}
@@ -244,3 +264,136 @@ func TestMsgTokenToNativeTokenInvalid(t *testing.T) {
 		require.Equal(t, testCase.exceptResultCode, err.Code())
 	}
 }
+
+func TestMsgMultiHopSwap(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	path := []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken2}
+	msg := NewMsgMultiHopSwap(soldTokenAmount, minBoughtTokenAmount, path, deadLine, addr, addr)
+
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgMultiHopSwap, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgMultiHopSwap{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	resAddr := msg.GetSigners()[0]
+	require.EqualValues(t, addr, resAddr)
+}
+
+func TestMsgMultiHopSwapInvalid(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	zeroSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec())
+	path := []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken2}
+	directPath := []string{TestBasePooledToken, TestBasePooledToken2}
+	tooLongPath := []string{TestBasePooledToken, "aaa", "bbb", "ccc", "ddd", TestBasePooledToken2}
+	wrongStartPath := []string{TestQuotePooledToken, TestBasePooledToken2}
+	wrongEndPath := []string{TestBasePooledToken, TestQuotePooledToken}
+	duplicatePath := []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken3, TestQuotePooledToken,
+		TestBasePooledToken2}
+	invalidTokenPath := []string{TestBasePooledToken, "1aaa", TestBasePooledToken2}
+
+	tests := []struct {
+		testCase             string
+		minBoughtTokenAmount sdk.DecCoin
+		soldTokenAmount      sdk.DecCoin
+		path                 []string
+		recipient            sdk.AccAddress
+		addr                 sdk.AccAddress
+		exceptResultCode     sdk.CodeType
+	}{
+		{"success", minBoughtTokenAmount, soldTokenAmount, path, addr, addr, sdk.CodeOK},
+		{"success with the direct path", minBoughtTokenAmount, soldTokenAmount, directPath, addr, addr, sdk.CodeOK},
+		{"empty sender", minBoughtTokenAmount, soldTokenAmount, path, addr, nil, sdk.CodeInvalidAddress},
+		{"empty recipient", minBoughtTokenAmount, soldTokenAmount, path, nil, addr, sdk.CodeInvalidAddress},
+		{"zero SoldTokenAmount", minBoughtTokenAmount, zeroSoldTokenAmount, path, addr, addr, sdk.CodeUnknownRequest},
+		{"empty path", minBoughtTokenAmount, soldTokenAmount, nil, addr, addr, sdk.CodeUnknownRequest},
+		{"too long path", minBoughtTokenAmount, soldTokenAmount, tooLongPath, addr, addr, sdk.CodeUnknownRequest},
+		{"path not starting with the token to sell", minBoughtTokenAmount, soldTokenAmount, wrongStartPath, addr, addr, sdk.CodeUnknownRequest},
+		{"path not ending with the token to buy", minBoughtTokenAmount, soldTokenAmount, wrongEndPath, addr, addr, sdk.CodeUnknownRequest},
+		{"duplicate token in path", minBoughtTokenAmount, soldTokenAmount, duplicatePath, addr, addr, sdk.CodeUnknownRequest},
+		{"invalid token in path", minBoughtTokenAmount, soldTokenAmount, invalidTokenPath, addr, addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgMultiHopSwap(testCase.soldTokenAmount, testCase.minBoughtTokenAmount, testCase.path, deadLine, testCase.recipient, testCase.addr)
+		err := msg.ValidateBasic()
+		if err == nil && testCase.exceptResultCode == sdk.CodeOK {
+			continue
+		}
+		require.NotNil(t, err, testCase.testCase)
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
+
+func TestMsgSwapTokenExactOutput(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
+	msg := NewMsgSwapTokenExactOutput(boughtTokenAmount, maxSoldTokenAmount, deadLine, addr, addr)
+
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgTokenSwapExactOutput, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgSwapTokenExactOutput{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	resAddr := msg.GetSigners()[0]
+	require.EqualValues(t, addr, resAddr)
+}
+
+func TestMsgSwapTokenExactOutputInvalid(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
+	invalidBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	invalidBoughtTokenAmount.Denom = "1aaa"
+	invalidMaxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
+	invalidMaxSoldTokenAmount.Denom = "1sdf"
+	zeroBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec())
+	zeroMaxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.ZeroDec())
+	notNativeMaxSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(2))
+	sameMaxSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+
+	tests := []struct {
+		testCase           string
+		boughtTokenAmount  sdk.DecCoin
+		maxSoldTokenAmount sdk.DecCoin
+		recipient          sdk.AccAddress
+		addr               sdk.AccAddress
+		exceptResultCode   sdk.CodeType
+	}{
+		{"success", boughtTokenAmount, maxSoldTokenAmount, addr, addr, sdk.CodeOK},
+		{"success without native token", boughtTokenAmount, notNativeMaxSoldTokenAmount, addr, addr, sdk.CodeOK},
+		{"empty sender", boughtTokenAmount, maxSoldTokenAmount, addr, nil, sdk.CodeInvalidAddress},
+		{"empty recipient", boughtTokenAmount, maxSoldTokenAmount, nil, addr, sdk.CodeInvalidAddress},
+		{"the same token to sell and token to buy", boughtTokenAmount, sameMaxSoldTokenAmount, addr, addr, sdk.CodeUnknownRequest},
+		{"invalid BoughtTokenAmount", invalidBoughtTokenAmount, maxSoldTokenAmount, addr, addr, sdk.CodeUnknownRequest},
+		{"invalid MaxSoldTokenAmount", boughtTokenAmount, invalidMaxSoldTokenAmount, addr, addr, sdk.CodeUnknownRequest},
+		{"zero BoughtTokenAmount", zeroBoughtTokenAmount, maxSoldTokenAmount, addr, addr, sdk.CodeUnknownRequest},
+		{"zero MaxSoldTokenAmount", boughtTokenAmount, zeroMaxSoldTokenAmount, addr, addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgSwapTokenExactOutput(testCase.boughtTokenAmount, testCase.maxSoldTokenAmount, deadLine, testCase.recipient, testCase.addr)
+		err := msg.ValidateBasic()
+		if err == nil && testCase.exceptResultCode == sdk.CodeOK {
+			continue
+		}
+		require.NotNil(t, err, testCase.testCase)
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
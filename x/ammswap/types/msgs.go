@@ -11,6 +11,12 @@ import (
 const (
 	TypeMsgAddLiquidity = "add_liquidity"
 	TypeMsgTokenSwap    = "token_swap"
+	TypeMsgMultiHopSwap = "multi_hop_swap"
+
+	TypeMsgTokenSwapExactOutput = "token_swap_exact_output"
+
+	// MaxSwapPathLength is the maximum number of tokens in the path of a multi-hop swap
+	MaxSwapPathLength = 5
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -53,8 +59,8 @@ func (msg MsgAddLiquidity) ValidateBasic() sdk.Error {
 	if !msg.QuoteAmount.IsValid() {
 		return sdk.ErrUnknownRequest("invalid QuoteAmount")
 	}
-	if msg.QuoteAmount.Denom != common.NativeToken {
-		return sdk.ErrUnknownRequest("quote token only supports " + common.NativeToken)
+	if msg.MaxBaseAmount.Denom == msg.QuoteAmount.Denom {
+		return sdk.ErrUnknownRequest("base token and quote token should not be the same")
 	}
 	return nil
 }
@@ -114,8 +120,8 @@ func (msg MsgRemoveLiquidity) ValidateBasic() sdk.Error {
 	if !msg.MinQuoteAmount.IsValid() {
 		return sdk.ErrUnknownRequest("invalid MinQuoteAmount")
 	}
-	if msg.MinQuoteAmount.Denom != common.NativeToken {
-		return sdk.ErrUnknownRequest("quote token only supports " + common.NativeToken)
+	if msg.MinBaseAmount.Denom == msg.MinQuoteAmount.Denom {
+		return sdk.ErrUnknownRequest("base token and quote token should not be the same")
 	}
 	return nil
 }
@@ -135,17 +141,19 @@ func (msg MsgRemoveLiquidity) GetSwapTokenPair() string {
 	return msg.MinBaseAmount.Denom + "_" + msg.MinQuoteAmount.Denom
 }
 
-// MsgCreateExchange creates a new exchange with token
+// MsgCreateExchange creates a new exchange with token pair
 type MsgCreateExchange struct {
-	Token  string         `json:"token"`  // Token
-	Sender sdk.AccAddress `json:"sender"` // Sender
+	Token      string         `json:"token"`                 // Base token
+	QuoteToken string         `json:"quote_token,omitempty"` // Quote token, the native token if empty
+	Sender     sdk.AccAddress `json:"sender"`                // Sender
 }
 
-// NewMsgCreateExchange create a new exchange with token
-func NewMsgCreateExchange(token string, sender sdk.AccAddress) MsgCreateExchange {
+// NewMsgCreateExchange create a new exchange with token pair
+func NewMsgCreateExchange(token, quoteToken string, sender sdk.AccAddress) MsgCreateExchange {
 	return MsgCreateExchange{
-		Token:  token,
-		Sender: sender,
+		Token:      token,
+		QuoteToken: quoteToken,
+		Sender:     sender,
 	}
 }
 
@@ -163,6 +171,13 @@ func (msg MsgCreateExchange) ValidateBasic() sdk.Error {
 	if sdk.ValidateDenom(msg.Token) != nil || ValidatePoolTokenName(msg.Token) {
 		return sdk.ErrUnknownRequest("invalid Token")
 	}
+	quoteToken := msg.GetQuoteToken()
+	if sdk.ValidateDenom(quoteToken) != nil || ValidatePoolTokenName(quoteToken) {
+		return sdk.ErrUnknownRequest("invalid QuoteToken")
+	}
+	if msg.Token == quoteToken {
+		return sdk.ErrUnknownRequest("base token and quote token should not be the same")
+	}
 	return nil
 }
 
@@ -176,7 +191,22 @@ func (msg MsgCreateExchange) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Sender}
 }
 
-// MsgTokenToNativeToken define the message for swap between token and DefaultBondDenom
+// GetQuoteToken returns the quote token of the exchange. The messages without it create the exchange against the
+// native token as before
+func (msg MsgCreateExchange) GetQuoteToken() string {
+	if msg.QuoteToken == "" {
+		return common.NativeToken
+	}
+	return msg.QuoteToken
+}
+
+// GetSwapTokenPair defines token pair
+func (msg MsgCreateExchange) GetSwapTokenPair() string {
+	return msg.Token + "_" + msg.GetQuoteToken()
+}
+
+// MsgTokenToNativeToken define the message for swap between two tokens, it's swapped in the pool of the two tokens
+// if exists, otherwise through the pools of both tokens against DefaultBondDenom
 type MsgTokenToNativeToken struct {
 	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
 	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // Minimum token purchased.
@@ -214,9 +244,9 @@ func (msg MsgTokenToNativeToken) ValidateBasic() sdk.Error {
 		return sdk.ErrInvalidAddress(msg.Recipient.String())
 	}
 
-	if msg.SoldTokenAmount.Denom != sdk.DefaultBondDenom && msg.MinBoughtTokenAmount.Denom != sdk.DefaultBondDenom {
-		return sdk.ErrUnknownRequest(fmt.Sprintf("both token to sell and token to buy do not contain %s,"+
-			" quote token only supports %s", sdk.DefaultBondDenom, sdk.DefaultBondDenom))
+	if msg.SoldTokenAmount.Denom == msg.MinBoughtTokenAmount.Denom {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("token to sell and token to buy should not be the same: %s",
+			msg.SoldTokenAmount.Denom))
 	}
 	if !(msg.SoldTokenAmount.IsPositive()) {
 		return sdk.ErrUnknownRequest("token amount must be positive")
@@ -241,10 +271,163 @@ func (msg MsgTokenToNativeToken) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Sender}
 }
 
-// GetSwapTokenPair defines token pair
+// GetSwapTokenPair defines the token pair against DefaultBondDenom
 func (msg MsgTokenToNativeToken) GetSwapTokenPair() string {
 	if msg.SoldTokenAmount.Denom == sdk.DefaultBondDenom {
 		return msg.MinBoughtTokenAmount.Denom + "_" + msg.SoldTokenAmount.Denom
 	}
 	return msg.SoldTokenAmount.Denom + "_" + msg.MinBoughtTokenAmount.Denom
 }
+
+// MsgSwapTokenExactOutput define the message for buying exactly the amount of token with at most the amount of token
+// sold, it's swapped in the same pools as MsgTokenToNativeToken
+type MsgSwapTokenExactOutput struct {
+	BoughtTokenAmount  sdk.DecCoin    `json:"bought_token_amount"`   // Amount of Tokens bought.
+	MaxSoldTokenAmount sdk.DecCoin    `json:"max_sold_token_amount"` // Maximum token sold.
+	Deadline           int64          `json:"deadline"`              // Time after which this transaction can no longer be executed.
+	Recipient          sdk.AccAddress `json:"recipient"`             // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender             sdk.AccAddress `json:"sender"`                // Sender
+}
+
+// NewMsgSwapTokenExactOutput is a constructor function for MsgSwapTokenExactOutput
+func NewMsgSwapTokenExactOutput(
+	boughtTokenAmount, maxSoldTokenAmount sdk.DecCoin, deadline int64, recipient, sender sdk.AccAddress,
+) MsgSwapTokenExactOutput {
+	return MsgSwapTokenExactOutput{
+		BoughtTokenAmount:  boughtTokenAmount,
+		MaxSoldTokenAmount: maxSoldTokenAmount,
+		Deadline:           deadline,
+		Recipient:          recipient,
+		Sender:             sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSwapTokenExactOutput) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSwapTokenExactOutput) Type() string { return TypeMsgTokenSwapExactOutput }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSwapTokenExactOutput) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+
+	if msg.BoughtTokenAmount.Denom == msg.MaxSoldTokenAmount.Denom {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("token to sell and token to buy should not be the same: %s",
+			msg.BoughtTokenAmount.Denom))
+	}
+	if !msg.BoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid BoughtTokenAmount")
+	}
+	if !msg.BoughtTokenAmount.IsPositive() {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+
+	if !msg.MaxSoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid MaxSoldTokenAmount")
+	}
+	if !msg.MaxSoldTokenAmount.IsPositive() {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSwapTokenExactOutput) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSwapTokenExactOutput) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgMultiHopSwap defines the message for swap along the path of tokens, the sold token is swapped in the pools of
+// every two adjacent tokens one by one, and it fails as a whole if the final amount bought is less than the minimum
+type MsgMultiHopSwap struct {
+	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
+	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // Minimum token purchased at the end of the path.
+	Path                 []string       `json:"path"`                    // Tokens to swap through, from the sold token to the bought token.
+	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
+	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+}
+
+// NewMsgMultiHopSwap is a constructor function for MsgMultiHopSwap
+func NewMsgMultiHopSwap(
+	soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, path []string, deadline int64, recipient, sender sdk.AccAddress,
+) MsgMultiHopSwap {
+	return MsgMultiHopSwap{
+		SoldTokenAmount:      soldTokenAmount,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		Path:                 path,
+		Deadline:             deadline,
+		Recipient:            recipient,
+		Sender:               sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgMultiHopSwap) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgMultiHopSwap) Type() string { return TypeMsgMultiHopSwap }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgMultiHopSwap) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid SoldTokenAmount")
+	}
+	if !msg.SoldTokenAmount.IsPositive() {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid MinBoughtTokenAmount")
+	}
+	return ValidateSwapPath(msg.Path, msg.SoldTokenAmount.Denom, msg.MinBoughtTokenAmount.Denom)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgMultiHopSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgMultiHopSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateSwapPath checks that the path goes from the sold token to the bought token through different tokens, within
+// MaxSwapPathLength
+func ValidateSwapPath(path []string, soldToken, boughtToken string) sdk.Error {
+	if len(path) < 2 || len(path) > MaxSwapPathLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("the length of the swap path should be between 2 and %d",
+			MaxSwapPathLength))
+	}
+	if path[0] != soldToken || path[len(path)-1] != boughtToken {
+		return sdk.ErrUnknownRequest("the swap path should start with the token to sell and end with the token to buy")
+	}
+	tokens := make(map[string]bool, len(path))
+	for _, token := range path {
+		if sdk.ValidateDenom(token) != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("invalid token in the swap path: %s", token))
+		}
+		if tokens[token] {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("duplicate token in the swap path: %s", token))
+		}
+		tokens[token] = true
+	}
+	return nil
+}
@@ -18,14 +18,20 @@ type SwapTokenPair struct {
 	QuotePooledCoin sdk.DecCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
 	BasePooledCoin  sdk.DecCoin `json:"base_pooled_coin"`  // The volume of base token in the token pair exchange pool
 	PoolTokenName   string      `json:"pool_token_name"`   // The name of pool token
+
+	PriceCumulative        sdk.Dec `json:"price_cumulative"`         // The sum of the price of base token in quote token weighted by seconds
+	InversePriceCumulative sdk.Dec `json:"inverse_price_cumulative"` // The sum of the price of quote token in base token weighted by seconds
+	LastUpdateTime         int64   `json:"last_update_time"`         // The block time in unix seconds when the pool was updated last
 }
 
 // NewSwapTokenPair is a constructor function for SwapTokenPair
 func NewSwapTokenPair(quotePooledCoin sdk.DecCoin, basePooledCoin sdk.DecCoin, poolTokenName string) *SwapTokenPair {
 	swapTokenPair := &SwapTokenPair{
-		QuotePooledCoin: quotePooledCoin,
-		BasePooledCoin:  basePooledCoin,
-		PoolTokenName:   poolTokenName,
+		QuotePooledCoin:        quotePooledCoin,
+		BasePooledCoin:         basePooledCoin,
+		PoolTokenName:          poolTokenName,
+		PriceCumulative:        sdk.ZeroDec(),
+		InversePriceCumulative: sdk.ZeroDec(),
 	}
 	return swapTokenPair
 }
@@ -34,7 +40,11 @@ func NewSwapTokenPair(quotePooledCoin sdk.DecCoin, basePooledCoin sdk.DecCoin, p
 func (s SwapTokenPair) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`QuotePooledCoin: %s
 BasePooledCoin: %s
-PoolTokenName: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName))
+PoolTokenName: %s
+PriceCumulative: %s
+InversePriceCumulative: %s
+LastUpdateTime: %d`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName,
+		s.GetPriceCumulative().String(), s.GetInversePriceCumulative().String(), s.LastUpdateTime))
 }
 
 // TokenPairName defines token pair
@@ -42,6 +52,47 @@ func (s SwapTokenPair) TokenPairName() string {
 	return s.BasePooledCoin.Denom + "_" + s.QuotePooledCoin.Denom
 }
 
+// GetPrices returns the current price of base token in quote token and the inverse, which are zero without liquidity
+func (s SwapTokenPair) GetPrices() (price, inversePrice sdk.Dec) {
+	if !s.BasePooledCoin.IsPositive() || !s.QuotePooledCoin.IsPositive() {
+		return sdk.ZeroDec(), sdk.ZeroDec()
+	}
+	return s.QuotePooledCoin.Amount.Quo(s.BasePooledCoin.Amount), s.BasePooledCoin.Amount.Quo(s.QuotePooledCoin.Amount)
+}
+
+// GetPriceCumulative returns the accumulator of the price of base token in quote token
+func (s SwapTokenPair) GetPriceCumulative() sdk.Dec {
+	if s.PriceCumulative.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return s.PriceCumulative
+}
+
+// GetInversePriceCumulative returns the accumulator of the price of quote token in base token
+func (s SwapTokenPair) GetInversePriceCumulative() sdk.Dec {
+	if s.InversePriceCumulative.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return s.InversePriceCumulative
+}
+
+// UpdatePriceCumulative accumulates the current prices for the seconds since the last update, and moves the last
+// update time to the block time. It must be called before the reserves are changed, so that a price set within a
+// block only takes effect from the block on
+func (s *SwapTokenPair) UpdatePriceCumulative(blockTime int64) {
+	s.PriceCumulative, s.InversePriceCumulative = s.GetPriceCumulative(), s.GetInversePriceCumulative()
+	if blockTime <= s.LastUpdateTime {
+		return
+	}
+	if s.LastUpdateTime > 0 {
+		elapsed := sdk.NewDec(blockTime - s.LastUpdateTime)
+		price, inversePrice := s.GetPrices()
+		s.PriceCumulative = s.PriceCumulative.Add(price.Mul(elapsed))
+		s.InversePriceCumulative = s.InversePriceCumulative.Add(inversePrice.Mul(elapsed))
+	}
+	s.LastUpdateTime = blockTime
+}
+
 // InitPoolToken default pool token
 func InitPoolToken(poolTokenName string) token.Token {
 	return token.Token{
@@ -62,3 +113,38 @@ func ValidatePoolTokenName(tokenName string) bool {
 	var poolTokenRegExp = regexp.MustCompile(poolTokenFormat)
 	return poolTokenRegExp.MatchString(tokenName)
 }
+
+// SwapRoute defines the route to swap the sold token for the bought token in the pools of every two adjacent tokens
+// in the path
+type SwapRoute struct {
+	Path              []string    `json:"path"`                // The tokens swapped through, from the sold token to the bought token
+	SoldTokenAmount   sdk.DecCoin `json:"sold_token_amount"`   // The amount of token sold
+	BoughtTokenAmount sdk.DecCoin `json:"bought_token_amount"` // The amount of token bought at the end of the path
+}
+
+// String implement fmt.Stringer
+func (r SwapRoute) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Path: %s
+SoldTokenAmount: %s
+BoughtTokenAmount: %s`, strings.Join(r.Path, " -> "), r.SoldTokenAmount.String(), r.BoughtTokenAmount.String()))
+}
+
+// SwapQuote defines the expected result of a swap along the path
+type SwapQuote struct {
+	Path              []string        `json:"path"`                // The tokens swapped through, from the sold token to the bought token
+	SoldTokenAmount   sdk.DecCoin     `json:"sold_token_amount"`   // The amount of token sold
+	BoughtTokenAmount sdk.DecCoin     `json:"bought_token_amount"` // The amount of token bought
+	Fees              sdk.DecCoins    `json:"fees"`                // The fee paid in each pool, in the token sold into the pool
+	PriceImpact       sdk.Dec         `json:"price_impact"`        // The ratio of the amount bought below the spot price, the fee included
+	SwapTokenPairs    []SwapTokenPair `json:"swap_token_pairs"`    // The pools after the swap, in the order of the path
+}
+
+// String implement fmt.Stringer
+func (q SwapQuote) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Path: %s
+SoldTokenAmount: %s
+BoughtTokenAmount: %s
+Fees: %s
+PriceImpact: %s`, strings.Join(q.Path, " -> "), q.SoldTokenAmount.String(), q.BoughtTokenAmount.String(),
+		q.Fees.String(), q.PriceImpact.String()))
+}
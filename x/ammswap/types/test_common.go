@@ -18,5 +18,8 @@ func GetTestSwapTokenPair() SwapTokenPair {
 		QuotePooledCoin: sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(0)),
 		BasePooledCoin:  sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(0)),
 		PoolTokenName:   PoolTokenPrefix + TestBasePooledToken,
+
+		PriceCumulative:        sdk.ZeroDec(),
+		InversePriceCumulative: sdk.ZeroDec(),
 	}
 }
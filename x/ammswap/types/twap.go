@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxTWAPWindow is the longest window in seconds over which the time-weighted average price is kept available, the
+// price observations before it are pruned
+const MaxTWAPWindow int64 = 24 * 60 * 60
+
+// PriceObservation is the snapshot of the price accumulators of a pool at its update time
+type PriceObservation struct {
+	TokenPairName          string  `json:"token_pair_name"`          // The name of the token pair
+	Time                   int64   `json:"time"`                     // The block time of the update in unix seconds
+	PriceCumulative        sdk.Dec `json:"price_cumulative"`         // The accumulator of the price of base token in quote token
+	InversePriceCumulative sdk.Dec `json:"inverse_price_cumulative"` // The accumulator of the price of quote token in base token
+}
+
+// NewPriceObservation creates the observation of the price accumulators of the pool at its last update time
+func NewPriceObservation(swapTokenPair SwapTokenPair) PriceObservation {
+	return PriceObservation{
+		TokenPairName:          swapTokenPair.TokenPairName(),
+		Time:                   swapTokenPair.LastUpdateTime,
+		PriceCumulative:        swapTokenPair.GetPriceCumulative(),
+		InversePriceCumulative: swapTokenPair.GetInversePriceCumulative(),
+	}
+}
+
+// TWAP defines the time-weighted average prices of a pool between two block times
+type TWAP struct {
+	BaseToken    string  `json:"base_token"`
+	QuoteToken   string  `json:"quote_token"`
+	StartTime    int64   `json:"start_time"`    // in unix seconds
+	EndTime      int64   `json:"end_time"`      // in unix seconds
+	Price        sdk.Dec `json:"price"`         // The average price of base token in quote token
+	InversePrice sdk.Dec `json:"inverse_price"` // The average price of quote token in base token
+}
+
+// String implement fmt.Stringer
+func (t TWAP) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPair: %s_%s
+StartTime: %d
+EndTime: %d
+Price: %s
+InversePrice: %s`, t.BaseToken, t.QuoteToken, t.StartTime, t.EndTime, t.Price.String(), t.InversePrice.String()))
+}
@@ -144,6 +144,13 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 		for _, record := range matchResult.Deals {
 			order := orderKeeper.GetOrder(ctx, record.OrderID)
 			if quantity, err := strconv.ParseFloat(record.Quantity.String(), 64); err == nil {
+				// deals of continuous auction are executed at the maker prices
+				dealPrice := price
+				if !record.Price.IsNil() {
+					if p, err := strconv.ParseFloat(record.Price.String(), 64); err == nil {
+						dealPrice = p
+					}
+				}
 
 				deal := &types.Deal{
 					BlockHeight: blockHeight,
@@ -151,7 +158,7 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 					Side:        record.Side,
 					Sender:      order.Sender.String(),
 					Product:     product,
-					Price:       price,
+					Price:       dealPrice,
 					Quantity:    quantity,
 					Fee:         record.Fee,
 					Timestamp:   ctx.BlockHeader().Time.Unix(),
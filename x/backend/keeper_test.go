@@ -124,6 +124,52 @@ func TestKeeper_Tx(t *testing.T) {
 	require.EqualValues(t, 1, len(getTxs))
 }
 
+func TestKeeper_TxAmend(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1, true, "")
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Now()}).WithBlockHeight(2)
+	feeParams := orderTypes.DefaultParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	msgOrderNew := orderTypes.NewMsgNewOrder(addrKeysSlice[0].Address, types.TestTokenPair, types.SellOrder,
+		"10.0", "1.0")
+	oldOrderID := orderTypes.FormatOrderID(2, 1)
+	msgOrderAmend := orderTypes.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]orderTypes.AmendOrderItem{orderTypes.NewAmendOrderItem(oldOrderID, "11.0", "2.0")})
+	txs := []auth.StdTx{
+		buildTx(mapp, ctx, addrKeysSlice[0], []sdk.Msg{msgOrderNew}),
+		buildTx(mapp, ctx, addrKeysSlice[0], []sdk.Msg{msgOrderAmend}),
+	}
+	mockApplyBlock(mapp, ctx, txs)
+
+	ctx = mapp.NewContext(true, abci.Header{})
+	newOrderID := mapp.orderKeeper.GetOrder(ctx, oldOrderID).GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyAmendTo)
+	newOrder := mapp.orderKeeper.GetOrder(ctx, newOrderID)
+	require.NotNil(t, newOrder)
+
+	// the new order, the cancel of the old order and the new order amended to
+	getTxs, _ := mapp.backendKeeper.GetTransactionList(ctx, addrKeysSlice[0].Address.String(), 0, 0, 0, 0, 200)
+	require.EqualValues(t, 3, len(getTxs))
+	cancelTxs, _ := mapp.backendKeeper.GetTransactionList(ctx, addrKeysSlice[0].Address.String(),
+		types.TxTypeOrderCancel, 0, 0, 0, 200)
+	require.EqualValues(t, 1, len(cancelTxs))
+	require.Equal(t, "1.00000000", cancelTxs[0].Quantity)
+	newTxs, _ := mapp.backendKeeper.GetTransactionList(ctx, addrKeysSlice[0].Address.String(),
+		types.TxTypeOrderNew, 0, 0, 0, 200)
+	require.EqualValues(t, 2, len(newTxs))
+	var quantities []string
+	for _, tx := range newTxs {
+		require.Equal(t, newOrder.Product, tx.Symbol)
+		require.EqualValues(t, types.TxSideSell, tx.Side)
+		quantities = append(quantities, tx.Quantity)
+	}
+	require.Contains(t, quantities, newOrder.Quantity.String())
+}
+
 func TestKeeper_CleanUpKlines(t *testing.T) {
 	o, _ := orm.MockSqlite3ORM()
 	ch := make(chan struct{}, 1)
@@ -42,7 +42,8 @@ import (
 type MockApp struct {
 	*mock.App
 
-	keyOrder *sdk.KVStoreKey
+	keyOrder  *sdk.KVStoreKey
+	tkeyOrder *sdk.TransientStoreKey
 
 	keyToken     *sdk.KVStoreKey
 	keyLock      *sdk.KVStoreKey
@@ -71,6 +72,7 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 	mockApp = &MockApp{
 		App:          mapp,
 		keyOrder:     sdk.NewKVStoreKey(ordertypes.OrderStoreKey),
+		tkeyOrder:    sdk.NewTransientStoreKey(ordertypes.TStoreKey),
 		keyToken:     sdk.NewKVStoreKey(tokentypes.ModuleName),
 		keyLock:      sdk.NewKVStoreKey(tokentypes.KeyLock),
 		keyDex:       sdk.NewKVStoreKey(dex.StoreKey),
@@ -124,6 +126,7 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		mockApp.ParamsKeeper.Subspace(ordertypes.DefaultParamspace),
 		auth.FeeCollectorName,
 		mockApp.keyOrder,
+		mockApp.tkeyOrder,
 		mockApp.Cdc,
 		true,
 		monitor.NopOrderMetrics())
@@ -184,7 +187,7 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		app.keyDex,
 	)
 
-	require.NoError(t, mockApp.CompleteSetup(mockApp.keyOrder))
+	require.NoError(t, mockApp.CompleteSetup(mockApp.keyOrder, mockApp.tkeyOrder))
 	mock.SetGenesis(mockApp.App, genAccs)
 	for i := 0; i < numGenAccs; i++ {
 		mock.CheckBalance(t, app.App, keysSlice[i].Address, coins)
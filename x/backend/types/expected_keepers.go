@@ -15,6 +15,7 @@ type OrderKeeper interface {
 	GetOrder(ctx sdk.Context, orderID string) *order.Order
 	GetUpdatedOrderIDs() []string
 	GetTxHandlerMsgResult() []bitset.BitSet
+	GetTxHandlerMsgOrderIDs() [][]string
 	GetBlockOrderNum(ctx sdk.Context, blockHeight int64) int64
 	GetBlockMatchResult() *ordertypes.BlockMatchResult
 	GetLastPrice(ctx sdk.Context, product string) sdk.Dec
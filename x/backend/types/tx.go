@@ -12,7 +12,9 @@ import (
 // GenerateTx return transaction, called at DeliverTx
 func GenerateTx(tx *auth.StdTx, txHash string, ctx sdk.Context, orderKeeper OrderKeeper, timestamp int64) []*Transaction {
 	orderHandlerTxResult := orderKeeper.GetTxHandlerMsgResult()
+	orderHandlerTxOrderIDs := orderKeeper.GetTxHandlerMsgOrderIDs()
 	idx := int(0)
+	cancelAllIdx := int(0)
 	var txs []*Transaction
 
 	for _, msg := range tx.GetMsgs() {
@@ -30,6 +32,20 @@ func GenerateTx(tx *auth.StdTx, txHash string, ctx sdk.Context, orderKeeper Orde
 				txHash, ctx, orderKeeper, timestamp)
 			txs = append(txs, transaction...)
 			idx++
+		case "cancel_all": // order/cancel_all
+			// the cancelled orders are not known from the msg, but recorded by the handler
+			cancelAllMsg := msg.(orderTypes.MsgCancelAllOrders)
+			transaction := buildTransactionCancel(orderHandlerTxResult[idx],
+				orderTypes.NewMsgCancelOrders(cancelAllMsg.Sender, orderHandlerTxOrderIDs[cancelAllIdx]),
+				txHash, ctx, orderKeeper, timestamp)
+			txs = append(txs, transaction...)
+			idx++
+			cancelAllIdx++
+		case "amend": // order/amend
+			transaction := buildTransactionAmend(orderHandlerTxResult[idx], msg.(orderTypes.MsgAmendOrders),
+				txHash, ctx, orderKeeper, timestamp)
+			txs = append(txs, transaction...)
+			idx++
 		default: // In other cases, do nothing
 			continue
 		}
@@ -105,26 +121,71 @@ func buildTransactionCancel(handlerMsgResult bitset.BitSet, msg orderTypes.MsgCa
 		if order == nil {
 			continue
 		}
+		result = append(result, buildTransactionCancelOrder(order, msg.Sender, txHash, timestamp))
+	}
+
+	return result
+}
+
+// buildTransactionCancelOrder builds the cancel transaction of the order with its cancel fee
+func buildTransactionCancelOrder(order *orderTypes.Order, sender sdk.AccAddress, txHash string,
+	timestamp int64) *Transaction {
+	side := TxSideBuy
+	if order.Side == orderTypes.SellOrder {
+		side = TxSideSell
+	}
+	cancelFeeStr := order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyCancelFee)
+	if cancelFeeStr == "" {
+		cancelFeeStr = sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
+	}
+	return &Transaction{
+		TxHash:    txHash,
+		Address:   sender.String(),
+		Type:      TxTypeOrderCancel,
+		Side:      int64(side),
+		Symbol:    order.Product,
+		Quantity:  order.Quantity.String(),
+		Fee:       cancelFeeStr,
+		Timestamp: timestamp,
+	}
+}
+
+// buildTransactionAmend builds a cancel transaction of the old order and a new transaction of the new order
+// for each amended order, the new order is found by the amend-to info of the old order
+func buildTransactionAmend(handlerMsgResult bitset.BitSet, msg orderTypes.MsgAmendOrders, txHash string, ctx sdk.Context, orderKeeper OrderKeeper, timestamp int64) []*Transaction {
+	var result []*Transaction
+
+	for idx, item := range msg.AmendItems {
+		if !handlerMsgResult.Test(uint(idx)) {
+			continue
+		}
+
+		order := orderKeeper.GetOrder(ctx, item.OrderID)
+		if order == nil {
+			continue
+		}
+		result = append(result, buildTransactionCancelOrder(order, msg.Sender, txHash, timestamp))
+
+		newOrder := orderKeeper.GetOrder(ctx, order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyAmendTo))
+		if newOrder == nil {
+			continue
+		}
 		side := TxSideBuy
-		if order.Side == orderTypes.SellOrder {
+		if newOrder.Side == orderTypes.SellOrder {
 			side = TxSideSell
 		}
-		cancelFeeStr := order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyCancelFee)
-		if cancelFeeStr == "" {
-			cancelFeeStr = sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
-		}
-		tx := Transaction{
+		newTx := Transaction{
 			TxHash:    txHash,
 			Address:   msg.Sender.String(),
-			Type:      TxTypeOrderCancel,
+			Type:      TxTypeOrderNew,
 			Side:      int64(side),
-			Symbol:    order.Product,
-			Quantity:  order.Quantity.String(),
-			Fee:       cancelFeeStr,
+			Symbol:    newOrder.Product,
+			Quantity:  newOrder.Quantity.String(),
+			Fee:       sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String(),
 			Timestamp: timestamp,
 		}
 
-		result = append(result, &tx)
+		result = append(result, &newTx)
 	}
 
 	return result
@@ -88,6 +88,66 @@ func TestGenerateTx(t *testing.T) {
 	tmpBitset.Set(1)
 	keeper.AddTxHandlerMsgResult(tmpBitset)
 	GenerateTx(&tx, "", ctx, keeper, time.Now().Unix())
+
+	// order/amend
+	orderAmendMsg := order.NewMsgAmendOrders(accFrom,
+		[]order.AmendOrderItem{order.NewAmendOrderItem("ORDER-123", "23.5", "100")})
+	orderAmendMsgSig, _ := priKeyFrom.Sign(orderAmendMsg.GetSignBytes())
+	sigs = []auth.StdSignature{
+		{
+			PubKey:    pubKeyFrom,
+			Signature: orderAmendMsgSig,
+		},
+	}
+	txSigMsg, _ = txbldr.BuildSignMsg([]sdk.Msg{orderAmendMsg})
+	tx = auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
+	newOr := &order.Order{
+		OrderID:  "ORDER-124",
+		Product:  "btc_" + common.NativeToken,
+		Side:     SellOrder,
+		Price:    sdk.MustNewDecFromStr("23.5"),
+		Quantity: sdk.MustNewDecFromStr("100"),
+	}
+	keeper.SetOrder(ctx, newOr.OrderID, newOr)
+	or.Product = newOr.Product
+	or.RecordOrderAmendTo(newOr.OrderID)
+	keeper.SetOrder(ctx, or.OrderID, or)
+	var amendBitset bitset.BitSet
+	amendBitset.Set(0)
+	keeper.AddTxHandlerMsgResult(amendBitset)
+	txs := GenerateTx(&tx, "", ctx, keeper, time.Now().Unix())
+	require.Equal(t, 2, len(txs))
+	require.EqualValues(t, TxTypeOrderCancel, txs[0].Type)
+	require.Equal(t, fee.String(), txs[0].Fee)
+	require.EqualValues(t, TxTypeOrderNew, txs[1].Type)
+	require.EqualValues(t, TxSideSell, txs[1].Side)
+	require.Equal(t, newOr.Product, txs[1].Symbol)
+	require.Equal(t, "100.00000000", txs[1].Quantity)
+
+	// order/cancel_all
+	orderCancelAllMsg := order.NewMsgCancelAllOrders(accFrom, "", "")
+	orderCancelAllMsgSig, _ := priKeyFrom.Sign(orderCancelAllMsg.GetSignBytes())
+	sigs = []auth.StdSignature{
+		{
+			PubKey:    pubKeyFrom,
+			Signature: orderCancelAllMsgSig,
+		},
+	}
+	txSigMsg, _ = txbldr.BuildSignMsg([]sdk.Msg{orderCancelAllMsg})
+	tx = auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
+	var cancelAllBitset bitset.BitSet
+	cancelAllBitset.Set(0).Set(1)
+	keeper.AddTxHandlerMsgResult(cancelAllBitset)
+	keeper.AddTxHandlerMsgOrderIDs([]string{or.OrderID, newOr.OrderID})
+	txs = GenerateTx(&tx, "", ctx, keeper, time.Now().Unix())
+	require.Equal(t, 2, len(txs))
+	for _, tx := range txs {
+		require.EqualValues(t, TxTypeOrderCancel, tx.Type)
+		require.Equal(t, accFrom.String(), tx.Address)
+	}
+	require.Equal(t, fee.String(), txs[0].Fee)
+	require.Equal(t, newOr.Product, txs[1].Symbol)
+	require.Equal(t, "100.00000000", txs[1].Quantity)
 }
 
 func TestTicker(t *testing.T) {
@@ -23,6 +23,12 @@ const (
 	DefaultMaxPriceDigitSize    = types.DefaultMaxPriceDigitSize
 	DefaultMaxQuantityDigitSize = types.DefaultMaxQuantityDigitSize
 
+	AuctionTypePeriodic   = types.AuctionTypePeriodic
+	AuctionTypeContinuous = types.AuctionTypeContinuous
+	DefaultAuctionType    = types.DefaultAuctionType
+
+	FeeTierVolumeDays = types.FeeTierVolumeDays
+
 	AuthFeeCollector = auth.FeeCollectorName
 )
 
@@ -43,6 +49,9 @@ type (
 	MsgTransferOwnership = types.MsgTransferOwnership
 	MsgUpdateOperator    = types.MsgUpdateOperator
 	MsgCreateOperator    = types.MsgCreateOperator
+	MsgSetFeeTiers       = types.MsgSetFeeTiers
+	MsgEditTokenPair     = types.MsgEditTokenPair
+	MsgDestroyOperator   = types.MsgDestroyOperator
 
 	TokenPair     = types.TokenPair
 	Params        = types.Params
@@ -50,6 +59,13 @@ type (
 	WithdrawInfos = types.WithdrawInfos
 	DEXOperator   = types.DEXOperator
 	DEXOperators  = types.DEXOperators
+	FeeTier       = types.FeeTier
+	FeeTiers      = types.FeeTiers
+
+	ProductFee           = types.ProductFee
+	ProductFees          = types.ProductFees
+	OperatorFees         = types.OperatorFees
+	OperatorFeeStatement = types.OperatorFeeStatement
 )
 
 var (
@@ -62,9 +78,17 @@ var (
 	GetBuiltInTokenPair = keeper.GetBuiltInTokenPair
 	DefaultParams       = types.DefaultParams
 
-	NewMsgList     = types.NewMsgList
-	NewMsgDeposit  = types.NewMsgDeposit
-	NewMsgWithdraw = types.NewMsgWithdraw
+	NewMsgList            = types.NewMsgList
+	NewMsgDeposit         = types.NewMsgDeposit
+	NewMsgWithdraw        = types.NewMsgWithdraw
+	NewMsgSetFeeTiers     = types.NewMsgSetFeeTiers
+	NewMsgEditTokenPair   = types.NewMsgEditTokenPair
+	NewMsgDestroyOperator = types.NewMsgDestroyOperator
+	NewFeeTier            = types.NewFeeTier
+
+	NewOperatorFees         = types.NewOperatorFees
+	NewOperatorFeeStatement = types.NewOperatorFeeStatement
+	GetOperatorFeeEpoch     = types.GetOperatorFeeEpoch
 
 	ErrInvalidProduct      = types.ErrInvalidProduct
 	ErrTokenPairNotFound   = types.ErrTokenPairNotFound
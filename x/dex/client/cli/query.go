@@ -29,6 +29,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryProductsUnderDelisting(queryRoute, cdc),
 		GetCmdQueryOperator(queryRoute, cdc),
 		GetCmdQueryOperators(queryRoute, cdc),
+		GetCmdQueryOperatorFees(queryRoute, cdc),
+		GetCmdQueryOperatorFeeStatements(queryRoute, cdc),
 	)...)
 
 	return queryCmd
@@ -239,3 +241,83 @@ type Strings []string
 func (strs Strings) String() string {
 	return strings.Join(strs, "\n")
 }
+
+// GetCmdQueryOperatorFees queries the fees accrued by the operator
+func GetCmdQueryOperatorFees(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator-fees [operator-address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the fees accrued by the operator from its token pairs",
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", args[0]))
+			}
+
+			params := types.NewQueryOperatorFeesParams(addr, viper.GetString("product"))
+			bz, err := cliCtx.Codec.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOperatorFees), bz)
+			if err != nil {
+				return err
+			}
+			var operatorFees types.OperatorFees
+			cdc.MustUnmarshalJSON(res, &operatorFees)
+			return cliCtx.PrintOutput(operatorFees)
+		},
+	}
+	cmd.Flags().String("product", "", "only query the fees accrued from the token pair")
+
+	return cmd
+}
+
+// GetCmdQueryOperatorFeeStatements queries the fee statements of the operator
+func GetCmdQueryOperatorFeeStatements(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator-fee-statements [operator-address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the fee statements of the operator, the latest epoch comes first",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the fee statements of the operator. A statement covers the fees accrued by the operator
+from its token pairs in an epoch of %d blocks, epoch N covers the blocks from N*%d to (N+1)*%d-1.
+
+Example:
+$ okchaincli query dex operator-fee-statements okchain1... --epoch 3
+`, types.OperatorFeeEpochBlocks, types.OperatorFeeEpochBlocks, types.OperatorFeeEpochBlocks),
+		),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", args[0]))
+			}
+
+			page := viper.GetUint("page-number")
+			perPage := viper.GetUint("items-per-page")
+			params := types.NewQueryOperatorFeeStatementsParams(addr, viper.GetInt64("epoch"), int(page), int(perPage))
+			bz, err := cliCtx.Codec.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOperatorFeeStatements), bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int64("epoch", -1, "only query the fee statement of the epoch, all the epochs if negative")
+	cmd.Flags().UintP("page-number", "p", types.DefaultPage, "page num")
+	cmd.Flags().UintP("items-per-page", "i", types.DefaultPerPage, "items per page")
+
+	return cmd
+}
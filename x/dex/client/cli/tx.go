@@ -34,6 +34,12 @@ const (
 	FlagTo                 = "to"
 	FlagWebsite            = "website"
 	FlagHandlingFeeAddress = "handling-fee-address"
+	FlagAuctionType        = "auction-type"
+	FlagFeeTiers           = "fee-tiers"
+	FlagMaxPriceDigit      = "max-price-digit"
+	FlagMaxQuantityDigit   = "max-quantity-digit"
+	FlagMinQuantity        = "min-quantity"
+	FlagSuccessor          = "successor"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -51,6 +57,9 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getMultiSignsCmd(cdc),
 		getCmdRegisterOperator(cdc),
 		getCmdEditOperator(cdc),
+		getCmdDestroyOperator(cdc),
+		getCmdSetFeeTiers(cdc),
+		getCmdEditTokenPair(cdc),
 	)...)
 
 	return txCmd
@@ -65,6 +74,7 @@ func getCmdList(cdc *codec.Codec) *cobra.Command {
 		Long: strings.TrimSpace(`List a trading pair:
 
 $ okchaincli tx dex list --base-asset mytoken --quote-asset okt --from mykey
+$ okchaincli tx dex list --base-asset mytoken --quote-asset okt --auction-type continuous --from mykey
 `),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -91,8 +101,13 @@ $ okchaincli tx dex list --base-asset mytoken --quote-asset okt --from mykey
 				return err
 			}
 			initPrice := sdk.MustNewDecFromStr(strInitPrice)
+			auctionType, err := flags.GetString(FlagAuctionType)
+			if err != nil {
+				return err
+			}
 			owner := cliCtx.GetFromAddress()
 			listMsg := types.NewMsgList(owner, baseAsset, quoteAsset, initPrice)
+			listMsg.AuctionType = auctionType
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{listMsg})
 		},
 	}
@@ -100,6 +115,8 @@ $ okchaincli tx dex list --base-asset mytoken --quote-asset okt --from mykey
 	cmd.Flags().StringP(FlagBaseAsset, "", "", FlagBaseAsset+" should be issued before listed to opendex")
 	cmd.Flags().StringP(FlagQuoteAsset, "", common.NativeToken, FlagQuoteAsset+" should be issued before listed to opendex")
 	cmd.Flags().StringP(FlagInitPrice, "", "0.01", FlagInitPrice+" should be valid price")
+	cmd.Flags().String(FlagAuctionType, "", fmt.Sprintf("match engine of the trading pair, %s(default) or %s",
+		types.AuctionTypePeriodic, types.AuctionTypeContinuous))
 
 	return cmd
 }
@@ -210,7 +227,7 @@ func getCmdTransferOwnership(cdc *codec.Codec) *cobra.Command {
 func getMultiSignsCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "multisign",
-		Short: "append signature to the unsigned tx file of transfer-ownership",
+		Short: "append signature to the unsigned tx file of transfer-ownership, destroy-operator or list-proposal",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -225,11 +242,6 @@ func getMultiSignsCmd(cdc *codec.Codec) *cobra.Command {
 				return errors.New("msg is empty")
 			}
 
-			msg, ok := stdTx.Msgs[0].(types.MsgTransferOwnership)
-			if !ok {
-				return errors.New("invalid msg type")
-			}
-
 			flags := cmd.Flags()
 			_, err = flags.GetString(FlagFrom)
 			if err != nil {
@@ -240,19 +252,51 @@ func getMultiSignsCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			signature, _, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), passphrase, msg.GetSignBytes())
-			if err != nil {
-				return fmt.Errorf("sign failed:%s", err.Error())
-			}
 			info, err := txBldr.Keybase().Get(cliCtx.GetFromName())
 			if err != nil {
 				return err
 			}
-			stdSignature := auth.StdSignature{
-				PubKey:    info.GetPubKey(),
-				Signature: signature,
+
+			var msg sdk.Msg
+			switch m := stdTx.Msgs[0].(type) {
+			case types.MsgTransferOwnership:
+				signature, _, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), passphrase, m.GetSignBytes())
+				if err != nil {
+					return fmt.Errorf("sign failed:%s", err.Error())
+				}
+				m.ToSignature = auth.StdSignature{
+					PubKey:    info.GetPubKey(),
+					Signature: signature,
+				}
+				msg = m
+			case types.MsgDestroyOperator:
+				signature, _, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), passphrase, m.GetSignBytes())
+				if err != nil {
+					return fmt.Errorf("sign failed:%s", err.Error())
+				}
+				m.SuccessorSignature = auth.StdSignature{
+					PubKey:    info.GetPubKey(),
+					Signature: signature,
+				}
+				msg = m
+			case gov.MsgSubmitProposal:
+				content, ok := m.Content.(types.ListProposal)
+				if !ok {
+					return errors.New("invalid proposal type")
+				}
+				signature, _, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), passphrase, content.GetSignBytes())
+				if err != nil {
+					return fmt.Errorf("sign failed:%s", err.Error())
+				}
+				content.OperatorSignature = auth.StdSignature{
+					PubKey:    info.GetPubKey(),
+					Signature: signature,
+				}
+				m.Content = content
+				msg = m
+			default:
+				return errors.New("invalid msg type")
 			}
-			msg.ToSignature = stdSignature
 			return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
@@ -306,6 +350,169 @@ Where proposal.json contains:
 
 }
 
+// GetCmdSubmitAuctionTypeProposal implements a command handler for submitting a dex auction type proposal transaction
+func GetCmdSubmitAuctionTypeProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auction-type-proposal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a dex auction type proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the auction type of a token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file. The auction type is one of "periodic" and "continuous".
+
+Example:
+$ %s tx gov submit-proposal auction-type-proposal <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "continuous auction for xxx/%s",
+ "description": "match xxx/%s orders continuously",
+ "base_asset": "xxx",
+ "quote_asset": "%s",
+ "auction_type": "continuous",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := dexUtils.ParseAuctionTypeProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewAuctionTypeProposal(proposal.Title, proposal.Description, from, proposal.BaseAsset,
+				proposal.QuoteAsset, proposal.AuctionType)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+}
+
+// GetCmdSubmitPriceBandProposal implements a command handler for submitting a dex price band proposal transaction
+func GetCmdSubmitPriceBandProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "price-band-proposal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a dex price band proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the price band of a token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file. The price band is the max deviation rate of the
+periodic auction match price from the last price, the token pair is halted if the match price breaks it.
+A price band of "0" turns it off.
+
+Example:
+$ %s tx gov submit-proposal price-band-proposal <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "price band for xxx/%s",
+ "description": "halt xxx/%s if the match price moves more than 10%%",
+ "base_asset": "xxx",
+ "quote_asset": "%s",
+ "price_band": "0.1",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := dexUtils.ParsePriceBandProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewPriceBandProposal(proposal.Title, proposal.Description, from, proposal.BaseAsset,
+				proposal.QuoteAsset, proposal.PriceBand)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+}
+
+// GetCmdSubmitListProposal implements a command handler for submitting a dex list proposal transaction
+func GetCmdSubmitListProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-proposal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a dex list proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to list a token pair along with an initial deposit. The token pair is listed
+under the operator with the initial trading rules once the proposal passes. The initial deposit should cover
+the list fee, which is charged out of the proposer's deposit when the proposal passes.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal list-proposal <path/to/proposal.json> --from=<key_or_address>
+
+If the operator isn't the proposer, the operator consents by signing the unsigned tx before the proposer does:
+$ %s tx gov submit-proposal list-proposal <path/to/proposal.json> --from=<proposer> --generate-only > unsigned.json
+$ %s tx dex multisign unsigned.json --from=<operator> > multisigned.json
+$ %s tx sign multisigned.json --from=<proposer>
+
+Where proposal.json contains:
+
+{
+ "title": "list xxx/%s",
+ "description": "list xxx/%s under the operator",
+ "base_asset": "xxx",
+ "quote_asset": "%s",
+ "init_price": "1.0",
+ "operator": "okchain1...",
+ "max_price_digit": "4",
+ "max_size_digit": "4",
+ "min_trade_size": "0.0001",
+ "auction_type": "continuous",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "20000"
+   }
+ ]
+}
+`, version.ClientName, version.ClientName, version.ClientName, version.ClientName,
+				sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := dexUtils.ParseListProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewListProposal(proposal.Title, proposal.Description, from, proposal.BaseAsset,
+				proposal.QuoteAsset, proposal.InitPrice, proposal.Operator, proposal.MaxPriceDigit,
+				proposal.MaxQuantityDigit, proposal.MinQuantity, proposal.AuctionType)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+}
+
 func getCmdRegisterOperator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "register-operator",
@@ -389,3 +596,174 @@ $ okchaincli tx dex edit-operator --website http://xxx/operator.json --handling-
 
 	return cmd
 }
+
+func getCmdDestroyOperator(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "destroy-operator",
+		Short: "destroy a dex operator and refund its deposits",
+		Args:  cobra.ExactArgs(0),
+		Long: strings.TrimSpace(`Destroy a dex operator, whose deposits are refunded after the withdraw period:
+
+$ okchaincli tx dex destroy-operator --from mykey
+
+If the operator owns token pairs, they are migrated to a successor operator, who should accept
+the migration by signing the unsigned tx with multisign before it is signed and broadcast by the operator:
+
+$ okchaincli tx dex destroy-operator --successor addr --from mykey > unsigned.json
+$ okchaincli tx dex multisign unsigned.json --from successorkey > multisigned.json
+$ okchaincli tx sign multisigned.json --from mykey > signed.json
+$ okchaincli tx broadcast signed.json
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			successorStr, err := cmd.Flags().GetString(FlagSuccessor)
+			if err != nil {
+				return err
+			}
+			owner := cliCtx.GetFromAddress()
+			if successorStr == "" {
+				msg := types.NewMsgDestroyOperator(owner, nil)
+				return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			successor, err := sdk.AccAddressFromBech32(successorStr)
+			if err != nil {
+				return sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", successorStr))
+			}
+			msg := types.NewMsgDestroyOperator(owner, successor)
+			return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSuccessor, "", "the operator which the token pairs are migrated to")
+
+	return cmd
+}
+
+func getCmdSetFeeTiers(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-fee-tiers",
+		Short: "set the maker/taker trading fee tiers of a trading pair or the default ones of the operator",
+		Args:  cobra.ExactArgs(0),
+		Long: strings.TrimSpace(`Set the trading fee tiers of a trading pair owned by the operator, or the default ones of all the
+operator's trading pairs if no product is given. Each tier is in the format of ${min_volume}:${maker_fee_rate}:${taker_fee_rate},
+in which min_volume is the rolling 30-day traded volume in quote asset. Empty fee tiers reset the fee schedule:
+
+$ okchaincli tx dex set-fee-tiers --product mytoken_okt --fee-tiers 0:0.001:0.002,100000:0.0005:0.001 --from mykey
+$ okchaincli tx dex set-fee-tiers --fee-tiers 0:0.001:0.002 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			product, err := flags.GetString(FlagProduct)
+			if err != nil {
+				return err
+			}
+			feeTiersStr, err := flags.GetString(FlagFeeTiers)
+			if err != nil {
+				return err
+			}
+			feeTiers, err := parseFeeTiers(feeTiersStr)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetFeeTiers(cliCtx.GetFromAddress(), product, feeTiers)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagProduct, "", "The trading pair, empty for the default fee tiers of the operator")
+	cmd.Flags().String(FlagFeeTiers, "", "Comma separated fee tiers in the format of min_volume:maker_fee_rate:taker_fee_rate")
+
+	return cmd
+}
+
+func getCmdEditTokenPair(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "edit the trading rules of a trading pair",
+		Args:  cobra.ExactArgs(0),
+		Long: strings.TrimSpace(`Edit the price and quantity precisions and the min quantity of a trading pair owned by the operator.
+The open orders whose prices or quantities don't fit the new rules are cancelled and refunded at the end of the block:
+
+$ okchaincli tx dex edit --product mytoken_okt --max-price-digit 2 --max-quantity-digit 4 --min-quantity 0.001 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			product, err := flags.GetString(FlagProduct)
+			if err != nil {
+				return err
+			}
+			maxPriceDigit, err := flags.GetInt64(FlagMaxPriceDigit)
+			if err != nil {
+				return err
+			}
+			maxQuantityDigit, err := flags.GetInt64(FlagMaxQuantityDigit)
+			if err != nil {
+				return err
+			}
+			minQuantityStr, err := flags.GetString(FlagMinQuantity)
+			if err != nil {
+				return err
+			}
+			minQuantity, err := sdk.NewDecFromStr(minQuantityStr)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgEditTokenPair(cliCtx.GetFromAddress(), product, maxPriceDigit, maxQuantityDigit,
+				minQuantity)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagProduct, "", "The trading pair to edit")
+	cmd.Flags().Int64(FlagMaxPriceDigit, types.DefaultMaxPriceDigitSize, "The max decimal places of the price")
+	cmd.Flags().Int64(FlagMaxQuantityDigit, types.DefaultMaxQuantityDigitSize, "The max decimal places of the quantity")
+	cmd.Flags().String(FlagMinQuantity, "0.00000001", "The min quantity of an order")
+
+	return cmd
+}
+
+func parseFeeTiers(feeTiersStr string) (types.FeeTiers, error) {
+	var feeTiers types.FeeTiers
+	if len(strings.TrimSpace(feeTiersStr)) == 0 {
+		return feeTiers, nil
+	}
+	for _, tierStr := range strings.Split(feeTiersStr, ",") {
+		fields := strings.Split(strings.TrimSpace(tierStr), ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid fee tier: %s", tierStr)
+		}
+		decs := make([]sdk.Dec, len(fields))
+		for i, field := range fields {
+			dec, err := sdk.NewDecFromStr(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fee tier %s: %s", tierStr, err.Error())
+			}
+			decs[i] = dec
+		}
+		feeTiers = append(feeTiers, types.NewFeeTier(decs[0], decs[1], decs[2]))
+	}
+	return feeTiers, nil
+}
@@ -10,4 +10,12 @@ import (
 var (
 	// DelistProposalHandler alias gov NewProposalHandler
 	DelistProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitDelistProposal, rest.DelistProposalRESTHandler)
+	// AuctionTypeProposalHandler alias gov NewProposalHandler
+	AuctionTypeProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitAuctionTypeProposal,
+		rest.AuctionTypeProposalRESTHandler)
+	// PriceBandProposalHandler alias gov NewProposalHandler
+	PriceBandProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitPriceBandProposal,
+		rest.PriceBandProposalRESTHandler)
+	// ListProposalHandler alias gov NewProposalHandler
+	ListProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitListProposal, rest.ListProposalRESTHandler)
 )
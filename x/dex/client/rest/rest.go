@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/okex/okchain/x/dex/types"
@@ -24,6 +25,8 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/dex/product_rank", matchOrderHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/dexoperator/{address}", operatorHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/dexoperators", operatorsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dexoperator/{address}/fees", operatorFeesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dexoperator/{address}/fee_statements", operatorFeeStatementsHandler(cliCtx)).Methods("GET")
 }
 
 func productsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -179,7 +182,84 @@ func operatorsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *
 	}
 }
 
+func operatorFeesHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address, err := sdk.AccAddressFromBech32(mux.Vars(r)["address"])
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		params := types.NewQueryOperatorFeesParams(address, r.URL.Query().Get("product"))
+		bz := cliContext.Codec.MustMarshalJSON(&params)
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryOperatorFees), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		result := common.GetBaseResponse("hello")
+		result2, err2 := json.Marshal(result)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliContext, err2.Error())
+			return
+		}
+		result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+		rest.PostProcessResponse(w, cliContext, result2)
+	}
+}
+
+func operatorFeeStatementsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address, err := sdk.AccAddressFromBech32(mux.Vars(r)["address"])
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		epoch := int64(-1)
+		if epochStr := r.URL.Query().Get("epoch"); epochStr != "" {
+			epoch, err = strconv.ParseInt(epochStr, 10, 64)
+			if err != nil {
+				common.HandleErrorMsg(w, cliContext, fmt.Sprintf("invalid epoch: %s", epochStr))
+				return
+			}
+		}
+		page, perPage, err := common.Paginate(r.URL.Query().Get("page"), r.URL.Query().Get("per_page"))
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		params := types.NewQueryOperatorFeeStatementsParams(address, epoch, page, perPage)
+		bz := cliContext.Codec.MustMarshalJSON(&params)
+		res, _, err := cliContext.QueryWithData(
+			fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryOperatorFeeStatements), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliContext, res)
+	}
+}
+
 // DelistProposalRESTHandler defines dex proposal handler
 func DelistProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
 	return govRest.ProposalRESTHandler{}
 }
+
+// AuctionTypeProposalRESTHandler defines dex auction type proposal handler
+func AuctionTypeProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// PriceBandProposalRESTHandler defines dex price band proposal handler
+func PriceBandProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// ListProposalRESTHandler defines dex list proposal handler
+func ListProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
@@ -30,3 +30,83 @@ func ParseDelistProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposa
 
 	return proposal, nil
 }
+
+// AuctionTypeProposalJSON defines an AuctionTypeProposal with a deposit used
+// to parse auction type proposals from a JSON file.
+type AuctionTypeProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	BaseAsset   string       `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string       `json:"quote_asset" yaml:"quote_asset"`
+	AuctionType string       `json:"auction_type" yaml:"auction_type"`
+	Deposit     sdk.DecCoins `json:"deposit" yaml:"deposit"`
+}
+
+// ParseAuctionTypeProposalJSON parse json from proposal file to AuctionTypeProposalJSON struct
+func ParseAuctionTypeProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposal AuctionTypeProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// PriceBandProposalJSON defines a PriceBandProposal with a deposit used
+// to parse price band proposals from a JSON file.
+type PriceBandProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	BaseAsset   string       `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string       `json:"quote_asset" yaml:"quote_asset"`
+	PriceBand   sdk.Dec      `json:"price_band" yaml:"price_band"`
+	Deposit     sdk.DecCoins `json:"deposit" yaml:"deposit"`
+}
+
+// ParsePriceBandProposalJSON parse json from proposal file to PriceBandProposalJSON struct
+func ParsePriceBandProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposal PriceBandProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// ListProposalJSON defines a ListProposal with a deposit used
+// to parse list proposals from a JSON file.
+type ListProposalJSON struct {
+	Title            string         `json:"title" yaml:"title"`
+	Description      string         `json:"description" yaml:"description"`
+	BaseAsset        string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset       string         `json:"quote_asset" yaml:"quote_asset"`
+	InitPrice        sdk.Dec        `json:"init_price" yaml:"init_price"`
+	Operator         sdk.AccAddress `json:"operator" yaml:"operator"`
+	MaxPriceDigit    int64          `json:"max_price_digit" yaml:"max_price_digit"`
+	MaxQuantityDigit int64          `json:"max_size_digit" yaml:"max_size_digit"`
+	MinQuantity      sdk.Dec        `json:"min_trade_size" yaml:"min_trade_size"`
+	AuctionType      string         `json:"auction_type" yaml:"auction_type"`
+	Deposit          sdk.DecCoins   `json:"deposit" yaml:"deposit"`
+}
+
+// ParseListProposalJSON parse json from proposal file to ListProposalJSON struct
+func ParseListProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposal ListProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
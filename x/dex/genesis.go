@@ -16,6 +16,9 @@ type GenesisState struct {
 	ProductLocks   ordertypes.ProductLockMap `json:"product_locks"`
 	Operators      DEXOperators              `json:"operators"`
 	MaxTokenPairID uint64                    `json:"max_token_pair_id" yaml:"max_token_pair_id"`
+
+	OperatorFees          []types.OperatorFees         `json:"operator_fees"`
+	OperatorFeeStatements []types.OperatorFeeStatement `json:"operator_fee_statements"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -38,6 +41,26 @@ func ValidateGenesis(data GenesisState) error {
 			return fmt.Errorf("invalid tx tokenPair ID: %d", pair.ID)
 		}
 	}
+
+	for _, operatorFees := range data.OperatorFees {
+		if operatorFees.Operator.Empty() {
+			return fmt.Errorf("empty operator of operator fees")
+		}
+		if err := types.ValidateProductFees(operatorFees.Fees); err != nil {
+			return fmt.Errorf("invalid fees of operator %s: %s", operatorFees.Operator, err)
+		}
+	}
+	for _, statement := range data.OperatorFeeStatements {
+		if statement.Operator.Empty() {
+			return fmt.Errorf("empty operator of operator fee statement")
+		}
+		if statement.Epoch < 0 {
+			return fmt.Errorf("invalid epoch %d of operator fee statement", statement.Epoch)
+		}
+		if err := types.ValidateProductFees(statement.Fees); err != nil {
+			return fmt.Errorf("invalid fees of operator %s in epoch %d: %s", statement.Operator, statement.Epoch, err)
+		}
+	}
 	return nil
 }
 
@@ -77,6 +100,14 @@ func InitGenesis(ctx sdk.Context, keeper IKeeper, data GenesisState) {
 	for k, v := range data.ProductLocks.Data {
 		keeper.LockTokenPair(ctx, k, v)
 	}
+
+	// reset operator fee accounting
+	for _, operatorFees := range data.OperatorFees {
+		keeper.SetOperatorFees(ctx, operatorFees)
+	}
+	for _, statement := range data.OperatorFeeStatements {
+		keeper.SetOperatorFeeStatement(ctx, statement)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -105,5 +136,8 @@ func ExportGenesis(ctx sdk.Context, keeper IKeeper) (data GenesisState) {
 		ProductLocks:   *keeper.LoadProductLocks(ctx),
 		Operators:      operators,
 		MaxTokenPairID: keeper.GetMaxTokenPairID(ctx),
+
+		OperatorFees:          keeper.GetAllOperatorFees(ctx),
+		OperatorFeeStatements: keeper.GetAllOperatorFeeStatements(ctx),
 	}
 }
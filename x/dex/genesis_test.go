@@ -48,13 +48,19 @@ func TestInitGenesis(t *testing.T) {
 	product := fmt.Sprintf("%s_%s", tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol)
 	lockMap.Data[product] = lock
 
+	fees := types.ProductFees{{Product: product, Fee: sdk.NewDecCoinsFromDec(tokenPair.QuoteAssetSymbol, sdk.NewDec(3))}}
+	operatorFees := []types.OperatorFees{types.NewOperatorFees(tokenPair.Owner, fees)}
+	operatorFeeStatements := []types.OperatorFeeStatement{types.NewOperatorFeeStatement(tokenPair.Owner, 2, fees)}
+
 	initGenesis := GenesisState{
-		Params:         params,
-		TokenPairs:     tokenPairs,
-		WithdrawInfos:  withdrawInfos,
-		ProductLocks:   *lockMap,
-		Operators:      operators,
-		MaxTokenPairID: 10,
+		Params:                params,
+		TokenPairs:            tokenPairs,
+		WithdrawInfos:         withdrawInfos,
+		ProductLocks:          *lockMap,
+		Operators:             operators,
+		MaxTokenPairID:        10,
+		OperatorFees:          operatorFees,
+		OperatorFeeStatements: operatorFeeStatements,
 	}
 	require.NoError(t, ValidateGenesis(initGenesis))
 	InitGenesis(ctx, keeper, initGenesis)
@@ -85,6 +91,8 @@ func TestInitGenesis(t *testing.T) {
 	require.True(t, initGenesis.WithdrawInfos.Equal(exportGenesis.WithdrawInfos))
 	require.Equal(t, initGenesis.ProductLocks, exportGenesis.ProductLocks)
 	require.Equal(t, initGenesis.MaxTokenPairID, exportGenesis.MaxTokenPairID)
+	require.Equal(t, initGenesis.OperatorFees, exportGenesis.OperatorFees)
+	require.Equal(t, initGenesis.OperatorFeeStatements, exportGenesis.OperatorFeeStatements)
 
 	exportGenesis.Params.WithdrawPeriod = 55555
 	exportGenesis.TokenPairs[0].ID = 66666
@@ -127,4 +135,15 @@ func TestInitGenesis(t *testing.T) {
 	})
 	require.True(t, newExportGenesis.WithdrawInfos.Equal(newExportWithdrawInfos))
 	require.Equal(t, newExportGenesis.ProductLocks, *newKeeper.LoadProductLocks(newCtx))
+	require.Equal(t, exportGenesis.OperatorFees, newExportGenesis.OperatorFees)
+	require.Equal(t, exportGenesis.OperatorFeeStatements, newExportGenesis.OperatorFeeStatements)
+
+	// invalid operator fee accounting
+	invalidGenesis := DefaultGenesisState()
+	invalidGenesis.OperatorFees = []types.OperatorFees{types.NewOperatorFees(nil, fees)}
+	require.Error(t, ValidateGenesis(invalidGenesis))
+	invalidGenesis = DefaultGenesisState()
+	invalidGenesis.OperatorFeeStatements = []types.OperatorFeeStatement{
+		types.NewOperatorFeeStatement(tokenPair.Owner, 1, append(fees, fees...))}
+	require.Error(t, ValidateGenesis(invalidGenesis))
 }
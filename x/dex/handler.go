@@ -51,6 +51,21 @@ func NewHandler(k IKeeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgUpdateOperator(ctx, k, msg, logger)
 			}
+		case MsgSetFeeTiers:
+			name = "handleMsgSetFeeTiers"
+			handlerFun = func() sdk.Result {
+				return handleMsgSetFeeTiers(ctx, k, msg, logger)
+			}
+		case MsgEditTokenPair:
+			name = "handleMsgEditTokenPair"
+			handlerFun = func() sdk.Result {
+				return handleMsgEditTokenPair(ctx, k, msg, logger)
+			}
+		case MsgDestroyOperator:
+			name = "handleMsgDestroyOperator"
+			handlerFun = func() sdk.Result {
+				return handleMsgDestroyOperator(ctx, k, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("unrecognized dex message type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -85,6 +100,7 @@ func handleMsgList(ctx sdk.Context, keeper IKeeper, msg MsgList, logger log.Logg
 		Delisting:        false,
 		Deposits:         DefaultTokenPairDeposit,
 		BlockHeight:      ctx.BlockHeight(),
+		AuctionType:      msg.AuctionType,
 	}
 
 	// check whether a specific token pair exists with the symbols of base asset and quote asset
@@ -120,6 +136,7 @@ func handleMsgList(ctx sdk.Context, keeper IKeeper, msg MsgList, logger log.Logg
 			sdk.NewAttribute("max-size-digit", strconv.FormatInt(tokenPair.MaxQuantityDigit, 10)),
 			sdk.NewAttribute("min-trade-size", tokenPair.MinQuantity.String()),
 			sdk.NewAttribute("delisting", fmt.Sprintf("%t", tokenPair.Delisting)),
+			sdk.NewAttribute("auction-type", tokenPair.GetAuctionType()),
 			sdk.NewAttribute(sdk.AttributeKeyFee, feeCoins.String()),
 		),
 	)
@@ -207,23 +224,25 @@ func handleMsgCreateOperator(ctx sdk.Context, keeper IKeeper, msg MsgCreateOpera
 	if _, isExist := keeper.GetOperator(ctx, msg.Owner); isExist {
 		return types.ErrExistOperator(msg.Owner).Result()
 	}
+	// deduction fee, which is kept as the deposits of the operator and refunded when the operator is destroyed
+	registerOperatorFee := keeper.GetParams(ctx).RegisterOperatorFee
+	feeCoins := registerOperatorFee.ToCoins()
+	err := keeper.GetSupplyKeeper().SendCoinsFromAccountToModule(ctx, msg.Owner, types.ModuleName, feeCoins)
+	if err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
+			feeCoins.String())).Result()
+	}
+
 	operator := types.DEXOperator{
 		Address:            msg.Owner,
 		HandlingFeeAddress: msg.HandlingFeeAddress,
 		Website:            msg.Website,
 		InitHeight:         ctx.BlockHeight(),
 		TxHash:             fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
+		Deposits:           registerOperatorFee,
 	}
 	keeper.SetOperator(ctx, operator)
 
-	// deduction fee
-	feeCoins := keeper.GetParams(ctx).RegisterOperatorFee.ToCoins()
-	err := keeper.GetSupplyKeeper().SendCoinsFromAccountToModule(ctx, msg.Owner, keeper.GetFeeCollector(), feeCoins)
-	if err != nil {
-		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
-			feeCoins.String())).Result()
-	}
-
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -261,3 +280,134 @@ func handleMsgUpdateOperator(ctx sdk.Context, keeper IKeeper, msg MsgUpdateOpera
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+func handleMsgDestroyOperator(ctx sdk.Context, keeper IKeeper, msg MsgDestroyOperator, logger log.Logger) sdk.Result {
+
+	logger.Debug(fmt.Sprintf("handleMsgDestroyOperator msg: %+v", msg))
+
+	operator, isExist := keeper.GetOperator(ctx, msg.Owner)
+	if !isExist {
+		return types.ErrUnknownOperator(msg.Owner).Result()
+	}
+
+	// migrate the token pairs to the successor, the transfer ownership fee is charged for each token pair
+	tokenPairs := keeper.GetUserTokenPairs(ctx, msg.Owner)
+	if len(tokenPairs) > 0 {
+		if msg.Successor.Empty() {
+			return types.ErrOperatorOwnsTokenPairs(msg.Owner, len(tokenPairs)).Result()
+		}
+		if _, exist := keeper.GetOperator(ctx, msg.Successor); !exist {
+			return types.ErrUnknownOperator(msg.Successor).Result()
+		}
+
+		feeCoins := keeper.GetParams(ctx).TransferOwnershipFee.ToCoins()
+		for _, tokenPair := range tokenPairs {
+			err := keeper.GetSupplyKeeper().SendCoinsFromAccountToModule(ctx, msg.Owner, keeper.GetFeeCollector(), feeCoins)
+			if err != nil {
+				return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
+					feeCoins.String())).Result()
+			}
+			if sdkErr := keeper.TransferOwnership(ctx, tokenPair.Name(), msg.Owner, msg.Successor); sdkErr != nil {
+				return sdkErr.Result()
+			}
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					sdk.EventTypeMessage,
+					sdk.NewAttribute("token-pair-migrated", tokenPair.Name()),
+					sdk.NewAttribute(sdk.AttributeKeyFee, feeCoins.String()),
+				),
+			)
+		}
+	}
+
+	if sdkErr := keeper.DestroyOperator(ctx, msg.Owner); sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgDestroyOperator: "+
+		"BlockHeight: %d, Msg: %+v, Deposits: %s", ctx.BlockHeight(), msg, operator.Deposits))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgSetFeeTiers(ctx sdk.Context, keeper IKeeper, msg MsgSetFeeTiers, logger log.Logger) sdk.Result {
+
+	logger.Debug(fmt.Sprintf("handleMsgSetFeeTiers msg: %+v", msg))
+
+	operator, isExist := keeper.GetOperator(ctx, msg.Owner)
+	if !isExist {
+		return types.ErrUnknownOperator(msg.Owner).Result()
+	}
+
+	if msg.Product == "" {
+		operator.FeeTiers = msg.FeeTiers
+		keeper.SetOperator(ctx, operator)
+	} else {
+		tokenPair := keeper.GetTokenPair(ctx, msg.Product)
+		if tokenPair == nil {
+			return types.ErrTokenPairNotFound(msg.Product).Result()
+		}
+		if !tokenPair.Owner.Equals(msg.Owner) {
+			return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of product(%s)",
+				msg.Owner.String(), msg.Product)).Result()
+		}
+		tokenPair.FeeTiers = msg.FeeTiers
+		keeper.UpdateTokenPair(ctx, msg.Product, tokenPair)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgEditTokenPair(ctx sdk.Context, keeper IKeeper, msg MsgEditTokenPair, logger log.Logger) sdk.Result {
+
+	logger.Debug(fmt.Sprintf("handleMsgEditTokenPair msg: %+v", msg))
+
+	if !keeper.GetParams(ctx).EditTokenPairEnabled {
+		return sdk.ErrUnauthorized("editing token pairs is disabled by governance").Result()
+	}
+
+	tokenPair := keeper.GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return types.ErrTokenPairNotFound(msg.Product).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of product(%s)",
+			msg.Owner.String(), msg.Product)).Result()
+	}
+	if keeper.IsTokenPairLocked(ctx, msg.Product) {
+		return sdk.ErrInternal(fmt.Sprintf("the trading pair (%s) is locked by the matching", msg.Product)).Result()
+	}
+
+	tokenPair.MaxPriceDigit = msg.MaxPriceDigit
+	tokenPair.MaxQuantityDigit = msg.MaxQuantityDigit
+	tokenPair.MinQuantity = msg.MinQuantity
+	keeper.UpdateTokenPair(ctx, msg.Product, tokenPair)
+	keeper.SetTokenPairEdited(ctx, msg.Product)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+			sdk.NewAttribute("product", msg.Product),
+			sdk.NewAttribute("max-price-digit", strconv.FormatInt(tokenPair.MaxPriceDigit, 10)),
+			sdk.NewAttribute("max-size-digit", strconv.FormatInt(tokenPair.MaxQuantityDigit, 10)),
+			sdk.NewAttribute("min-trade-size", tokenPair.MinQuantity.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -133,3 +133,166 @@ func TestHandler_handleMsgTransferOwnership(t *testing.T) {
 	spKeeper.behaveEvil = true
 	handlerFunctor(ctx, msgFailedTransferOwnership)
 }
+
+func TestHandler_HandleMsgSetFeeTiers(t *testing.T) {
+	mApp, _, _, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	mDexKeeper.getFakeTokenPair = false
+
+	tokenPair := GetBuiltInTokenPair()
+	err := mDexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+	other := mApp.GenesisAccounts[0].GetAddress()
+	feeTiers := types.FeeTiers{
+		types.NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+		types.NewFeeTier(sdk.NewDec(1000), sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001")),
+	}
+
+	// fail case : the sender is not an operator
+	result := handlerFunctor(ctx, types.NewMsgSetFeeTiers(tokenPair.Owner, tokenPair.Name(), feeTiers))
+	require.False(t, result.IsOK())
+
+	mDexKeeper.SetOperator(ctx, types.DEXOperator{Address: tokenPair.Owner, HandlingFeeAddress: tokenPair.Owner})
+	mDexKeeper.SetOperator(ctx, types.DEXOperator{Address: other, HandlingFeeAddress: other})
+
+	// fail case : the product does not exist
+	result = handlerFunctor(ctx, types.NewMsgSetFeeTiers(tokenPair.Owner, "no-product", feeTiers))
+	require.False(t, result.IsOK())
+
+	// fail case : the sender is not the owner of the product
+	result = handlerFunctor(ctx, types.NewMsgSetFeeTiers(other, tokenPair.Name(), feeTiers))
+	require.False(t, result.IsOK())
+
+	// successful case : set the fee tiers of the product
+	result = handlerFunctor(ctx, types.NewMsgSetFeeTiers(tokenPair.Owner, tokenPair.Name(), feeTiers))
+	require.True(t, result.IsOK())
+	require.EqualValues(t, feeTiers, mDexKeeper.GetTokenPair(ctx, tokenPair.Name()).FeeTiers)
+
+	// successful case : set the default fee tiers of the operator
+	result = handlerFunctor(ctx, types.NewMsgSetFeeTiers(other, "", feeTiers[:1]))
+	require.True(t, result.IsOK())
+	operator, _ := mDexKeeper.GetOperator(ctx, other)
+	require.EqualValues(t, feeTiers[:1], operator.FeeTiers)
+
+	// successful case : reset the fee tiers of the product
+	result = handlerFunctor(ctx, types.NewMsgSetFeeTiers(tokenPair.Owner, tokenPair.Name(), nil))
+	require.True(t, result.IsOK())
+	require.Empty(t, mDexKeeper.GetTokenPair(ctx, tokenPair.Name()).FeeTiers)
+}
+
+func TestHandler_HandleMsgEditTokenPair(t *testing.T) {
+	mApp, _, _, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	mDexKeeper.getFakeTokenPair = false
+
+	tokenPair := GetBuiltInTokenPair()
+	err := mDexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+	other := mApp.GenesisAccounts[0].GetAddress()
+	minQuantity := sdk.MustNewDecFromStr("0.01")
+
+	// fail case : the product does not exist
+	result := handlerFunctor(ctx, types.NewMsgEditTokenPair(tokenPair.Owner, "no-product", 2, 2, minQuantity))
+	require.False(t, result.IsOK())
+
+	// fail case : the sender is not the owner of the product
+	result = handlerFunctor(ctx, types.NewMsgEditTokenPair(other, tokenPair.Name(), 2, 2, minQuantity))
+	require.False(t, result.IsOK())
+
+	// successful case
+	result = handlerFunctor(ctx, types.NewMsgEditTokenPair(tokenPair.Owner, tokenPair.Name(), 2, 2, minQuantity))
+	require.True(t, result.IsOK())
+	newTokenPair := mDexKeeper.GetTokenPair(ctx, tokenPair.Name())
+	require.EqualValues(t, 2, newTokenPair.MaxPriceDigit)
+	require.EqualValues(t, 2, newTokenPair.MaxQuantityDigit)
+	require.Equal(t, minQuantity, newTokenPair.MinQuantity)
+	require.Equal(t, []string{tokenPair.Name()}, mDexKeeper.GetEditedTokenPairs(ctx))
+	mDexKeeper.ClearEditedTokenPairs(ctx)
+	require.Empty(t, mDexKeeper.GetEditedTokenPairs(ctx))
+
+	// fail case : editing token pairs is disabled by governance
+	params := mDexKeeper.GetParams(ctx)
+	params.EditTokenPairEnabled = false
+	mDexKeeper.SetParams(ctx, params)
+	result = handlerFunctor(ctx, types.NewMsgEditTokenPair(tokenPair.Owner, tokenPair.Name(), 4, 4, minQuantity))
+	require.False(t, result.IsOK())
+}
+
+func TestHandler_HandleMsgDestroyOperator(t *testing.T) {
+	mApp, _, spKeeper, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	mDexKeeper.getFakeTokenPair = false
+
+	tokenPair := GetBuiltInTokenPair()
+	err := mDexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+	owner := tokenPair.Owner
+	successor := mApp.GenesisAccounts[0].GetAddress()
+	deposits := sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(10))
+
+	// fail case : the sender is not an operator
+	result := handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, nil))
+	require.False(t, result.IsOK())
+
+	mDexKeeper.SetOperator(ctx, types.DEXOperator{Address: owner, HandlingFeeAddress: owner, Deposits: deposits})
+
+	// fail case : the operator owns token pairs but no successor
+	result = handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, nil))
+	require.False(t, result.IsOK())
+
+	// fail case : the successor is not an operator
+	result = handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, successor))
+	require.False(t, result.IsOK())
+
+	mDexKeeper.SetOperator(ctx, types.DEXOperator{Address: successor, HandlingFeeAddress: successor})
+
+	// fail case : failed to pay the transfer ownership fee
+	spKeeper.behaveEvil = true
+	result = handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, successor))
+	require.False(t, result.IsOK())
+	require.Equal(t, owner, mDexKeeper.GetTokenPair(ctx, tokenPair.Name()).Owner)
+	spKeeper.behaveEvil = false
+
+	// successful case : the token pairs are migrated to the successor and the deposits are refunded later
+	result = handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, successor))
+	require.True(t, result.IsOK())
+	_, exist := mDexKeeper.GetOperator(ctx, owner)
+	require.False(t, exist)
+	require.Equal(t, successor, mDexKeeper.GetTokenPair(ctx, tokenPair.Name()).Owner)
+	require.Empty(t, mDexKeeper.GetUserTokenPairs(ctx, owner))
+	withdrawInfo, ok := mDexKeeper.GetWithdrawInfo(ctx, owner)
+	require.True(t, ok)
+	require.True(t, deposits.IsEqual(withdrawInfo.Deposits))
+	require.Equal(t, ctx.BlockHeader().Time.Add(mDexKeeper.GetParams(ctx).WithdrawPeriod), withdrawInfo.CompleteTime)
+
+	// fail case : the operator is destroyed already
+	result = handlerFunctor(ctx, types.NewMsgDestroyOperator(owner, nil))
+	require.False(t, result.IsOK())
+}
+
+func TestHandler_HandleMsgCreateOperator(t *testing.T) {
+	mApp, _, spKeeper, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+	owner := mApp.GenesisAccounts[0].GetAddress()
+
+	params := mDexKeeper.GetParams(ctx)
+	params.RegisterOperatorFee = sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(10))
+	mDexKeeper.SetParams(ctx, params)
+
+	// fail case : failed to pay the register operator fee
+	spKeeper.behaveEvil = true
+	result := handlerFunctor(ctx, types.NewMsgCreateOperator("", owner, nil))
+	require.False(t, result.IsOK())
+	spKeeper.behaveEvil = false
+
+	// successful case : the register operator fee is kept as the deposits of the operator
+	result = handlerFunctor(ctx, types.NewMsgCreateOperator("", owner, nil))
+	require.True(t, result.IsOK())
+	operator, exist := mDexKeeper.GetOperator(ctx, owner)
+	require.True(t, exist)
+	require.True(t, params.RegisterOperatorFee.IsEqual(operator.Deposits))
+
+	// fail case : the operator exists
+	result = handlerFunctor(ctx, types.NewMsgCreateOperator("", owner, nil))
+	require.False(t, result.IsOK())
+}
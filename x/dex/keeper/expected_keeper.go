@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 	"github.com/okex/okchain/x/dex/types"
+	govTypes "github.com/okex/okchain/x/gov/types"
 	ordertypes "github.com/okex/okchain/x/order/types"
 	"github.com/okex/okchain/x/params"
 )
@@ -16,6 +17,7 @@ type SupplyKeeper interface {
 		recipientModule string, amt sdk.Coins) sdk.Error
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string,
 		recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) sdk.Error
 	GetModuleAccount(ctx sdk.Context, moduleName string) exported.ModuleAccountI
 	GetModuleAddress(moduleName string) sdk.AccAddress
 	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error
@@ -33,6 +35,7 @@ type IKeeper interface {
 	GetUserTokenPairs(ctx sdk.Context, owner sdk.AccAddress) []*types.TokenPair
 	GetTokenPairsOrdered(ctx sdk.Context) types.TokenPairs
 	SaveTokenPair(ctx sdk.Context, tokenPair *types.TokenPair) error
+	UpdateTokenPair(ctx sdk.Context, product string, tokenPair *types.TokenPair)
 	DeleteTokenPairByName(ctx sdk.Context, owner sdk.AccAddress, tokenPairName string)
 	Deposit(ctx sdk.Context, product string, from sdk.AccAddress, amount sdk.DecCoin) sdk.Error
 	Withdraw(ctx sdk.Context, product string, to sdk.AccAddress, amount sdk.DecCoin) sdk.Error
@@ -44,6 +47,8 @@ type IKeeper interface {
 	SetParams(ctx sdk.Context, params types.Params)
 	GetFeeCollector() string
 	TransferOwnership(ctx sdk.Context, product string, from sdk.AccAddress, to sdk.AccAddress) sdk.Error
+	SetTokenPairEdited(ctx sdk.Context, product string)
+	IsTokenPairLocked(ctx sdk.Context, product string) bool
 	LockTokenPair(ctx sdk.Context, product string, lock *ordertypes.ProductLock)
 	LoadProductLocks(ctx sdk.Context) *ordertypes.ProductLockMap
 	SetWithdrawInfo(ctx sdk.Context, withdrawInfo types.WithdrawInfo)
@@ -55,8 +60,17 @@ type IKeeper interface {
 	SetOperator(ctx sdk.Context, operator types.DEXOperator)
 	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator types.DEXOperator, isExist bool)
 	IterateOperators(ctx sdk.Context, cb func(operator types.DEXOperator) (stop bool))
+	DestroyOperator(ctx sdk.Context, addr sdk.AccAddress) sdk.Error
 	GetMaxTokenPairID(ctx sdk.Context) (tokenPairMaxID uint64)
 	SetMaxTokenPairID(ctx sdk.Context, tokenPairMaxID uint64)
+	AddOperatorFee(ctx sdk.Context, operator sdk.AccAddress, product string, fee sdk.DecCoins)
+	SetOperatorFees(ctx sdk.Context, operatorFees types.OperatorFees)
+	GetOperatorFees(ctx sdk.Context, operator sdk.AccAddress) types.OperatorFees
+	GetAllOperatorFees(ctx sdk.Context) []types.OperatorFees
+	SetOperatorFeeStatement(ctx sdk.Context, statement types.OperatorFeeStatement)
+	GetOperatorFeeStatement(ctx sdk.Context, operator sdk.AccAddress, epoch int64) (statement types.OperatorFeeStatement, found bool)
+	GetOperatorFeeStatements(ctx sdk.Context, operator sdk.AccAddress) []types.OperatorFeeStatement
+	GetAllOperatorFeeStatements(ctx sdk.Context) []types.OperatorFeeStatement
 }
 
 // StakingKeeper defines the expected staking Keeper (noalias)
@@ -72,4 +86,6 @@ type BankKeeper interface {
 // GovKeeper defines the expected gov Keeper
 type GovKeeper interface {
 	RemoveFromActiveProposalQueue(ctx sdk.Context, proposalID uint64, endTime time.Time)
+	GetDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) (deposit govTypes.Deposit, found bool)
+	SetDeposit(ctx sdk.Context, deposit govTypes.Deposit)
 }
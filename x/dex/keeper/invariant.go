@@ -13,7 +13,7 @@ func RegisterInvariants(ir sdk.InvariantRegistry, keeper IKeeper, supplyKeeper S
 }
 
 // ModuleAccountInvariant checks that the module account coins reflects the sum of
-// locks amounts held on store
+// locks amounts held on store, i.e. the deposits of token pairs and operators and the withdrawing deposits
 func ModuleAccountInvariant(keeper IKeeper, supplyKeeper SupplyKeeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
 		var depositsCoins, withdrawCoins sdk.DecCoins
@@ -31,6 +31,14 @@ func ModuleAccountInvariant(keeper IKeeper, supplyKeeper SupplyKeeper) sdk.Invar
 			return false
 		})
 
+		// get operator deposits
+		keeper.IterateOperators(ctx, func(operator types.DEXOperator) (stop bool) {
+			if operator.Deposits.IsPositive() {
+				depositsCoins = depositsCoins.Add(sdk.DecCoins{operator.Deposits})
+			}
+			return false
+		})
+
 		moduleAcc := supplyKeeper.GetModuleAccount(ctx, types.ModuleName)
 
 		broken := !moduleAcc.GetCoins().IsEqual(depositsCoins.Add(withdrawCoins))
@@ -47,4 +47,15 @@ func TestModuleAccountInvariant(t *testing.T) {
 	_, broken = invariant(ctx)
 	require.False(t, broken)
 
+	// register an operator with 10 okt deposits
+	operatorDeposits := sdk.NewDecCoin(builtInTP.QuoteAssetSymbol, sdk.NewInt(10))
+	err = keeper.supplyKeeper.SendCoinsFromAccountToModule(ctx, accounts[0], types.ModuleName, operatorDeposits.ToCoins())
+	require.Nil(t, err)
+	keeper.SetOperator(ctx, types.DEXOperator{Address: accounts[0], HandlingFeeAddress: accounts[0],
+		Deposits: operatorDeposits})
+
+	// module acount balance 110okt
+	// xxb_okt deposits 50 okt. operator deposits 10 okt. withdraw info 50 okt
+	_, broken = invariant(ctx)
+	require.False(t, broken)
 }
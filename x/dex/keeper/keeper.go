@@ -204,6 +204,33 @@ func (k Keeper) UpdateTokenPair(ctx sdk.Context, product string, tokenPair *type
 	}
 }
 
+// SetTokenPairEdited records that the trading rules of the token pair are edited in the block,
+// for the order module to cancel the open orders which don't fit the new rules
+func (k Keeper) SetTokenPairEdited(ctx sdk.Context, product string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetEditedTokenPairKey(product), []byte(product))
+}
+
+// GetEditedTokenPairs returns the token pairs whose trading rules are edited in the block
+func (k Keeper) GetEditedTokenPairs(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.EditedTokenPairKeyPrefix)
+	defer iter.Close()
+
+	var products []string
+	for ; iter.Valid(); iter.Next() {
+		products = append(products, string(iter.Value()))
+	}
+	return products
+}
+
+// ClearEditedTokenPairs clears the records of the edited token pairs after their open orders are handled
+func (k Keeper) ClearEditedTokenPairs(ctx sdk.Context) {
+	for _, product := range k.GetEditedTokenPairs(ctx) {
+		ctx.KVStore(k.storeKey).Delete(types.GetEditedTokenPairKey(product))
+	}
+}
+
 // CheckTokenPairUnderDexDelist checks if token pair is under delist. for x/order: It's not allowed to place an order about the tokenpair under dex delist
 func (k Keeper) CheckTokenPairUnderDexDelist(ctx sdk.Context, product string) (isDelisting bool, err error) {
 	tp := k.GetTokenPair(ctx, product)
@@ -261,26 +288,39 @@ func (k Keeper) Withdraw(ctx sdk.Context, product string, to sdk.AccAddress, amo
 		return sdk.ErrInsufficientCoins(fmt.Sprintf("failed to withdraws because deposits:%s is less than withdraw:%s", tokenPair.Deposits.String(), amount.String()))
 	}
 
-	completeTime := ctx.BlockHeader().Time.Add(k.GetParams(ctx).WithdrawPeriod)
 	// add withdraw info to store
-	withdrawInfo, ok := k.GetWithdrawInfo(ctx, to)
+	if err := k.addWithdrawInfo(ctx, to, amount); err != nil {
+		return err
+	}
+
+	// update token pair
+	tokenPair.Deposits = tokenPair.Deposits.Sub(amount)
+	k.UpdateTokenPair(ctx, product, tokenPair)
+	return nil
+}
+
+// addWithdrawInfo adds the amount to the withdrawing deposits of the address,
+// which are paid back after the withdraw period
+func (k Keeper) addWithdrawInfo(ctx sdk.Context, addr sdk.AccAddress, amount sdk.DecCoin) sdk.Error {
+	completeTime := ctx.BlockHeader().Time.Add(k.GetParams(ctx).WithdrawPeriod)
+	withdrawInfo, ok := k.GetWithdrawInfo(ctx, addr)
 	if !ok {
 		withdrawInfo = types.WithdrawInfo{
-			Owner:        to,
+			Owner:        addr,
 			Deposits:     amount,
 			CompleteTime: completeTime,
 		}
 	} else {
-		k.DeleteWithdrawCompleteTimeAddress(ctx, withdrawInfo.CompleteTime, to)
+		if withdrawInfo.Deposits.Denom != amount.Denom {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("failed to withdraw %s because %s of %s is withdrawing",
+				amount, withdrawInfo.Deposits, addr))
+		}
+		k.DeleteWithdrawCompleteTimeAddress(ctx, withdrawInfo.CompleteTime, addr)
 		withdrawInfo.Deposits = withdrawInfo.Deposits.Add(amount)
 		withdrawInfo.CompleteTime = completeTime
 	}
 	k.SetWithdrawInfo(ctx, withdrawInfo)
-	k.SetWithdrawCompleteTimeAddress(ctx, completeTime, to)
-
-	// update token pair
-	tokenPair.Deposits = tokenPair.Deposits.Sub(amount)
-	k.UpdateTokenPair(ctx, product, tokenPair)
+	k.SetWithdrawCompleteTimeAddress(ctx, completeTime, addr)
 	return nil
 }
 
@@ -313,7 +353,11 @@ func (k Keeper) SortProducts(ctx sdk.Context, products []string) {
 
 // GetParams gets inflation params from the global param store
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.GetParamSubspace().GetParamSet(ctx, &params)
+	// the params added after the chain started are not stored until set by governance, their defaults are used
+	params = *types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.GetParamSubspace().GetIfExists(ctx, pair.Key, pair.Value)
+	}
 	return params
 }
 
@@ -466,6 +510,28 @@ func (k Keeper) GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator type
 	return operator, true
 }
 
+// DestroyOperator deletes the operator, which should own no token pair.
+// The deposits of the operator are paid back after the withdraw period
+func (k Keeper) DestroyOperator(ctx sdk.Context, addr sdk.AccAddress) sdk.Error {
+	operator, exists := k.GetOperator(ctx, addr)
+	if !exists {
+		return types.ErrUnknownOperator(addr)
+	}
+
+	if tokenPairs := k.GetUserTokenPairs(ctx, addr); len(tokenPairs) > 0 {
+		return types.ErrOperatorOwnsTokenPairs(addr, len(tokenPairs))
+	}
+
+	if operator.Deposits.IsPositive() {
+		if err := k.addWithdrawInfo(ctx, addr, operator.Deposits); err != nil {
+			return err
+		}
+	}
+
+	ctx.KVStore(k.storeKey).Delete(types.GetOperatorAddressKey(addr))
+	return nil
+}
+
 // IterateOperators iterates over the all the operators and performs a callback function
 func (k Keeper) IterateOperators(ctx sdk.Context, cb func(operator types.DEXOperator) (stop bool)) {
 	store := ctx.KVStore(k.storeKey)
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -460,3 +461,75 @@ func TestKeeper_CheckTokenPairUnderDexDelist(t *testing.T) {
 	require.Equal(t, isDelisting, tokenPair.Delisting)
 
 }
+
+func TestDestroyOperator(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+	keeper.SetParams(ctx, *types.DefaultParams())
+	owner := testInput.TestAddrs[0]
+
+	// fail case : the operator doesn't exist
+	err := keeper.DestroyOperator(ctx, owner)
+	require.NotNil(t, err)
+
+	deposits := sdk.NewDecCoin(common.NativeToken, sdk.NewInt(10))
+	keeper.SetOperator(ctx, types.DEXOperator{Address: owner, HandlingFeeAddress: owner, Deposits: deposits})
+	tokenPair := GetBuiltInTokenPair()
+	tokenPair.Owner = owner
+	require.Nil(t, keeper.SaveTokenPair(ctx, tokenPair))
+
+	// fail case : the operator owns token pairs
+	err = keeper.DestroyOperator(ctx, owner)
+	require.NotNil(t, err)
+	_, exists := keeper.GetOperator(ctx, owner)
+	require.True(t, exists)
+
+	// fail case : a withdrawing of the other denom is in the queue
+	keeper.DeleteTokenPairByName(ctx, owner, tokenPair.Name())
+	keeper.SetWithdrawInfo(ctx, types.WithdrawInfo{Owner: owner,
+		Deposits: sdk.NewDecCoin(common.TestToken, sdk.NewInt(1)), CompleteTime: ctx.BlockHeader().Time})
+	err = keeper.DestroyOperator(ctx, owner)
+	require.NotNil(t, err)
+	keeper.deleteWithdrawInfo(ctx, owner)
+
+	// successful case : the deposits are added to the withdraw queue
+	err = keeper.DestroyOperator(ctx, owner)
+	require.Nil(t, err)
+	_, exists = keeper.GetOperator(ctx, owner)
+	require.False(t, exists)
+	withdrawInfo, ok := keeper.GetWithdrawInfo(ctx, owner)
+	require.True(t, ok)
+	require.True(t, deposits.IsEqual(withdrawInfo.Deposits))
+
+	var addrs []sdk.AccAddress
+	completeTime := ctx.BlockHeader().Time.Add(keeper.GetParams(ctx).WithdrawPeriod)
+	keeper.IterateWithdrawAddress(ctx, completeTime, func(_ int64, key []byte) (stop bool) {
+		_, addr := types.SplitWithdrawTimeKey(key)
+		addrs = append(addrs, addr)
+		return false
+	})
+	require.Equal(t, []sdk.AccAddress{owner}, addrs)
+}
+
+func TestKeeper_GetParamsAddedLater(t *testing.T) {
+	testInput := createTestInput(t)
+	keeper := testInput.DexKeeper
+	ctx := testInput.Ctx
+
+	// only the params of the chain started before EditTokenPairEnabled was added are stored
+	stored := *types.DefaultParams()
+	stored.WithdrawPeriod = time.Hour
+	stored.EditTokenPairEnabled = false
+	for _, pair := range stored.ParamSetPairs() {
+		if bytes.Equal(pair.Key, []byte("EditTokenPairEnabled")) {
+			break
+		}
+		keeper.GetParamSubspace().Set(ctx, pair.Key, pair.Value)
+	}
+
+	// the params not stored take the default values
+	expected := stored
+	expected.EditTokenPairEnabled = types.DefaultParams().EditTokenPairEnabled
+	require.Equal(t, expected, keeper.GetParams(ctx))
+}
@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/dex/types"
+)
+
+// AddOperatorFee accounts the fee of the token pair paid to the operator,
+// both in the accrued fees of the operator and in its fee statement of the current epoch
+func (k Keeper) AddOperatorFee(ctx sdk.Context, operator sdk.AccAddress, product string, fee sdk.DecCoins) {
+	if fee.IsZero() {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+
+	feeKey := types.GetOperatorFeeKey(operator, product)
+	store.Set(feeKey, k.cdc.MustMarshalBinaryLengthPrefixed(k.getDecCoins(store, feeKey).Add(fee)))
+
+	statementKey := types.GetOperatorFeeStatementKey(operator, types.GetOperatorFeeEpoch(ctx.BlockHeight()), product)
+	store.Set(statementKey, k.cdc.MustMarshalBinaryLengthPrefixed(k.getDecCoins(store, statementKey).Add(fee)))
+}
+
+func (k Keeper) getDecCoins(store sdk.KVStore, key []byte) (coins sdk.DecCoins) {
+	bz := store.Get(key)
+	if bz == nil {
+		return sdk.DecCoins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &coins)
+	return coins
+}
+
+// SetOperatorFees sets the fees accrued by the operator from its token pairs
+func (k Keeper) SetOperatorFees(ctx sdk.Context, operatorFees types.OperatorFees) {
+	store := ctx.KVStore(k.storeKey)
+	for _, f := range operatorFees.Fees {
+		store.Set(types.GetOperatorFeeKey(operatorFees.Operator, f.Product), k.cdc.MustMarshalBinaryLengthPrefixed(f.Fee))
+	}
+}
+
+// GetOperatorFees returns the fees accrued by the operator from its token pairs
+func (k Keeper) GetOperatorFees(ctx sdk.Context, operator sdk.AccAddress) types.OperatorFees {
+	allOperatorFees := k.getOperatorFees(ctx, types.GetOperatorFeePrefix(operator))
+	if len(allOperatorFees) == 0 {
+		return types.NewOperatorFees(operator, nil)
+	}
+	return allOperatorFees[0]
+}
+
+// GetAllOperatorFees returns the fees accrued by all the operators
+func (k Keeper) GetAllOperatorFees(ctx sdk.Context) []types.OperatorFees {
+	return k.getOperatorFees(ctx, types.OperatorFeeKeyPrefix)
+}
+
+// getOperatorFees groups the accrued fees under the prefix by operator
+func (k Keeper) getOperatorFees(ctx sdk.Context, prefix []byte) (allOperatorFees []types.OperatorFees) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	var operator sdk.AccAddress
+	var fees types.ProductFees
+	for ; iter.Valid(); iter.Next() {
+		addr, product := types.SplitOperatorFeeKey(iter.Key())
+		if !addr.Equals(operator) {
+			if fees != nil {
+				allOperatorFees = append(allOperatorFees, types.NewOperatorFees(operator, fees))
+			}
+			operator, fees = addr, types.ProductFees{}
+		}
+		var fee sdk.DecCoins
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &fee)
+		fees = append(fees, types.ProductFee{Product: product, Fee: fee})
+	}
+	if fees != nil {
+		allOperatorFees = append(allOperatorFees, types.NewOperatorFees(operator, fees))
+	}
+	return allOperatorFees
+}
+
+// SetOperatorFeeStatement sets the fee statement of the operator in the epoch
+func (k Keeper) SetOperatorFeeStatement(ctx sdk.Context, statement types.OperatorFeeStatement) {
+	store := ctx.KVStore(k.storeKey)
+	for _, f := range statement.Fees {
+		store.Set(types.GetOperatorFeeStatementKey(statement.Operator, statement.Epoch, f.Product),
+			k.cdc.MustMarshalBinaryLengthPrefixed(f.Fee))
+	}
+}
+
+// GetOperatorFeeStatement returns the fee statement of the operator in the epoch
+func (k Keeper) GetOperatorFeeStatement(ctx sdk.Context, operator sdk.AccAddress,
+	epoch int64) (statement types.OperatorFeeStatement, found bool) {
+	statements := k.getOperatorFeeStatements(ctx, types.GetOperatorFeeStatementEpochPrefix(operator, epoch))
+	if len(statements) == 0 {
+		return statement, false
+	}
+	return statements[0], true
+}
+
+// GetOperatorFeeStatements returns the fee statements of the operator sorted by epoch
+func (k Keeper) GetOperatorFeeStatements(ctx sdk.Context, operator sdk.AccAddress) []types.OperatorFeeStatement {
+	return k.getOperatorFeeStatements(ctx, types.GetOperatorFeeStatementPrefix(operator))
+}
+
+// GetAllOperatorFeeStatements returns the fee statements of all the operators
+func (k Keeper) GetAllOperatorFeeStatements(ctx sdk.Context) []types.OperatorFeeStatement {
+	return k.getOperatorFeeStatements(ctx, types.OperatorFeeStatementKeyPrefix)
+}
+
+// getOperatorFeeStatements groups the fees under the prefix by operator and epoch
+func (k Keeper) getOperatorFeeStatements(ctx sdk.Context, prefix []byte) (statements []types.OperatorFeeStatement) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	var operator sdk.AccAddress
+	var epoch int64
+	var fees types.ProductFees
+	for ; iter.Valid(); iter.Next() {
+		addr, e, product := types.SplitOperatorFeeStatementKey(iter.Key())
+		if !addr.Equals(operator) || e != epoch {
+			if fees != nil {
+				statements = append(statements, types.NewOperatorFeeStatement(operator, epoch, fees))
+			}
+			operator, epoch, fees = addr, e, types.ProductFees{}
+		}
+		var fee sdk.DecCoins
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &fee)
+		fees = append(fees, types.ProductFee{Product: product, Fee: fee})
+	}
+	if fees != nil {
+		statements = append(statements, types.NewOperatorFeeStatement(operator, epoch, fees))
+	}
+	return statements
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex/types"
+	"github.com/stretchr/testify/require"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestKeeper_AddOperatorFee(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 2, 10000)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+	operator0, operator1 := testInput.TestAddrs[0], testInput.TestAddrs[1]
+
+	fee := func(amount int64) sdk.DecCoins {
+		return sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(amount))}
+	}
+
+	// no fee accrued
+	require.Nil(t, keeper.GetOperatorFees(ctx, operator0).Fees)
+	require.True(t, keeper.GetOperatorFees(ctx, operator0).Total.IsZero())
+	_, found := keeper.GetOperatorFeeStatement(ctx, operator0, 0)
+	require.False(t, found)
+
+	// zero fee is ignored
+	keeper.AddOperatorFee(ctx, operator0, "aaa_okt", sdk.DecCoins{})
+	require.Nil(t, keeper.GetAllOperatorFees(ctx))
+
+	// fees in epoch 0
+	ctx = ctx.WithBlockHeight(10)
+	keeper.AddOperatorFee(ctx, operator0, "aaa_okt", fee(1))
+	keeper.AddOperatorFee(ctx, operator0, "bbb_okt", fee(2))
+	keeper.AddOperatorFee(ctx, operator0, "aaa_okt", fee(3))
+	keeper.AddOperatorFee(ctx, operator1, "ccc_okt", fee(4))
+
+	// fees in epoch 1
+	ctx = ctx.WithBlockHeight(types.OperatorFeeEpochBlocks + 1)
+	keeper.AddOperatorFee(ctx, operator0, "bbb_okt", fee(5))
+
+	operatorFees := keeper.GetOperatorFees(ctx, operator0)
+	require.Equal(t, types.ProductFees{{Product: "aaa_okt", Fee: fee(4)}, {Product: "bbb_okt", Fee: fee(7)}},
+		operatorFees.Fees)
+	require.Equal(t, fee(11), operatorFees.Total)
+	require.Equal(t, fee(4), keeper.GetOperatorFees(ctx, operator1).Total)
+	require.Equal(t, 2, len(keeper.GetAllOperatorFees(ctx)))
+
+	statements := keeper.GetOperatorFeeStatements(ctx, operator0)
+	require.Equal(t, 2, len(statements))
+	require.Equal(t, int64(0), statements[0].Epoch)
+	require.Equal(t, int64(0), statements[0].StartHeight)
+	require.Equal(t, types.OperatorFeeEpochBlocks-1, statements[0].EndHeight)
+	require.Equal(t, types.ProductFees{{Product: "aaa_okt", Fee: fee(4)}, {Product: "bbb_okt", Fee: fee(2)}},
+		statements[0].Fees)
+	require.Equal(t, fee(6), statements[0].Total)
+	require.Equal(t, int64(1), statements[1].Epoch)
+	require.Equal(t, types.OperatorFeeEpochBlocks, statements[1].StartHeight)
+	require.Equal(t, fee(5), statements[1].Total)
+
+	statement, found := keeper.GetOperatorFeeStatement(ctx, operator0, 1)
+	require.True(t, found)
+	require.Equal(t, statements[1], statement)
+	require.Equal(t, 3, len(keeper.GetAllOperatorFeeStatements(ctx)))
+
+	// the statements add up to the accrued fees
+	total := sdk.DecCoins{}
+	for _, s := range statements {
+		total = total.Add(s.Total)
+	}
+	require.Equal(t, operatorFees.Total, total)
+}
+
+func TestQuerier_OperatorFees(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+	operator := testInput.TestAddrs[0]
+	querier := NewQuerier(keeper)
+
+	fee := sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1))}
+	keeper.AddOperatorFee(ctx.WithBlockHeight(1), operator, "aaa_okt", fee)
+	keeper.AddOperatorFee(ctx.WithBlockHeight(1), operator, "bbb_okt", fee)
+	keeper.AddOperatorFee(ctx.WithBlockHeight(types.OperatorFeeEpochBlocks), operator, "aaa_okt", fee)
+
+	// accrued fees of all the token pairs
+	bz, err := amino.MarshalJSON(types.NewQueryOperatorFeesParams(operator, ""))
+	require.Nil(t, err)
+	res, sdkErr := querier(ctx, []string{types.QueryOperatorFees}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var operatorFees types.OperatorFees
+	types.ModuleCdc.MustUnmarshalJSON(res, &operatorFees)
+	require.Equal(t, keeper.GetOperatorFees(ctx, operator), operatorFees)
+
+	// accrued fees of a token pair
+	bz, err = amino.MarshalJSON(types.NewQueryOperatorFeesParams(operator, "aaa_okt"))
+	require.Nil(t, err)
+	res, sdkErr = querier(ctx, []string{types.QueryOperatorFees}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	types.ModuleCdc.MustUnmarshalJSON(res, &operatorFees)
+	require.Equal(t, 1, len(operatorFees.Fees))
+	require.Equal(t, fee.Add(fee), operatorFees.Total)
+
+	// error case : empty operator
+	bz, err = amino.MarshalJSON(types.NewQueryOperatorFeesParams(nil, ""))
+	require.Nil(t, err)
+	_, sdkErr = querier(ctx, []string{types.QueryOperatorFees}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+
+	// fee statements of all the epochs, the latest comes first
+	bz, err = amino.MarshalJSON(types.NewQueryOperatorFeeStatementsParams(operator, -1, 0, 0))
+	require.Nil(t, err)
+	res, sdkErr = querier(ctx, []string{types.QueryOperatorFeeStatements}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var response struct {
+		Data struct {
+			Data []types.OperatorFeeStatement `json:"data"`
+		} `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(res, &response))
+	require.Equal(t, 2, len(response.Data.Data))
+	require.Equal(t, int64(1), response.Data.Data[0].Epoch)
+	require.Equal(t, int64(0), response.Data.Data[1].Epoch)
+
+	// fee statement of an epoch
+	bz, err = amino.MarshalJSON(types.NewQueryOperatorFeeStatementsParams(operator, 0, 0, 0))
+	require.Nil(t, err)
+	res, sdkErr = querier(ctx, []string{types.QueryOperatorFeeStatements}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	require.Nil(t, json.Unmarshal(res, &response))
+	require.Equal(t, 1, len(response.Data.Data))
+	require.Equal(t, 2, len(response.Data.Data[0].Fees))
+
+	// error case : invalid page
+	bz, err = amino.MarshalJSON(types.NewQueryOperatorFeeStatementsParams(operator, -1, -1, 10))
+	require.Nil(t, err)
+	_, sdkErr = querier(ctx, []string{types.QueryOperatorFeeStatements}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
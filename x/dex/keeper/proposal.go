@@ -13,24 +13,36 @@ import (
 
 // GetMinDeposit returns min deposit
 func (k Keeper) GetMinDeposit(ctx sdk.Context, content gov.Content) (minDeposit sdk.DecCoins) {
-	if _, ok := content.(types.DelistProposal); ok {
+	// auction type and price band proposals share the deposit and voting params with delist proposal
+	switch content.(type) {
+	case types.DelistProposal, types.AuctionTypeProposal, types.PriceBandProposal:
 		minDeposit = k.GetParams(ctx).DelistMinDeposit
+	case types.ListProposal:
+		minDeposit = k.GetParams(ctx).ListMinDeposit
 	}
 	return
 }
 
 // GetMaxDepositPeriod returns max deposit period
 func (k Keeper) GetMaxDepositPeriod(ctx sdk.Context, content gov.Content) (maxDepositPeriod time.Duration) {
-	if _, ok := content.(types.DelistProposal); ok {
+	// auction type and price band proposals share the deposit and voting params with delist proposal
+	switch content.(type) {
+	case types.DelistProposal, types.AuctionTypeProposal, types.PriceBandProposal:
 		maxDepositPeriod = k.GetParams(ctx).DelistMaxDepositPeriod
+	case types.ListProposal:
+		maxDepositPeriod = k.GetParams(ctx).ListMaxDepositPeriod
 	}
 	return
 }
 
 // GetVotingPeriod returns voting period
 func (k Keeper) GetVotingPeriod(ctx sdk.Context, content gov.Content) (votingPeriod time.Duration) {
-	if _, ok := content.(types.DelistProposal); ok {
+	// auction type and price band proposals share the deposit and voting params with delist proposal
+	switch content.(type) {
+	case types.DelistProposal, types.AuctionTypeProposal, types.PriceBandProposal:
 		votingPeriod = k.GetParams(ctx).DelistVotingPeriod
+	case types.ListProposal:
+		votingPeriod = k.GetParams(ctx).ListVotingPeriod
 	}
 	return
 }
@@ -53,8 +65,130 @@ func (k Keeper) checkMsgDelistProposal(ctx sdk.Context, delistProposal types.Del
 		return types.ErrTokenPairNotFound(fmt.Sprintf("failed to submit proposal because the asset with base asset '%s' and quote asset '%s' didn't exist on the Dex", delistProposal.BaseAsset, delistProposal.QuoteAsset))
 	}
 
-	// check the initial deposit
-	localMinDeposit := k.GetParams(ctx).DelistMinDeposit.MulDec(sdk.NewDecWithPrec(1, 1))
+	return k.checkInitialDeposit(ctx, proposer, initialDeposit, k.GetParams(ctx).DelistMinDeposit)
+}
+
+// check msg auction type proposal
+func (k Keeper) checkMsgAuctionTypeProposal(ctx sdk.Context, proposal types.AuctionTypeProposal, proposer sdk.AccAddress, initialDeposit sdk.DecCoins) sdk.Error {
+	// check the proposer of the msg is a validator
+	if !k.stakingKeeper.IsValidator(ctx, proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of auction type proposal should be a validator")
+	}
+
+	// check the propose of the msg is equal the proposer in proposal content
+	if !proposer.Equals(proposal.Proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of proposal msg should be equal the proposer in proposal content")
+	}
+
+	// check whether the token pair is in the Dex list
+	queryTokenPair := k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.BaseAsset, proposal.QuoteAsset))
+	if queryTokenPair == nil {
+		return types.ErrTokenPairNotFound(fmt.Sprintf("failed to submit proposal because the asset with base asset '%s' and quote asset '%s' didn't exist on the Dex", proposal.BaseAsset, proposal.QuoteAsset))
+	}
+
+	if queryTokenPair.GetAuctionType() == proposal.AuctionType {
+		return types.ErrInvalidAuctionType(fmt.Sprintf("failed to submit proposal because the auction type of %s is %s already", queryTokenPair.Name(), proposal.AuctionType))
+	}
+
+	return k.checkInitialDeposit(ctx, proposer, initialDeposit, k.GetParams(ctx).DelistMinDeposit)
+}
+
+// check msg price band proposal
+func (k Keeper) checkMsgPriceBandProposal(ctx sdk.Context, proposal types.PriceBandProposal, proposer sdk.AccAddress, initialDeposit sdk.DecCoins) sdk.Error {
+	// check the proposer of the msg is a validator
+	if !k.stakingKeeper.IsValidator(ctx, proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of price band proposal should be a validator")
+	}
+
+	// check the propose of the msg is equal the proposer in proposal content
+	if !proposer.Equals(proposal.Proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of proposal msg should be equal the proposer in proposal content")
+	}
+
+	// check whether the token pair is in the Dex list
+	queryTokenPair := k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.BaseAsset, proposal.QuoteAsset))
+	if queryTokenPair == nil {
+		return types.ErrTokenPairNotFound(fmt.Sprintf("failed to submit proposal because the asset with base asset '%s' and quote asset '%s' didn't exist on the Dex", proposal.BaseAsset, proposal.QuoteAsset))
+	}
+
+	if queryTokenPair.GetPriceBand().Equal(proposal.PriceBand) {
+		return types.ErrInvalidPriceBand(fmt.Sprintf("failed to submit proposal because the price band of %s is %s already", queryTokenPair.Name(), proposal.PriceBand))
+	}
+
+	return k.checkInitialDeposit(ctx, proposer, initialDeposit, k.GetParams(ctx).DelistMinDeposit)
+}
+
+// check msg list proposal
+func (k Keeper) checkMsgListProposal(ctx sdk.Context, proposal types.ListProposal, proposer sdk.AccAddress, initialDeposit sdk.DecCoins) sdk.Error {
+	// the proposer in proposal content should be the proposer of the msg, the operator's consent is checked by
+	// ValidateBasic of the proposal
+	if !proposer.Equals(proposal.Proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of proposal msg should be equal the proposer in proposal content")
+	}
+
+	if err := k.CheckListProposalContent(ctx, proposal); err != nil {
+		return err
+	}
+
+	// the list fee is charged out of the proposer's deposit when the proposal passes
+	listFee := k.GetParams(ctx).ListFee
+	if initialDeposit.AmountOf(listFee.Denom).LT(listFee.Amount) {
+		return types.ErrInvalidAsset(fmt.Sprintf("failed to submit proposal because initial deposit should cover the list fee %s", listFee.String()))
+	}
+
+	return k.checkInitialDeposit(ctx, proposer, initialDeposit, k.GetParams(ctx).ListMinDeposit)
+}
+
+// CheckListProposalContent checks that the tokens of a list proposal exist, the token pair isn't listed yet
+// and the operator is registered. It is checked again when the proposal passes
+func (k Keeper) CheckListProposalContent(ctx sdk.Context, proposal types.ListProposal) sdk.Error {
+	if !k.GetTokenKeeper().TokenExist(ctx, proposal.BaseAsset) || !k.GetTokenKeeper().TokenExist(ctx, proposal.QuoteAsset) {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("failed to list token pair because %s or %s is not valid", proposal.BaseAsset, proposal.QuoteAsset))
+	}
+
+	// Note: aaa_bbb and bbb_aaa are actually one token pair
+	if k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.BaseAsset, proposal.QuoteAsset)) != nil ||
+		k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.QuoteAsset, proposal.BaseAsset)) != nil {
+		return types.ErrTokenPairExisted(proposal.BaseAsset, proposal.QuoteAsset)
+	}
+
+	if _, exists := k.GetOperator(ctx, proposal.Operator); !exists {
+		return types.ErrUnknownOperator(proposal.Operator)
+	}
+	return nil
+}
+
+// ChargeListFee charges the list fee out of the proposer's deposit on the passed list proposal to the fee collector.
+// The rest of the deposits are refunded by gov after the proposal is handled
+func (k Keeper) ChargeListFee(ctx sdk.Context, proposalID uint64, proposer sdk.AccAddress) (sdk.DecCoins, sdk.Error) {
+	listFee := k.GetParams(ctx).ListFee
+	if !listFee.IsPositive() {
+		return sdk.DecCoins{}, nil
+	}
+
+	feeCoins := listFee.ToCoins()
+	deposit, found := k.govKeeper.GetDeposit(ctx, proposalID, proposer)
+	if !found {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient deposit of proposer %s (need %s)",
+			proposer, feeCoins))
+	}
+	rest, hasNeg := deposit.Amount.SafeSub(feeCoins)
+	if hasNeg {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient deposit of proposer %s (need %s, has %s)",
+			proposer, feeCoins, deposit.Amount))
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, gov.ModuleName, k.feeCollectorName, feeCoins); err != nil {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("failed to charge the list fee %s: %s", feeCoins, err.Error()))
+	}
+	deposit.Amount = rest
+	k.govKeeper.SetDeposit(ctx, deposit)
+	return feeCoins, nil
+}
+
+// check the initial deposit of dex proposals, which should be more than 10% of the min deposit
+func (k Keeper) checkInitialDeposit(ctx sdk.Context, proposer sdk.AccAddress, initialDeposit sdk.DecCoins, minDeposit sdk.DecCoins) sdk.Error {
+	localMinDeposit := minDeposit.MulDec(sdk.NewDecWithPrec(1, 1))
 	err := common.HasSufficientCoins(proposer, initialDeposit, localMinDeposit)
 
 	if err != nil {
@@ -74,6 +208,12 @@ func (k Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg govTypes.MsgSubmitPr
 	switch content := msg.Content.(type) {
 	case types.DelistProposal:
 		sdkErr = k.checkMsgDelistProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
+	case types.AuctionTypeProposal:
+		sdkErr = k.checkMsgAuctionTypeProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
+	case types.PriceBandProposal:
+		sdkErr = k.checkMsgPriceBandProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
+	case types.ListProposal:
+		sdkErr = k.checkMsgListProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
 	default:
 		errContent := fmt.Sprintf("unrecognized dex proposal content type: %T", content)
 		sdkErr = sdk.ErrUnknownRequest(errContent)
@@ -84,15 +224,20 @@ func (k Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg govTypes.MsgSubmitPr
 // nolint
 func (k Keeper) AfterSubmitProposalHandler(ctx sdk.Context, proposal govTypes.Proposal) {}
 
-// VoteHandler handles  delist proposal when voted
+// VoteHandler handles delist proposal, auction type proposal and price band proposal when voted
 func (k Keeper) VoteHandler(ctx sdk.Context, proposal govTypes.Proposal, vote govTypes.Vote) (string, sdk.Error) {
-	if _, ok := proposal.Content.(types.DelistProposal); ok {
-		delistProposal := proposal.Content.(types.DelistProposal)
-		tokenPairName := delistProposal.BaseAsset + "_" + delistProposal.QuoteAsset
-		if k.IsTokenPairLocked(ctx, tokenPairName) {
-			errContent := fmt.Sprintf("the trading pair (%s) is locked, please retry later", tokenPairName)
-			return "", sdk.ErrInternal(errContent)
-		}
+	var tokenPairName string
+	switch content := proposal.Content.(type) {
+	case types.DelistProposal:
+		tokenPairName = content.BaseAsset + "_" + content.QuoteAsset
+	case types.AuctionTypeProposal:
+		tokenPairName = content.BaseAsset + "_" + content.QuoteAsset
+	case types.PriceBandProposal:
+		tokenPairName = content.BaseAsset + "_" + content.QuoteAsset
+	}
+	if len(tokenPairName) > 0 && k.IsTokenPairLocked(ctx, tokenPairName) {
+		errContent := fmt.Sprintf("the trading pair (%s) is locked, please retry later", tokenPairName)
+		return "", sdk.ErrInternal(errContent)
 	}
 	return "", nil
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/okex/okchain/x/dex/types"
 	govTypes "github.com/okex/okchain/x/gov/types"
 	ordertypes "github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
+	tokentypes "github.com/okex/okchain/x/token/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,6 +93,79 @@ func TestKeeper_CheckMsgSubmitProposal(t *testing.T) {
 
 }
 
+func TestKeeper_CheckMsgAuctionTypeProposal(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+
+	testInput.DexKeeper.SetParams(ctx, *types.DefaultParams())
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewAuctionTypeProposal("continuous xxb_okb", "match xxb_okb continuously", tokenPair.Owner,
+		tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, types.AuctionTypePeriodic)
+	proposal := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, tokenPair.Owner)
+
+	// error case : fail to check proposal because product(token pair) not exist
+	err := testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	// SaveTokenPair
+	saveErr := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// error case : fail to check proposal because the auction type is not changed
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	// successful case : check proposal successfully
+	content.AuctionType = types.AuctionTypeContinuous
+	proposal.Content = content
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.NoError(t, err)
+
+	// error case: fail to check proposal because initial deposit must not be less than 100.00000000okb
+	proposal.InitialDeposit = sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1))}
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	// min deposit, max deposit period and voting period are shared with delist proposal
+	require.True(t, testInput.DexKeeper.GetMinDeposit(ctx, content).IsEqual(types.DefaultParams().DelistMinDeposit))
+	require.EqualValues(t, types.DefaultParams().DelistMaxDepositPeriod, testInput.DexKeeper.GetMaxDepositPeriod(ctx, content))
+	require.EqualValues(t, types.DefaultParams().DelistVotingPeriod, testInput.DexKeeper.GetVotingPeriod(ctx, content))
+}
+
+func TestKeeper_CheckMsgPriceBandProposal(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+
+	testInput.DexKeeper.SetParams(ctx, *types.DefaultParams())
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewPriceBandProposal("price band of xxb_okb", "no price band of xxb_okb", tokenPair.Owner,
+		tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, sdk.ZeroDec())
+	proposal := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, tokenPair.Owner)
+
+	// error case : fail to check proposal because product(token pair) not exist
+	err := testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	// SaveTokenPair
+	saveErr := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// error case : fail to check proposal because the price band is not changed
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	// successful case : check proposal successfully
+	content.PriceBand = sdk.MustNewDecFromStr("0.1")
+	proposal.Content = content
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.NoError(t, err)
+
+	// min deposit, max deposit period and voting period are shared with delist proposal
+	require.True(t, testInput.DexKeeper.GetMinDeposit(ctx, content).IsEqual(types.DefaultParams().DelistMinDeposit))
+	require.EqualValues(t, types.DefaultParams().DelistMaxDepositPeriod, testInput.DexKeeper.GetMaxDepositPeriod(ctx, content))
+	require.EqualValues(t, types.DefaultParams().DelistVotingPeriod, testInput.DexKeeper.GetVotingPeriod(ctx, content))
+}
+
 func TestKeeper_RejectedHandler(t *testing.T) {
 	testInput := createTestInputWithBalance(t, 1, 10000)
 	ctx := testInput.Ctx
@@ -154,3 +229,71 @@ func TestKeeper_VoteHandler(t *testing.T) {
 	require.NotNil(t, err)
 
 }
+
+func TestKeeper_CheckMsgListProposal(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 2, 10000)
+	ctx := testInput.Ctx
+
+	params := *types.DefaultParams()
+	params.ListFee = sdk.NewDecCoin(common.NativeToken, sdk.NewInt(100))
+	testInput.DexKeeper.SetParams(ctx, params)
+	operator := testInput.TestAddrs[0]
+
+	content := types.NewListProposal("list xxb_okb", "list xxb_okb on the dex", operator, "xxb", common.NativeToken,
+		sdk.MustNewDecFromStr("10"), operator, 4, 4, sdk.MustNewDecFromStr("0.0001"), types.AuctionTypeContinuous)
+	proposal := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, operator)
+
+	// error case : fail to check proposal because the tokens don't exist
+	err := testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	tokenKeeper := testInput.DexKeeper.GetTokenKeeper().(token.Keeper)
+	tokenKeeper.NewToken(ctx, tokentypes.Token{Symbol: "xxb", Owner: operator})
+	tokenKeeper.NewToken(ctx, tokentypes.Token{Symbol: common.NativeToken, Owner: operator})
+
+	// error case : fail to check proposal because the operator doesn't exist
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	testInput.DexKeeper.SetOperator(ctx, types.DEXOperator{Address: operator, HandlingFeeAddress: operator})
+
+	// error case : fail to check proposal because the proposer of msg isn't the proposer in content
+	proposal.Proposer = testInput.TestAddrs[1]
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	proposal.Proposer = operator
+
+	// error case : fail to check proposal because the initial deposit is less than 10% of the list min deposit
+	proposal.InitialDeposit = sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1))}
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	proposal.InitialDeposit = sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}
+
+	// error case : fail to check proposal because the initial deposit doesn't cover the list fee
+	proposal.InitialDeposit = sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(50))}
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+	proposal.InitialDeposit = sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}
+
+	// successful case : the operator can submit a list proposal without being a validator
+	testInput.DexKeeper.stakingKeeper.(*mockStakingKeeper).SetFakeValidator(false)
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.NoError(t, err)
+
+	// error case : fail to check proposal because the token pair exists in either order
+	tokenPair := &types.TokenPair{BaseAssetSymbol: common.NativeToken, QuoteAssetSymbol: "xxb", Owner: operator,
+		InitPrice: sdk.OneDec(), MinQuantity: sdk.MustNewDecFromStr("0.0001"), MaxPriceDigit: 4, MaxQuantityDigit: 4,
+		Deposits: types.DefaultTokenPairDeposit}
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	// min deposit, max deposit period and voting period come from list params
+	params = types.Params{
+		ListMinDeposit:       sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(12345))},
+		ListMaxDepositPeriod: time.Second * 123,
+		ListVotingPeriod:     time.Second * 456,
+	}
+	testInput.DexKeeper.SetParams(ctx, params)
+	require.True(t, testInput.DexKeeper.GetMinDeposit(ctx, content).IsEqual(params.ListMinDeposit))
+	require.EqualValues(t, params.ListMaxDepositPeriod, testInput.DexKeeper.GetMaxDepositPeriod(ctx, content))
+	require.EqualValues(t, params.ListVotingPeriod, testInput.DexKeeper.GetVotingPeriod(ctx, content))
+}
@@ -32,6 +32,10 @@ func NewQuerier(keeper IKeeper) sdk.Querier {
 			return queryOperator(ctx, req, keeper)
 		case types.QueryOperators:
 			return queryOperators(ctx, keeper)
+		case types.QueryOperatorFees:
+			return queryOperatorFees(ctx, req, keeper)
+		case types.QueryOperatorFeeStatements:
+			return queryOperatorFeeStatements(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown dex query endpoint")
 		}
@@ -275,3 +279,82 @@ func queryOperators(ctx sdk.Context, keeper IKeeper) ([]byte, sdk.Error) {
 	}
 	return bz, nil
 }
+
+// nolint
+func queryOperatorFees(ctx sdk.Context, req abci.RequestQuery, keeper IKeeper) ([]byte, sdk.Error) {
+	var params types.QueryOperatorFeesParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Operator.Empty() {
+		return nil, sdk.ErrInvalidAddress("empty operator address")
+	}
+
+	operatorFees := keeper.GetOperatorFees(ctx, params.Operator)
+	if params.Product != "" {
+		var fees types.ProductFees
+		for _, f := range operatorFees.Fees {
+			if f.Product == params.Product {
+				fees = append(fees, f)
+			}
+		}
+		operatorFees = types.NewOperatorFees(params.Operator, fees)
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, operatorFees)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// nolint
+func queryOperatorFeeStatements(ctx sdk.Context, req abci.RequestQuery, keeper IKeeper) ([]byte, sdk.Error) {
+	var params types.QueryOperatorFeeStatementsParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Operator.Empty() {
+		return nil, sdk.ErrInvalidAddress("empty operator address")
+	}
+	if params.Page <= 0 || params.PerPage <= 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid params: page=%d or per_page=%d", params.Page, params.PerPage))
+	}
+
+	var statements []types.OperatorFeeStatement
+	if params.Epoch < 0 {
+		statements = keeper.GetOperatorFeeStatements(ctx, params.Operator)
+		// the latest statement comes first
+		for i, j := 0, len(statements)-1; i < j; i, j = i+1, j-1 {
+			statements[i], statements[j] = statements[j], statements[i]
+		}
+	} else if statement, found := keeper.GetOperatorFeeStatement(ctx, params.Operator, params.Epoch); found {
+		statements = append(statements, statement)
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	total := len(statements)
+	switch {
+	case total < offset:
+		statements = statements[0:0]
+	case total < offset+limit:
+		statements = statements[offset:]
+	default:
+		statements = statements[offset : offset+limit]
+	}
+
+	var response *common.ListResponse
+	if len(statements) > 0 {
+		response = common.GetListResponse(total, params.Page, params.PerPage, statements)
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+
+	bz, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
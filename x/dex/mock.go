@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 	"github.com/okex/okchain/x/common"
+	govTypes "github.com/okex/okchain/x/gov/types"
 	ordertypes "github.com/okex/okchain/x/order/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -53,6 +54,12 @@ func (k *mockSupplyKeeper) SendCoinsFromModuleToAccount(
 	return k.behave()
 }
 
+// SendCoinsFromModuleToModule mocks SendCoinsFromModuleToModule of supply.Keeper
+func (k *mockSupplyKeeper) SendCoinsFromModuleToModule(
+	ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) sdk.Error {
+	return k.behave()
+}
+
 // GetModuleAccount returns the ModuleAccount
 func (k *mockSupplyKeeper) GetModuleAccount(
 	ctx sdk.Context, moduleName string) exported.ModuleAccountI {
@@ -170,7 +177,7 @@ func newMockApp(tokenKeeper TokenKeeper, supplyKeeper SupplyKeeper, accountsInGe
 	dexKeeper := NewKeeper(AuthFeeCollector, supplyKeeper, paramsSubspace, tokenKeeper, nil, nil,
 		storeKey, keyTokenPair, mApp.Cdc)
 
-	dexKeeper.SetGovKeeper(mockGovKeeper{})
+	dexKeeper.SetGovKeeper(newMockGovKeeper())
 
 	fakeDexKeeper := newMockDexKeeper(&dexKeeper)
 
@@ -228,8 +235,26 @@ func getInitChainer(mapp *mock.App, keeper IKeeper) sdk.InitChainer {
 	}
 }
 
-type mockGovKeeper struct{}
+type mockGovKeeper struct {
+	deposits map[string]govTypes.Deposit
+}
+
+func newMockGovKeeper() *mockGovKeeper {
+	return &mockGovKeeper{deposits: make(map[string]govTypes.Deposit)}
+}
 
 // RemoveFromActiveProposalQueue mocks RemoveFromActiveProposalQueue of gov.Keeper
-func (k mockGovKeeper) RemoveFromActiveProposalQueue(ctx sdk.Context, proposalID uint64, endTime time.Time) {
+func (k *mockGovKeeper) RemoveFromActiveProposalQueue(ctx sdk.Context, proposalID uint64, endTime time.Time) {
+}
+
+// GetDeposit mocks GetDeposit of gov.Keeper
+func (k *mockGovKeeper) GetDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) (
+	govTypes.Deposit, bool) {
+	deposit, found := k.deposits[fmt.Sprintf("%d-%s", proposalID, depositorAddr)]
+	return deposit, found
+}
+
+// SetDeposit mocks SetDeposit of gov.Keeper
+func (k *mockGovKeeper) SetDeposit(ctx sdk.Context, deposit govTypes.Deposit) {
+	k.deposits[fmt.Sprintf("%d-%s", deposit.ProposalID, deposit.Depositor)] = deposit
 }
@@ -14,6 +14,12 @@ func NewProposalHandler(k *Keeper) govTypes.Handler {
 		switch c := proposal.Content.(type) {
 		case types.DelistProposal:
 			return handleDelistProposal(ctx, k, proposal)
+		case types.AuctionTypeProposal:
+			return handleAuctionTypeProposal(ctx, k, proposal)
+		case types.PriceBandProposal:
+			return handlePriceBandProposal(ctx, k, proposal)
+		case types.ListProposal:
+			return handleListProposal(ctx, k, proposal)
 		default:
 			errMsg := fmt.Sprintf("unrecognized param proposal content type: %s", c)
 			return sdk.ErrUnknownRequest(errMsg)
@@ -56,3 +62,111 @@ func handleDelistProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Pr
 		))
 	return nil
 }
+
+func handleAuctionTypeProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Proposal) (err sdk.Error) {
+	p := proposal.Content.(types.AuctionTypeProposal)
+	logger := ctx.Logger().With("module", types.ModuleName)
+	logger.Debug("execute AuctionTypeProposal begin")
+
+	tokenPairName := fmt.Sprintf("%s_%s", p.BaseAsset, p.QuoteAsset)
+	tokenPair := keeper.GetTokenPair(ctx, tokenPairName)
+	if tokenPair == nil {
+		return ErrTokenPairNotFound(fmt.Sprintf("%+v", p))
+	}
+	if keeper.IsTokenPairLocked(ctx, tokenPairName) {
+		errContent := fmt.Sprintf("unexpected state, the trading pair (%s) is locked", tokenPairName)
+		return sdk.ErrInternal(errContent)
+	}
+
+	// the order module picks up the new auction type from the next match
+	tokenPair.AuctionType = p.AuctionType
+	keeper.UpdateTokenPair(ctx, tokenPairName, tokenPair)
+
+	// remove the auctionTypeProposal from the active proposal queue
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute("token-pair-auction-type", fmt.Sprintf("%s:%s", tokenPairName, p.AuctionType)),
+		))
+	return nil
+}
+
+func handlePriceBandProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Proposal) (err sdk.Error) {
+	p := proposal.Content.(types.PriceBandProposal)
+	logger := ctx.Logger().With("module", types.ModuleName)
+	logger.Debug("execute PriceBandProposal begin")
+
+	tokenPairName := fmt.Sprintf("%s_%s", p.BaseAsset, p.QuoteAsset)
+	tokenPair := keeper.GetTokenPair(ctx, tokenPairName)
+	if tokenPair == nil {
+		return ErrTokenPairNotFound(fmt.Sprintf("%+v", p))
+	}
+	if keeper.IsTokenPairLocked(ctx, tokenPairName) {
+		errContent := fmt.Sprintf("unexpected state, the trading pair (%s) is locked", tokenPairName)
+		return sdk.ErrInternal(errContent)
+	}
+
+	// the order module checks the match price against the new price band from the next match
+	priceBand := p.PriceBand
+	tokenPair.PriceBand = &priceBand
+	keeper.UpdateTokenPair(ctx, tokenPairName, tokenPair)
+
+	// remove the priceBandProposal from the active proposal queue
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute("token-pair-price-band", fmt.Sprintf("%s:%s", tokenPairName, p.PriceBand)),
+		))
+	return nil
+}
+
+func handleListProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Proposal) (err sdk.Error) {
+	p := proposal.Content.(types.ListProposal)
+	logger := ctx.Logger().With("module", types.ModuleName)
+	logger.Debug("execute ListProposal begin")
+
+	// the tokens, token pair and operator may have changed during the voting period
+	if err := keeper.CheckListProposalContent(ctx, p); err != nil {
+		return err
+	}
+
+	// the list fee is charged out of the proposer's deposit, which is refunded after the proposal is handled
+	feeCoins, err := keeper.ChargeListFee(ctx, proposal.ProposalID, p.Proposer)
+	if err != nil {
+		return err
+	}
+
+	tokenPair := &TokenPair{
+		BaseAssetSymbol:  p.BaseAsset,
+		QuoteAssetSymbol: p.QuoteAsset,
+		InitPrice:        p.InitPrice,
+		MaxPriceDigit:    p.MaxPriceDigit,
+		MaxQuantityDigit: p.MaxQuantityDigit,
+		MinQuantity:      p.MinQuantity,
+		Owner:            p.Operator,
+		Delisting:        false,
+		Deposits:         DefaultTokenPairDeposit,
+		BlockHeight:      ctx.BlockHeight(),
+		AuctionType:      p.AuctionType,
+	}
+	if err := keeper.SaveTokenPair(ctx, tokenPair); err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to SaveTokenPair: %s", err.Error()))
+	}
+
+	// remove the listProposal from the active proposal queue
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute("token-pair-listed", tokenPair.Name()),
+			sdk.NewAttribute("operator", p.Operator.String()),
+			sdk.NewAttribute("auction-type", tokenPair.GetAuctionType()),
+			sdk.NewAttribute(sdk.AttributeKeyFee, feeCoins.String()),
+		))
+	return nil
+}
@@ -1,10 +1,12 @@
 package dex
 
 import (
+	"fmt"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/okex/okchain/x/common"
 	"github.com/okex/okchain/x/dex/types"
 	govTypes "github.com/okex/okchain/x/gov/types"
 	ordertypes "github.com/okex/okchain/x/order/types"
@@ -67,3 +69,149 @@ func TestProposal_NewProposalHandler(t *testing.T) {
 	require.Error(t, err)
 
 }
+
+func TestProposal_HandleAuctionTypeProposal(t *testing.T) {
+	fakeTokenKeeper := newMockTokenKeeper()
+	fakeSupplyKeeper := newMockSupplyKeeper()
+
+	mApp, mDexKeeper, err := newMockApp(fakeTokenKeeper, fakeSupplyKeeper, 10)
+	require.True(t, err == nil)
+
+	mApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mApp.BaseApp.NewContext(false, abci.Header{})
+
+	proposalHandler := NewProposalHandler(mDexKeeper.Keeper)
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewAuctionTypeProposal("continuous xxb_okb", "match xxb_okb continuously",
+		tokenPair.Owner, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, types.AuctionTypeContinuous)
+	proposal := govTypes.Proposal{Content: content}
+
+	// error case : fail to handle proposal because product(token pair) not exist
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+
+	saveErr := mApp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+	require.Equal(t, types.AuctionTypePeriodic, mDexKeeper.Keeper.GetTokenPair(ctx, tokenPair.Name()).GetAuctionType())
+
+	// successful case : auction type changed
+	err = proposalHandler(ctx, &proposal)
+	require.Nil(t, err)
+	require.Equal(t, types.AuctionTypeContinuous, mDexKeeper.Keeper.GetTokenPair(ctx, tokenPair.Name()).GetAuctionType())
+
+	// error case : token pair is locked
+	lock := ordertypes.ProductLock{}
+	mDexKeeper.LockTokenPair(ctx, ordertypes.TestTokenPair, &lock)
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+}
+
+func TestProposal_HandlePriceBandProposal(t *testing.T) {
+	fakeTokenKeeper := newMockTokenKeeper()
+	fakeSupplyKeeper := newMockSupplyKeeper()
+
+	mApp, mDexKeeper, err := newMockApp(fakeTokenKeeper, fakeSupplyKeeper, 10)
+	require.True(t, err == nil)
+
+	mApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mApp.BaseApp.NewContext(false, abci.Header{})
+
+	proposalHandler := NewProposalHandler(mDexKeeper.Keeper)
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewPriceBandProposal("price band of xxb_okb", "halt xxb_okb if the price moves 10%",
+		tokenPair.Owner, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, sdk.MustNewDecFromStr("0.1"))
+	proposal := govTypes.Proposal{Content: content}
+
+	// error case : fail to handle proposal because product(token pair) not exist
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+
+	saveErr := mApp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+	require.True(t, mDexKeeper.Keeper.GetTokenPair(ctx, tokenPair.Name()).GetPriceBand().IsZero())
+
+	// successful case : price band changed
+	err = proposalHandler(ctx, &proposal)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.1"), mDexKeeper.Keeper.GetTokenPair(ctx, tokenPair.Name()).GetPriceBand())
+
+	// error case : token pair is locked
+	lock := ordertypes.ProductLock{}
+	mDexKeeper.LockTokenPair(ctx, ordertypes.TestTokenPair, &lock)
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+}
+
+func TestProposal_HandleListProposal(t *testing.T) {
+	fakeTokenKeeper := newMockTokenKeeper()
+	fakeSupplyKeeper := newMockSupplyKeeper()
+
+	mApp, mDexKeeper, err := newMockApp(fakeTokenKeeper, fakeSupplyKeeper, 10)
+	require.True(t, err == nil)
+
+	mApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mApp.BaseApp.NewContext(false, abci.Header{})
+
+	proposalHandler := NewProposalHandler(mDexKeeper.Keeper)
+	govKeeper := newMockGovKeeper()
+	mDexKeeper.SetGovKeeper(govKeeper)
+	params := types.DefaultParams()
+	params.ListFee = sdk.NewDecCoin(common.NativeToken, sdk.NewInt(100))
+	mDexKeeper.SetParams(ctx, *params)
+	operator := mApp.GenesisAccounts[0].GetAddress()
+
+	content := types.NewListProposal("list xxb_okb", "list xxb_okb on the dex", operator, "xxb", common.NativeToken,
+		sdk.MustNewDecFromStr("10"), operator, 4, 2, sdk.MustNewDecFromStr("0.01"), types.AuctionTypeContinuous)
+	proposal := govTypes.Proposal{Content: content, ProposalID: 1}
+
+	// error case : fail to handle proposal because the tokens don't exist
+	fakeTokenKeeper.exist = false
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+	fakeTokenKeeper.exist = true
+
+	// error case : fail to handle proposal because the operator doesn't exist
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+	mDexKeeper.SetOperator(ctx, types.DEXOperator{Address: operator, HandlingFeeAddress: operator})
+
+	// error case : fail to handle proposal because the proposer's deposit doesn't cover the list fee
+	fakeSupplyKeeper.behaveEvil = false
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+	govKeeper.SetDeposit(ctx, govTypes.Deposit{ProposalID: 1, Depositor: operator,
+		Amount: sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(50))}})
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+	govKeeper.SetDeposit(ctx, govTypes.Deposit{ProposalID: 1, Depositor: operator,
+		Amount: sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}})
+
+	// error case : fail to handle proposal because the list fee can't be charged
+	fakeSupplyKeeper.behaveEvil = true
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+	require.Nil(t, mDexKeeper.Keeper.GetTokenPair(ctx, fmt.Sprintf("xxb_%s", common.NativeToken)))
+	fakeSupplyKeeper.behaveEvil = false
+
+	// successful case : the token pair is listed under the operator with the trading rules
+	err = proposalHandler(ctx, &proposal)
+	require.Nil(t, err)
+	tokenPair := mDexKeeper.Keeper.GetTokenPair(ctx, fmt.Sprintf("xxb_%s", common.NativeToken))
+	require.NotNil(t, tokenPair)
+	require.Equal(t, operator, tokenPair.Owner)
+	require.Equal(t, sdk.MustNewDecFromStr("10"), tokenPair.InitPrice)
+	require.Equal(t, int64(4), tokenPair.MaxPriceDigit)
+	require.Equal(t, int64(2), tokenPair.MaxQuantityDigit)
+	require.Equal(t, sdk.MustNewDecFromStr("0.01"), tokenPair.MinQuantity)
+	require.Equal(t, types.DefaultTokenPairDeposit, tokenPair.Deposits)
+	require.Equal(t, types.AuctionTypeContinuous, tokenPair.AuctionType)
+	// the list fee is charged out of the deposit, the rest is left to be refunded by gov
+	deposit, _ := govKeeper.GetDeposit(ctx, 1, operator)
+	require.Equal(t, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(50))}, deposit.Amount)
+
+	// error case : fail to handle proposal because the token pair exists
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+}
@@ -9,8 +9,14 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgWithdraw{}, "okchain/dex/MsgWithdraw", nil)
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okchain/dex/MsgTransferTradingPairOwnership", nil)
 	cdc.RegisterConcrete(DelistProposal{}, "okchain/dex/DelistProposal", nil)
+	cdc.RegisterConcrete(AuctionTypeProposal{}, "okchain/dex/AuctionTypeProposal", nil)
+	cdc.RegisterConcrete(PriceBandProposal{}, "okchain/dex/PriceBandProposal", nil)
+	cdc.RegisterConcrete(ListProposal{}, "okchain/dex/ListProposal", nil)
 	cdc.RegisterConcrete(MsgCreateOperator{}, "okchain/dex/CreateOperator", nil)
 	cdc.RegisterConcrete(MsgUpdateOperator{}, "okchain/dex/UpdateOperator", nil)
+	cdc.RegisterConcrete(MsgSetFeeTiers{}, "okchain/dex/SetFeeTiers", nil)
+	cdc.RegisterConcrete(MsgEditTokenPair{}, "okchain/dex/EditTokenPair", nil)
+	cdc.RegisterConcrete(MsgDestroyOperator{}, "okchain/dex/DestroyOperator", nil)
 }
 
 // ModuleCdc represents generic sealed codec to be used throughout this module
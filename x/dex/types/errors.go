@@ -17,6 +17,11 @@ const (
 	codeExistOperator           sdk.CodeType = 7
 	codeInvalidWebsiteLength    sdk.CodeType = 8
 	codeInvalidWebsiteURL       sdk.CodeType = 9
+	codeInvalidAuctionType      sdk.CodeType = 10
+	codeInvalidFeeTiers         sdk.CodeType = 11
+	codeInvalidPriceBand        sdk.CodeType = 12
+	codeInvalidTradingRules     sdk.CodeType = 13
+	codeOperatorOwnsTokenPairs  sdk.CodeType = 14
 )
 
 // CodeType to Message
@@ -74,6 +79,32 @@ func ErrInvalidWebsiteURL(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, codeInvalidWebsiteURL, fmt.Sprintf("invalid website URL: %s", msg))
 }
 
+// ErrInvalidAuctionType returns invalid auction type error
+func ErrInvalidAuctionType(auctionType string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidAuctionType, fmt.Sprintf("invalid auction type: %s", auctionType))
+}
+
+// ErrInvalidFeeTiers returns invalid fee tiers error
+func ErrInvalidFeeTiers(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidFeeTiers, fmt.Sprintf("invalid fee tiers: %s", msg))
+}
+
+// ErrInvalidPriceBand returns invalid price band error
+func ErrInvalidPriceBand(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidPriceBand, fmt.Sprintf("invalid price band: %s", msg))
+}
+
+// ErrInvalidTradingRules returns invalid trading rules error
+func ErrInvalidTradingRules(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidTradingRules, fmt.Sprintf("invalid trading rules: %s", msg))
+}
+
+// ErrOperatorOwnsTokenPairs returns an error when the operator to be destroyed still owns token pairs
+func ErrOperatorOwnsTokenPairs(addr sdk.AccAddress, count int) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeOperatorOwnsTokenPairs,
+		fmt.Sprintf("dex operator %s owns %d token pairs, which should be migrated to a successor", addr.String(), count))
+}
+
 // ErrTokenPairExisted returns an error when the token pair is existed during the process of listing
 // ErrTokenPairExisted returns an error when the token pair is existing during the process of listing
 func ErrTokenPairExisted(baseAsset, quoteAsset string) sdk.Error {
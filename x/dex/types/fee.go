@@ -4,6 +4,7 @@ const (
 	defaultFeeList              = "20000"
 	defaultFeeTransferOwnership = "10"
 	defaultDelistMinDeposit     = "100"
+	defaultListMinDeposit       = "100"
 
 	// DefaultMaxPriceDigitSize defines default max price digit size
 	DefaultMaxPriceDigitSize = 4
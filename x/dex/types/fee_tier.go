@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeTierVolumeDays is the number of days of the rolling traded volume which the fee tiers are based on
+const FeeTierVolumeDays = 30
+
+// FeeTier is a level of the trading fee schedule, which applies to the addresses whose rolling traded volume
+// (counted in quote asset) of the token pair reaches MinVolume
+type FeeTier struct {
+	MinVolume    sdk.Dec `json:"min_volume"`
+	MakerFeeRate sdk.Dec `json:"maker_fee_rate"`
+	TakerFeeRate sdk.Dec `json:"taker_fee_rate"`
+}
+
+// NewFeeTier creates a new FeeTier
+func NewFeeTier(minVolume, makerFeeRate, takerFeeRate sdk.Dec) FeeTier {
+	return FeeTier{
+		MinVolume:    minVolume,
+		MakerFeeRate: makerFeeRate,
+		TakerFeeRate: takerFeeRate,
+	}
+}
+
+// String returns a human readable string representation of FeeTier
+func (t FeeTier) String() string {
+	return fmt.Sprintf("%s:%s:%s", t.MinVolume, t.MakerFeeRate, t.TakerFeeRate)
+}
+
+// FeeTiers is the trading fee schedule sorted by MinVolume in ascending order
+type FeeTiers []FeeTier
+
+// String returns a human readable string representation of FeeTiers
+func (tiers FeeTiers) String() string {
+	strs := make([]string, len(tiers))
+	for i, tier := range tiers {
+		strs[i] = tier.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// Validate checks that the first tier starts from zero volume, the volumes are strictly ascending
+// and the fee rates are within [0, 1)
+func (tiers FeeTiers) Validate() sdk.Error {
+	for i, tier := range tiers {
+		if tier.MinVolume.IsNil() || tier.MakerFeeRate.IsNil() || tier.TakerFeeRate.IsNil() {
+			return ErrInvalidFeeTiers(fmt.Sprintf("fee tier %d is incomplete", i))
+		}
+		if i == 0 && !tier.MinVolume.IsZero() {
+			return ErrInvalidFeeTiers("the min volume of the first fee tier should be 0")
+		}
+		if i > 0 && !tier.MinVolume.GT(tiers[i-1].MinVolume) {
+			return ErrInvalidFeeTiers("the min volumes of the fee tiers should be in strictly ascending order")
+		}
+		if !isValidFeeRate(tier.MakerFeeRate) || !isValidFeeRate(tier.TakerFeeRate) {
+			return ErrInvalidFeeTiers(fmt.Sprintf("the fee rates of fee tier %d should be within [0, 1)", i))
+		}
+	}
+	return nil
+}
+
+// GetFeeRate returns the maker or taker fee rate of the highest tier which the volume reaches
+func (tiers FeeTiers) GetFeeRate(volume sdk.Dec, isMaker bool) sdk.Dec {
+	rate := sdk.ZeroDec()
+	for _, tier := range tiers {
+		if volume.LT(tier.MinVolume) {
+			break
+		}
+		if isMaker {
+			rate = tier.MakerFeeRate
+		} else {
+			rate = tier.TakerFeeRate
+		}
+	}
+	return rate
+}
+
+func isValidFeeRate(rate sdk.Dec) bool {
+	return !rate.IsNegative() && rate.LT(sdk.OneDec())
+}
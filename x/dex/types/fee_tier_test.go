@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeTiers(t *testing.T) {
+	feeTiers := FeeTiers{
+		NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+		NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.0005"), sdk.MustNewDecFromStr("0.001")),
+		NewFeeTier(sdk.NewDec(10000), sdk.ZeroDec(), sdk.MustNewDecFromStr("0.0008")),
+	}
+	require.Nil(t, feeTiers.Validate())
+	require.Nil(t, FeeTiers{}.Validate())
+	require.Equal(t, "0.00000000:0.00100000:0.00200000", feeTiers[0].String())
+
+	// fee rate of the highest tier reached
+	require.Equal(t, sdk.MustNewDecFromStr("0.001"), feeTiers.GetFeeRate(sdk.NewDec(999), true))
+	require.Equal(t, sdk.MustNewDecFromStr("0.002"), feeTiers.GetFeeRate(sdk.NewDec(999), false))
+	require.Equal(t, sdk.MustNewDecFromStr("0.0005"), feeTiers.GetFeeRate(sdk.NewDec(1000), true))
+	require.Equal(t, sdk.MustNewDecFromStr("0.001"), feeTiers.GetFeeRate(sdk.NewDec(1000), false))
+	require.Equal(t, sdk.ZeroDec(), feeTiers.GetFeeRate(sdk.NewDec(100000), true))
+	require.Equal(t, sdk.MustNewDecFromStr("0.0008"), feeTiers.GetFeeRate(sdk.NewDec(100000), false))
+
+	// invalid fee tiers
+	invalidFeeTiers := []FeeTiers{
+		{NewFeeTier(sdk.NewDec(1), sdk.ZeroDec(), sdk.ZeroDec())},
+		{NewFeeTier(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()), NewFeeTier(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())},
+		{NewFeeTier(sdk.ZeroDec(), sdk.NewDec(-1), sdk.ZeroDec())},
+		{NewFeeTier(sdk.ZeroDec(), sdk.ZeroDec(), sdk.OneDec())},
+		{FeeTier{MinVolume: sdk.ZeroDec()}},
+	}
+	for _, tiers := range invalidFeeTiers {
+		require.NotNil(t, tiers.Validate())
+	}
+
+	msg := NewMsgSetFeeTiers(nil, "", feeTiers)
+	require.NotNil(t, msg.ValidateBasic())
+	msg.Owner = sdk.AccAddress([]byte("owner"))
+	require.Nil(t, msg.ValidateBasic())
+	msg.FeeTiers = invalidFeeTiers[0]
+	require.NotNil(t, msg.ValidateBasic())
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -37,6 +38,10 @@ const (
 	QueryOperator = "operator"
 	// QueryOperators defines operators query route path
 	QueryOperators = "operators"
+	// QueryOperatorFees defines operator fees query route path
+	QueryOperatorFees = "operator-fees"
+	// QueryOperatorFeeStatements defines operator fee statements query route path
+	QueryOperatorFeeStatements = "operator-fee-statements"
 )
 
 var (
@@ -61,6 +66,12 @@ var (
 	WithdrawTimeKeyPrefix = []byte{0x54}
 	// UserTokenPairKeyPrefix is the store key for user token pair num
 	UserTokenPairKeyPrefix = []byte{0x06}
+	// EditedTokenPairKeyPrefix is the store key prefix for the token pairs whose trading rules are edited in the block
+	EditedTokenPairKeyPrefix = []byte{0x07}
+	// OperatorFeeKeyPrefix is the store key prefix for the fees accrued by the operators from each token pair
+	OperatorFeeKeyPrefix = []byte{0x08}
+	// OperatorFeeStatementKeyPrefix is the store key prefix for the fee statements of the operators in each epoch
+	OperatorFeeStatementKeyPrefix = []byte{0x09}
 )
 
 // GetUserTokenPairAddressPrefix returns token pair address prefix key
@@ -73,6 +84,54 @@ func GetUserTokenPairAddress(owner sdk.AccAddress, assertPair string) []byte {
 	return append(GetUserTokenPairAddressPrefix(owner), []byte(assertPair)...)
 }
 
+// GetEditedTokenPairKey returns the store key of the token pair edited in the block
+func GetEditedTokenPairKey(product string) []byte {
+	return append(EditedTokenPairKeyPrefix, []byte(product)...)
+}
+
+// GetOperatorFeeKey returns the store key of the fee accrued by the operator from the token pair
+func GetOperatorFeeKey(operator sdk.AccAddress, product string) []byte {
+	return append(GetOperatorFeePrefix(operator), []byte(product)...)
+}
+
+// GetOperatorFeePrefix returns the store key prefix of the fees accrued by the operator
+func GetOperatorFeePrefix(operator sdk.AccAddress) []byte {
+	return append(OperatorFeeKeyPrefix, operator.Bytes()...)
+}
+
+// SplitOperatorFeeKey splits the key and returns the operator and the product
+func SplitOperatorFeeKey(key []byte) (sdk.AccAddress, string) {
+	if len(key[1:]) <= sdk.AddrLen {
+		panic(fmt.Sprintf("unexpected key length (%d <= %d)", len(key[1:]), sdk.AddrLen))
+	}
+	return sdk.AccAddress(key[1 : 1+sdk.AddrLen]), string(key[1+sdk.AddrLen:])
+}
+
+// GetOperatorFeeStatementKey returns the store key of the fee accrued by the operator from the token pair in the epoch
+func GetOperatorFeeStatementKey(operator sdk.AccAddress, epoch int64, product string) []byte {
+	return append(GetOperatorFeeStatementEpochPrefix(operator, epoch), []byte(product)...)
+}
+
+// GetOperatorFeeStatementPrefix returns the store key prefix of the fee statements of the operator
+func GetOperatorFeeStatementPrefix(operator sdk.AccAddress) []byte {
+	return append(OperatorFeeStatementKeyPrefix, operator.Bytes()...)
+}
+
+// GetOperatorFeeStatementEpochPrefix returns the store key prefix of the fee statement of the operator in the epoch
+func GetOperatorFeeStatementEpochPrefix(operator sdk.AccAddress, epoch int64) []byte {
+	return append(GetOperatorFeeStatementPrefix(operator), sdk.Uint64ToBigEndian(uint64(epoch))...)
+}
+
+// SplitOperatorFeeStatementKey splits the key and returns the operator, the epoch and the product
+func SplitOperatorFeeStatementKey(key []byte) (sdk.AccAddress, int64, string) {
+	if len(key[1:]) <= sdk.AddrLen+8 {
+		panic(fmt.Sprintf("unexpected key length (%d <= %d)", len(key[1:]), sdk.AddrLen+8))
+	}
+	operator := sdk.AccAddress(key[1 : 1+sdk.AddrLen])
+	epoch := int64(binary.BigEndian.Uint64(key[1+sdk.AddrLen : 1+sdk.AddrLen+8]))
+	return operator, epoch, string(key[1+sdk.AddrLen+8:])
+}
+
 // GetTokenPairAddress returns store key of token pair
 func GetTokenPairAddress(key string) []byte {
 	return append(TokenPairKey, []byte(key)...)
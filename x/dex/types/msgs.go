@@ -15,6 +15,9 @@ const (
 	typeMsgTransferOwnership = "transferOwnership"
 	typeMsgUpdateOperator    = "updateOperator"
 	typeMsgCreateOperator    = "createOperator"
+	typeMsgSetFeeTiers       = "setFeeTiers"
+	typeMsgEditTokenPair     = "editTokenPair"
+	typeMsgDestroyOperator   = "destroyOperator"
 )
 
 // MsgList - high level transaction of the dex module
@@ -23,6 +26,8 @@ type MsgList struct {
 	ListAsset  string         `json:"list_asset"`  //  Symbol of asset listed on Dex.
 	QuoteAsset string         `json:"quote_asset"` //  Symbol of asset quoted by asset listed on Dex.
 	InitPrice  sdk.Dec        `json:"init_price"`
+	// AuctionType is the match engine of the token pair, the default one if empty
+	AuctionType string `json:"auction_type,omitempty"`
 }
 
 // NewMsgList creates a new MsgList
@@ -54,6 +59,10 @@ func (msg MsgList) ValidateBasic() sdk.Error {
 	if msg.Owner.Empty() {
 		return sdk.ErrInvalidAddress("missing owner address")
 	}
+
+	if !IsValidAuctionType(msg.AuctionType) {
+		return ErrInvalidAuctionType(msg.AuctionType)
+	}
 	return nil
 }
 
@@ -311,6 +320,160 @@ func (msg MsgUpdateOperator) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
 }
 
+// MsgDestroyOperator deregisters a DEXOperator and refunds its deposits after the withdraw period.
+// The token pairs owned by the operator are migrated to the successor operator, who should accept
+// the migration by signing the msg, like MsgTransferOwnership. The successor can be empty if no token pair is owned
+type MsgDestroyOperator struct {
+	Owner              sdk.AccAddress    `json:"owner"`
+	Successor          sdk.AccAddress    `json:"successor"`
+	SuccessorSignature auth.StdSignature `json:"successor_signature"`
+}
+
+// NewMsgDestroyOperator creates a new MsgDestroyOperator
+func NewMsgDestroyOperator(owner, successor sdk.AccAddress) MsgDestroyOperator {
+	return MsgDestroyOperator{
+		Owner:              owner,
+		Successor:          successor,
+		SuccessorSignature: auth.StdSignature{},
+	}
+}
+
+// Route Implements Msg
+func (msg MsgDestroyOperator) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgDestroyOperator) Type() string { return typeMsgDestroyOperator }
+
+// ValidateBasic Implements Msg
+func (msg MsgDestroyOperator) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress("missing owner address")
+	}
+
+	if msg.Successor.Empty() {
+		return nil
+	}
+
+	if msg.Successor.Equals(msg.Owner) {
+		return sdk.ErrInvalidAddress("successor should not be the owner")
+	}
+
+	if !msg.checkMultiSign() {
+		return sdk.ErrUnauthorized("invalid multi signature")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgDestroyOperator) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg
+func (msg MsgDestroyOperator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func (msg MsgDestroyOperator) checkMultiSign() bool {
+	// check pubkey
+	if msg.SuccessorSignature.PubKey == nil {
+		return false
+	}
+
+	if !sdk.AccAddress(msg.SuccessorSignature.PubKey.Address()).Equals(msg.Successor) {
+		return false
+	}
+
+	// check multisign
+	successorSignature := msg.SuccessorSignature
+	msg.SuccessorSignature = auth.StdSignature{}
+	return successorSignature.VerifyBytes(msg.GetSignBytes(), successorSignature.Signature)
+}
+
+// MsgSetFeeTiers sets the trading fee schedule of a token pair owned by the operator,
+// or the operator's default one if the product is empty.
+// Empty FeeTiers resets the fee schedule to the fallback one
+type MsgSetFeeTiers struct {
+	Owner    sdk.AccAddress `json:"owner"`
+	Product  string         `json:"product"`
+	FeeTiers FeeTiers       `json:"fee_tiers"`
+}
+
+// NewMsgSetFeeTiers creates a new MsgSetFeeTiers
+func NewMsgSetFeeTiers(owner sdk.AccAddress, product string, feeTiers FeeTiers) MsgSetFeeTiers {
+	return MsgSetFeeTiers{owner, strings.TrimSpace(product), feeTiers}
+}
+
+// Route Implements Msg
+func (msg MsgSetFeeTiers) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgSetFeeTiers) Type() string { return typeMsgSetFeeTiers }
+
+// ValidateBasic Implements Msg
+func (msg MsgSetFeeTiers) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress("missing owner address")
+	}
+	return msg.FeeTiers.Validate()
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgSetFeeTiers) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg
+func (msg MsgSetFeeTiers) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgEditTokenPair changes the trading rules of a token pair owned by the operator.
+// The open orders which don't fit the new rules are cancelled in the EndBlock of the order module
+type MsgEditTokenPair struct {
+	Owner            sdk.AccAddress `json:"owner"`
+	Product          string         `json:"product"`
+	MaxPriceDigit    int64          `json:"max_price_digit"`
+	MaxQuantityDigit int64          `json:"max_size_digit"`
+	MinQuantity      sdk.Dec        `json:"min_trade_size"`
+}
+
+// NewMsgEditTokenPair creates a new MsgEditTokenPair
+func NewMsgEditTokenPair(owner sdk.AccAddress, product string, maxPriceDigit, maxQuantityDigit int64,
+	minQuantity sdk.Dec) MsgEditTokenPair {
+	return MsgEditTokenPair{owner, strings.TrimSpace(product), maxPriceDigit, maxQuantityDigit, minQuantity}
+}
+
+// Route Implements Msg
+func (msg MsgEditTokenPair) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgEditTokenPair) Type() string { return typeMsgEditTokenPair }
+
+// ValidateBasic Implements Msg
+func (msg MsgEditTokenPair) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress("missing owner address")
+	}
+	if len(msg.Product) == 0 {
+		return ErrInvalidProduct("product is empty")
+	}
+	return ValidateTradingRules(msg.MaxPriceDigit, msg.MaxQuantityDigit, msg.MinQuantity)
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgEditTokenPair) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg
+func (msg MsgEditTokenPair) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
 func checkWebsite(website string) sdk.Error {
 	if len(website) == 0 {
 		return nil
@@ -22,6 +22,7 @@ func TestMsg(t *testing.T) {
 	msgDeposit := NewMsgDeposit(product, sdk.NewDecCoin(common.NativeToken, sdk.NewInt(100)), addr)
 	msgWithdraw := NewMsgWithdraw(product, sdk.NewDecCoin(common.NativeToken, sdk.NewInt(100)), addr)
 	msgTransferOwnership := NewMsgTransferOwnership(addr, addr, product)
+	msgEditTokenPair := NewMsgEditTokenPair(addr, product, 2, 4, sdk.MustNewDecFromStr("0.001"))
 
 	// test msg.Route()、msg.Type()、msg.GetSigners()、GetSignBytes()
 	type Want struct {
@@ -43,6 +44,8 @@ func TestMsg(t *testing.T) {
 			Want{"dex", typeMsgWithdraw, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msgWithdraw)), []sdk.AccAddress{addr}}},
 		{"msgTransferOwnership", msgTransferOwnership,
 			Want{"dex", typeMsgTransferOwnership, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msgTransferOwnership)), []sdk.AccAddress{addr}}},
+		{"msgEditTokenPair", msgEditTokenPair,
+			Want{"dex", typeMsgEditTokenPair, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msgEditTokenPair)), []sdk.AccAddress{addr}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,6 +74,15 @@ func TestMsg(t *testing.T) {
 	toPriKey := secp256k1.GenPrivKey()
 	toPubKey := toPriKey.PubKey()
 	toAddr := sdk.AccAddress(toPubKey.Address())
+
+	msgDestroyOperator := NewMsgDestroyOperator(fromAddr, toAddr)
+	successorSignature, err := toPriKey.Sign(msgDestroyOperator.GetSignBytes())
+	require.Nil(t, err)
+	msgDestroyOperator.SuccessorSignature = auth.StdSignature{PubKey: toPubKey, Signature: successorSignature}
+	msgDestroyOperatorWrongSign := NewMsgDestroyOperator(fromAddr, toAddr)
+	msgDestroyOperatorWrongSign.SuccessorSignature = auth.StdSignature{PubKey: toPubKey, Signature: successorSignature}
+	msgDestroyOperatorWrongSign.Owner = addr
+
 	testBasics := []struct {
 		name   string
 		msg    sdk.Msg
@@ -80,9 +92,9 @@ func TestMsg(t *testing.T) {
 		{"msgDeposit", msgDeposit, true},
 		{"msgWithdraw", msgWithdraw, true},
 
-		{"deposit-invalid-amount", NewMsgDeposit(product, sdk.DecCoin{"", sdk.NewDec(1)}, addr), false},
+		{"deposit-invalid-amount", NewMsgDeposit(product, sdk.DecCoin{Denom: "", Amount: sdk.NewDec(1)}, addr), false},
 		{"deposit-no-depositor", NewMsgDeposit(product, sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1)), nil), false},
-		{"withdraw-invalid-amount", NewMsgWithdraw(product, sdk.DecCoin{"", sdk.NewDec(1)}, addr), false},
+		{"withdraw-invalid-amount", NewMsgWithdraw(product, sdk.DecCoin{Denom: "", Amount: sdk.NewDec(1)}, addr), false},
 		{"withdraw-no-depositor", NewMsgWithdraw(product, sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1)), nil), false},
 
 		{"transfer-no-sign", NewMsgTransferOwnership(fromAddr, toAddr, product), false},
@@ -91,6 +103,22 @@ func TestMsg(t *testing.T) {
 		{"transfer-no-product", NewMsgTransferOwnership(fromAddr, toAddr, ""), false},
 		{"transfer-worng-pk", MsgTransferOwnership{fromAddr, fromAddr, product, auth.StdSignature{PubKey: fromPubKey}}, false},
 		{"transfer-wright-pk", MsgTransferOwnership{fromAddr, fromAddr, product, auth.StdSignature{PubKey: toPubKey}}, false},
+
+		{"msgDestroyOperator", msgDestroyOperator, true},
+		{"destroy-no-successor", NewMsgDestroyOperator(fromAddr, nil), true},
+		{"destroy-no-owner", NewMsgDestroyOperator(nil, nil), false},
+		{"destroy-no-sign", NewMsgDestroyOperator(fromAddr, toAddr), false},
+		{"destroy-self-successor", NewMsgDestroyOperator(fromAddr, fromAddr), false},
+		{"destroy-wrong-pk", MsgDestroyOperator{fromAddr, toAddr, auth.StdSignature{PubKey: fromPubKey}}, false},
+		{"destroy-wrong-sign", msgDestroyOperatorWrongSign, false},
+
+		{"msgEditTokenPair", msgEditTokenPair, true},
+		{"edit-no-owner", NewMsgEditTokenPair(nil, product, 2, 4, sdk.ZeroDec()), false},
+		{"edit-no-product", NewMsgEditTokenPair(addr, "", 2, 4, sdk.ZeroDec()), false},
+		{"edit-invalid-price-digit", NewMsgEditTokenPair(addr, product, 9, 4, sdk.ZeroDec()), false},
+		{"edit-invalid-quantity-digit", NewMsgEditTokenPair(addr, product, 2, -1, sdk.ZeroDec()), false},
+		{"edit-negative-min-quantity", NewMsgEditTokenPair(addr, product, 2, 4, sdk.NewDec(-1)), false},
+		{"edit-min-quantity-over-accuracy", NewMsgEditTokenPair(addr, product, 2, 2, sdk.MustNewDecFromStr("0.001")), false},
 	}
 	for _, tb := range testBasics {
 		t.Run(tb.name, func(t *testing.T) {
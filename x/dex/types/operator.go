@@ -14,6 +14,10 @@ type DEXOperator struct {
 	Website            string         `json:"website"`
 	InitHeight         int64          `json:"init_height"`
 	TxHash             string         `json:"tx_hash"`
+	// FeeTiers is the default trading fee schedule of the token pairs owned by the operator
+	FeeTiers FeeTiers `json:"fee_tiers,omitempty"`
+	// Deposits is the register operator fee paid by the operator, which is refunded when the operator is destroyed
+	Deposits sdk.DecCoin `json:"deposits"`
 }
 
 // nolint
@@ -23,9 +27,11 @@ func (o DEXOperator) String() string {
   Handling Fee Address: %s
   Website:              %s
   Init Height:          %d
-  TxHash:               %s`,
+  TxHash:               %s
+  Fee Tiers:            %s
+  Deposits:             %s`,
 		o.Address, o.HandlingFeeAddress, o.Website,
-		o.InitHeight, o.TxHash,
+		o.InitHeight, o.TxHash, o.FeeTiers, o.Deposits,
 	)
 }
 
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OperatorFeeEpochBlocks is the number of blocks covered by an operator fee statement, about one day
+const OperatorFeeEpochBlocks int64 = 28800
+
+// GetOperatorFeeEpoch returns the epoch of the operator fee statements which the block height belongs to
+func GetOperatorFeeEpoch(height int64) int64 {
+	return height / OperatorFeeEpochBlocks
+}
+
+// ProductFee is the fee revenue from a token pair
+type ProductFee struct {
+	Product string       `json:"product"`
+	Fee     sdk.DecCoins `json:"fee"`
+}
+
+// nolint
+func (f ProductFee) String() string {
+	return fmt.Sprintf("%s: %s", f.Product, f.Fee)
+}
+
+// ProductFees is the fee revenue from a list of token pairs
+type ProductFees []ProductFee
+
+// Total returns the sum of the fees
+func (fs ProductFees) Total() sdk.DecCoins {
+	total := sdk.DecCoins{}
+	for _, f := range fs {
+		total = total.Add(f.Fee)
+	}
+	return total
+}
+
+// nolint
+func (fs ProductFees) String() string {
+	out := make([]string, 0, len(fs))
+	for _, f := range fs {
+		out = append(out, f.String())
+	}
+	return strings.Join(out, ", ")
+}
+
+// OperatorFees is the fee revenue accrued by a dex operator from its token pairs
+type OperatorFees struct {
+	Operator sdk.AccAddress `json:"operator"`
+	Fees     ProductFees    `json:"fees"`
+	Total    sdk.DecCoins   `json:"total"`
+}
+
+// NewOperatorFees creates a new OperatorFees
+func NewOperatorFees(operator sdk.AccAddress, fees ProductFees) OperatorFees {
+	return OperatorFees{
+		Operator: operator,
+		Fees:     fees,
+		Total:    fees.Total(),
+	}
+}
+
+// nolint
+func (o OperatorFees) String() string {
+	return fmt.Sprintf(`OperatorFees :
+  Operator: %s
+  Fees:     %s
+  Total:    %s`,
+		o.Operator, o.Fees, o.Total,
+	)
+}
+
+// OperatorFeeStatement is the fee revenue accrued by a dex operator from its token pairs in an epoch,
+// which covers the blocks from StartHeight to EndHeight
+type OperatorFeeStatement struct {
+	Operator    sdk.AccAddress `json:"operator"`
+	Epoch       int64          `json:"epoch"`
+	StartHeight int64          `json:"start_height"`
+	EndHeight   int64          `json:"end_height"`
+	Fees        ProductFees    `json:"fees"`
+	Total       sdk.DecCoins   `json:"total"`
+}
+
+// NewOperatorFeeStatement creates a new OperatorFeeStatement
+func NewOperatorFeeStatement(operator sdk.AccAddress, epoch int64, fees ProductFees) OperatorFeeStatement {
+	return OperatorFeeStatement{
+		Operator:    operator,
+		Epoch:       epoch,
+		StartHeight: epoch * OperatorFeeEpochBlocks,
+		EndHeight:   (epoch+1)*OperatorFeeEpochBlocks - 1,
+		Fees:        fees,
+		Total:       fees.Total(),
+	}
+}
+
+// nolint
+func (s OperatorFeeStatement) String() string {
+	return fmt.Sprintf(`OperatorFeeStatement :
+  Operator:     %s
+  Epoch:        %d
+  Start Height: %d
+  End Height:   %d
+  Fees:         %s
+  Total:        %s`,
+		s.Operator, s.Epoch, s.StartHeight, s.EndHeight, s.Fees, s.Total,
+	)
+}
+
+// ValidateProductFees validates the fees of the token pairs of an operator
+func ValidateProductFees(fees ProductFees) error {
+	products := make(map[string]bool, len(fees))
+	for _, f := range fees {
+		if len(f.Product) == 0 {
+			return fmt.Errorf("empty product")
+		}
+		if products[f.Product] {
+			return fmt.Errorf("duplicate product %s", f.Product)
+		}
+		products[f.Product] = true
+		if !f.Fee.IsValid() {
+			return fmt.Errorf("invalid fee %s of product %s", f.Fee, f.Product)
+		}
+	}
+	return nil
+}
@@ -10,6 +10,27 @@ import (
 // DefaultTokenPairDeposit defines default deposit of token pair
 var DefaultTokenPairDeposit = sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(0))
 
+// auction types of the match engine which a token pair runs on
+const (
+	// AuctionTypePeriodic matches all the orders at one uniform price in EndBlock
+	AuctionTypePeriodic = "periodic"
+	// AuctionTypeContinuous matches every new order against the resting orders by price-time priority
+	AuctionTypeContinuous = "continuous"
+
+	// DefaultAuctionType is the auction type of the token pairs which didn't choose one
+	DefaultAuctionType = AuctionTypePeriodic
+)
+
+// IsValidAuctionType returns true if the auction type is supported, empty means the default one
+func IsValidAuctionType(auctionType string) bool {
+	switch auctionType {
+	case "", AuctionTypePeriodic, AuctionTypeContinuous:
+		return true
+	default:
+		return false
+	}
+}
+
 // TokenPair represents token pair object
 type TokenPair struct {
 	BaseAssetSymbol  string         `json:"base_asset_symbol"`
@@ -23,6 +44,12 @@ type TokenPair struct {
 	Owner            sdk.AccAddress `json:"owner"`
 	Deposits         sdk.DecCoin    `json:"deposits"`
 	BlockHeight      int64          `json:"block_height"`
+	AuctionType      string         `json:"auction_type"`
+	// FeeTiers is the trading fee schedule of the token pair, the operator's one is used if empty
+	FeeTiers FeeTiers `json:"fee_tiers,omitempty"`
+	// PriceBand is the max deviation rate of the periodic auction match price from the last price,
+	// the token pair is halted if the match price breaks it. Zero means no price band
+	PriceBand *sdk.Dec `json:"price_band,omitempty"`
 }
 
 // Name returns name of token pair
@@ -30,6 +57,46 @@ func (tp *TokenPair) Name() string {
 	return fmt.Sprintf("%s_%s", tp.BaseAssetSymbol, tp.QuoteAssetSymbol)
 }
 
+// GetAuctionType returns the auction type of token pair, DefaultAuctionType if not set
+func (tp *TokenPair) GetAuctionType() string {
+	if tp.AuctionType == "" {
+		return DefaultAuctionType
+	}
+	return tp.AuctionType
+}
+
+// GetPriceBand returns the price band of the token pair, zero if not set
+func (tp *TokenPair) GetPriceBand() sdk.Dec {
+	if tp.PriceBand == nil || tp.PriceBand.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return *tp.PriceBand
+}
+
+// IsValidPriceBand returns true if the price band is within [0, 1), zero means no price band
+func IsValidPriceBand(priceBand sdk.Dec) bool {
+	return !priceBand.IsNil() && !priceBand.IsNegative() && priceBand.LT(sdk.OneDec())
+}
+
+// ValidateTradingRules checks that the price and quantity digits are within [0, sdk.Precision],
+// and the min quantity is non-negative and fits the quantity digit
+func ValidateTradingRules(maxPriceDigit, maxQuantityDigit int64, minQuantity sdk.Dec) sdk.Error {
+	if maxPriceDigit < 0 || maxPriceDigit > sdk.Precision {
+		return ErrInvalidTradingRules(fmt.Sprintf("max price digit %d is out of [0, %d]", maxPriceDigit, sdk.Precision))
+	}
+	if maxQuantityDigit < 0 || maxQuantityDigit > sdk.Precision {
+		return ErrInvalidTradingRules(fmt.Sprintf("max quantity digit %d is out of [0, %d]", maxQuantityDigit,
+			sdk.Precision))
+	}
+	if minQuantity.IsNil() || minQuantity.IsNegative() {
+		return ErrInvalidTradingRules("min quantity should not be negative")
+	}
+	if !minQuantity.RoundDecimal(maxQuantityDigit).Equal(minQuantity) {
+		return ErrInvalidTradingRules(fmt.Sprintf("min quantity(%s) over accuracy(%d)", minQuantity, maxQuantityDigit))
+	}
+	return nil
+}
+
 // IsGT returns true if the token pair is greater than the other one
 // 1. compare deposits
 // 2. compare block height
@@ -18,6 +18,10 @@ var (
 	keyDelistMinDeposit       = []byte("DelistMinDeposit")
 	keyDelistVotingPeriod     = []byte("DelistVotingPeriod")
 	keyWithdrawPeriod         = []byte("WithdrawPeriod")
+	keyEditTokenPairEnabled   = []byte("EditTokenPairEnabled")
+	keyListMaxDepositPeriod   = []byte("ListMaxDepositPeriod")
+	keyListMinDeposit         = []byte("ListMinDeposit")
+	keyListVotingPeriod       = []byte("ListVotingPeriod")
 )
 
 // Params defines param object
@@ -34,6 +38,16 @@ type Params struct {
 	DelistVotingPeriod time.Duration `json:"delist_voting_period"`
 
 	WithdrawPeriod time.Duration `json:"withdraw_period"`
+
+	// whether the operators can edit the trading rules of their token pairs, switched by governance
+	EditTokenPairEnabled bool `json:"edit_token_pair_enabled"`
+
+	//  maximum period for okt holders to deposit on a dex list proposal
+	ListMaxDepositPeriod time.Duration `json:"list_max_deposit_period"`
+	//  minimum deposit for a dex list proposal to enter voting period
+	ListMinDeposit sdk.DecCoins `json:"list_min_deposit"`
+	//  length of the voting period for dex list proposal
+	ListVotingPeriod time.Duration `json:"list_voting_period"`
 }
 
 // ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
@@ -46,6 +60,10 @@ func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
 		{Key: keyDelistMinDeposit, Value: &p.DelistMinDeposit},
 		{Key: keyDelistVotingPeriod, Value: &p.DelistVotingPeriod},
 		{Key: keyWithdrawPeriod, Value: &p.WithdrawPeriod},
+		{Key: keyEditTokenPairEnabled, Value: &p.EditTokenPairEnabled},
+		{Key: keyListMaxDepositPeriod, Value: &p.ListMaxDepositPeriod},
+		{Key: keyListMinDeposit, Value: &p.ListMinDeposit},
+		{Key: keyListVotingPeriod, Value: &p.ListVotingPeriod},
 	}
 }
 
@@ -59,6 +77,7 @@ func DefaultParams() *Params {
 	defaultListFee := sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultFeeList))
 	defaultTransferOwnershipFee := sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultFeeTransferOwnership))
 	defaultDelistMinDeposit := sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultDelistMinDeposit))
+	defaultListMinDeposit := sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultListMinDeposit))
 	return &Params{
 		ListFee:                defaultListFee,
 		TransferOwnershipFee:   defaultTransferOwnershipFee,
@@ -67,12 +86,18 @@ func DefaultParams() *Params {
 		DelistMinDeposit:       sdk.DecCoins{defaultDelistMinDeposit},
 		DelistVotingPeriod:     time.Hour * 72,
 		WithdrawPeriod:         DefaultWithdrawPeriod,
+		EditTokenPairEnabled:   true,
+		ListMaxDepositPeriod:   time.Hour * 24,
+		ListMinDeposit:         sdk.DecCoins{defaultListMinDeposit},
+		ListVotingPeriod:       time.Hour * 72,
 	}
 }
 
 // String implements the stringer interface.
 func (p Params) String() string {
 	return fmt.Sprintf("Params: \nDexListFee:%s\nTransferOwnershipFee:%s\nRegisterOperatorFee:%s\nDelistMaxDepositPeriod:%s\n"+
-		"DelistMinDeposit:%s\nDelistVotingPeriod:%s\nWithdrawPeriod:%d\n",
-		p.ListFee, p.TransferOwnershipFee, p.RegisterOperatorFee, p.DelistMaxDepositPeriod, p.DelistMinDeposit, p.DelistVotingPeriod, p.WithdrawPeriod)
+		"DelistMinDeposit:%s\nDelistVotingPeriod:%s\nWithdrawPeriod:%d\nEditTokenPairEnabled:%t\n"+
+		"ListMaxDepositPeriod:%s\nListMinDeposit:%s\nListVotingPeriod:%s\n",
+		p.ListFee, p.TransferOwnershipFee, p.RegisterOperatorFee, p.DelistMaxDepositPeriod, p.DelistMinDeposit, p.DelistVotingPeriod, p.WithdrawPeriod,
+		p.EditTokenPairEnabled, p.ListMaxDepositPeriod, p.ListMinDeposit, p.ListVotingPeriod)
 }
@@ -5,16 +5,26 @@ import (
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	govtypes "github.com/okex/okchain/x/gov/types"
 )
 
 const (
-	proposalTypeDelist = "Delist"
+	proposalTypeDelist      = "Delist"
+	proposalTypeAuctionType = "AuctionType"
+	proposalTypePriceBand   = "PriceBand"
+	proposalTypeList        = "List"
 )
 
 func init() {
 	govtypes.RegisterProposalType(proposalTypeDelist)
 	govtypes.RegisterProposalTypeCodec(DelistProposal{}, "okchain/dex/DelistProposal")
+	govtypes.RegisterProposalType(proposalTypeAuctionType)
+	govtypes.RegisterProposalTypeCodec(AuctionTypeProposal{}, "okchain/dex/AuctionTypeProposal")
+	govtypes.RegisterProposalType(proposalTypePriceBand)
+	govtypes.RegisterProposalTypeCodec(PriceBandProposal{}, "okchain/dex/PriceBandProposal")
+	govtypes.RegisterProposalType(proposalTypeList)
+	govtypes.RegisterProposalTypeCodec(ListProposal{}, "okchain/dex/ListProposal")
 
 }
 
@@ -107,3 +117,355 @@ func (drp DelistProposal) String() string {
 		drp.BaseAsset, drp.QuoteAsset,
 	)
 }
+
+// Assert AuctionTypeProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = (*AuctionTypeProposal)(nil)
+
+// AuctionTypeProposal represents the proposal object to change the auction type of a token pair
+type AuctionTypeProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	BaseAsset   string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string         `json:"quote_asset" yaml:"quote_asset"`
+	AuctionType string         `json:"auction_type" yaml:"auction_type"`
+}
+
+// NewAuctionTypeProposal creates a new auction type proposal object
+func NewAuctionTypeProposal(title, description string, proposer sdk.AccAddress, baseAsset, quoteAsset,
+	auctionType string) AuctionTypeProposal {
+	return AuctionTypeProposal{
+		Title:       title,
+		Description: description,
+		Proposer:    proposer,
+		BaseAsset:   baseAsset,
+		QuoteAsset:  quoteAsset,
+		AuctionType: auctionType,
+	}
+}
+
+// GetTitle returns title of auction type proposal object
+func (atp AuctionTypeProposal) GetTitle() string {
+	return atp.Title
+}
+
+// GetDescription returns description of auction type proposal object
+func (atp AuctionTypeProposal) GetDescription() string {
+	return atp.Description
+}
+
+// ProposalRoute returns route key of auction type proposal object
+func (AuctionTypeProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of auction type proposal object
+func (AuctionTypeProposal) ProposalType() string {
+	return proposalTypeAuctionType
+}
+
+// ValidateBasic validates auction type proposal
+func (atp AuctionTypeProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(atp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit auction type proposal because title is blank")
+	}
+	if len(atp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit auction type proposal because title is longer than max length of %d", govtypes.MaxTitleLength))
+	}
+
+	if len(atp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit auction type proposal because description is blank")
+	}
+
+	if len(atp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit auction type proposal because description is longer than max length of %d", govtypes.MaxDescriptionLength))
+	}
+
+	if atp.ProposalType() != proposalTypeAuctionType {
+		return govtypes.ErrInvalidProposalType(DefaultCodespace, atp.ProposalType())
+	}
+
+	if atp.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(atp.Proposer.String())
+	}
+
+	if atp.BaseAsset == atp.QuoteAsset {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("failed to submit auction type proposal because baseasset is same as quoteasset"))
+	}
+
+	if len(atp.AuctionType) == 0 || !IsValidAuctionType(atp.AuctionType) {
+		return ErrInvalidAuctionType(atp.AuctionType)
+	}
+
+	return nil
+}
+
+// String converts auction type proposal object to string
+func (atp AuctionTypeProposal) String() string {
+	return fmt.Sprintf(`AuctionTypeProposal:
+ Title:               %s
+ Description:         %s
+ Type:                %s
+ Proposer:            %s
+ BaseAsset            %s
+ QuoteAsset           %s
+ AuctionType          %s
+`, atp.Title, atp.Description,
+		atp.ProposalType(), atp.Proposer,
+		atp.BaseAsset, atp.QuoteAsset, atp.AuctionType,
+	)
+}
+
+// Assert PriceBandProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = (*PriceBandProposal)(nil)
+
+// PriceBandProposal represents the proposal object to change the price band of a token pair
+type PriceBandProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	BaseAsset   string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string         `json:"quote_asset" yaml:"quote_asset"`
+	PriceBand   sdk.Dec        `json:"price_band" yaml:"price_band"`
+}
+
+// NewPriceBandProposal creates a new price band proposal object
+func NewPriceBandProposal(title, description string, proposer sdk.AccAddress, baseAsset, quoteAsset string,
+	priceBand sdk.Dec) PriceBandProposal {
+	return PriceBandProposal{
+		Title:       title,
+		Description: description,
+		Proposer:    proposer,
+		BaseAsset:   baseAsset,
+		QuoteAsset:  quoteAsset,
+		PriceBand:   priceBand,
+	}
+}
+
+// GetTitle returns title of price band proposal object
+func (pbp PriceBandProposal) GetTitle() string {
+	return pbp.Title
+}
+
+// GetDescription returns description of price band proposal object
+func (pbp PriceBandProposal) GetDescription() string {
+	return pbp.Description
+}
+
+// ProposalRoute returns route key of price band proposal object
+func (PriceBandProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of price band proposal object
+func (PriceBandProposal) ProposalType() string {
+	return proposalTypePriceBand
+}
+
+// ValidateBasic validates price band proposal
+func (pbp PriceBandProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(pbp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit price band proposal because title is blank")
+	}
+	if len(pbp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit price band proposal because title is longer than max length of %d", govtypes.MaxTitleLength))
+	}
+
+	if len(pbp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit price band proposal because description is blank")
+	}
+
+	if len(pbp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit price band proposal because description is longer than max length of %d", govtypes.MaxDescriptionLength))
+	}
+
+	if pbp.ProposalType() != proposalTypePriceBand {
+		return govtypes.ErrInvalidProposalType(DefaultCodespace, pbp.ProposalType())
+	}
+
+	if pbp.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(pbp.Proposer.String())
+	}
+
+	if pbp.BaseAsset == pbp.QuoteAsset {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("failed to submit price band proposal because baseasset is same as quoteasset"))
+	}
+
+	if !IsValidPriceBand(pbp.PriceBand) {
+		return ErrInvalidPriceBand(fmt.Sprintf("%s, it should be within [0, 1)", pbp.PriceBand))
+	}
+
+	return nil
+}
+
+// String converts price band proposal object to string
+func (pbp PriceBandProposal) String() string {
+	return fmt.Sprintf(`PriceBandProposal:
+ Title:               %s
+ Description:         %s
+ Type:                %s
+ Proposer:            %s
+ BaseAsset            %s
+ QuoteAsset           %s
+ PriceBand            %s
+`, pbp.Title, pbp.Description,
+		pbp.ProposalType(), pbp.Proposer,
+		pbp.BaseAsset, pbp.QuoteAsset, pbp.PriceBand,
+	)
+}
+
+// Assert ListProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = (*ListProposal)(nil)
+
+// ListProposal represents the proposal object to list a token pair under a dex operator with initial trading rules.
+// The operator consents to own the token pair by submitting it, or by signing it for another proposer. The list fee
+// is charged out of the proposer's deposit when the proposal passes
+type ListProposal struct {
+	Title            string         `json:"title" yaml:"title"`
+	Description      string         `json:"description" yaml:"description"`
+	Proposer         sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	BaseAsset        string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset       string         `json:"quote_asset" yaml:"quote_asset"`
+	InitPrice        sdk.Dec        `json:"init_price" yaml:"init_price"`
+	Operator         sdk.AccAddress `json:"operator" yaml:"operator"`
+	MaxPriceDigit    int64          `json:"max_price_digit" yaml:"max_price_digit"`
+	MaxQuantityDigit int64          `json:"max_size_digit" yaml:"max_size_digit"`
+	MinQuantity      sdk.Dec        `json:"min_trade_size" yaml:"min_trade_size"`
+	// AuctionType is the match engine of the token pair, the default one if empty
+	AuctionType string `json:"auction_type,omitempty" yaml:"auction_type"`
+	// OperatorSignature is the operator's signature on the proposal, required if the operator isn't the proposer
+	OperatorSignature auth.StdSignature `json:"operator_signature" yaml:"operator_signature"`
+}
+
+// NewListProposal creates a new list proposal object
+func NewListProposal(title, description string, proposer sdk.AccAddress, baseAsset, quoteAsset string,
+	initPrice sdk.Dec, operator sdk.AccAddress, maxPriceDigit, maxQuantityDigit int64,
+	minQuantity sdk.Dec, auctionType string) ListProposal {
+	return ListProposal{
+		Title:             title,
+		Description:       description,
+		Proposer:          proposer,
+		BaseAsset:         baseAsset,
+		QuoteAsset:        quoteAsset,
+		InitPrice:         initPrice,
+		Operator:          operator,
+		MaxPriceDigit:     maxPriceDigit,
+		MaxQuantityDigit:  maxQuantityDigit,
+		MinQuantity:       minQuantity,
+		AuctionType:       auctionType,
+		OperatorSignature: auth.StdSignature{},
+	}
+}
+
+// GetTitle returns title of list proposal object
+func (lp ListProposal) GetTitle() string {
+	return lp.Title
+}
+
+// GetDescription returns description of list proposal object
+func (lp ListProposal) GetDescription() string {
+	return lp.Description
+}
+
+// ProposalRoute returns route key of list proposal object
+func (ListProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of list proposal object
+func (ListProposal) ProposalType() string {
+	return proposalTypeList
+}
+
+// ValidateBasic validates list proposal
+func (lp ListProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(lp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit list proposal because title is blank")
+	}
+	if len(lp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit list proposal because title is longer than max length of %d", govtypes.MaxTitleLength))
+	}
+
+	if len(lp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit list proposal because description is blank")
+	}
+
+	if len(lp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit list proposal because description is longer than max length of %d", govtypes.MaxDescriptionLength))
+	}
+
+	if lp.ProposalType() != proposalTypeList {
+		return govtypes.ErrInvalidProposalType(DefaultCodespace, lp.ProposalType())
+	}
+
+	if lp.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(lp.Proposer.String())
+	}
+
+	if lp.Operator.Empty() {
+		return sdk.ErrInvalidAddress("failed to submit list proposal because operator is empty")
+	}
+
+	// the operator consents to own the token pair by submitting the proposal or signing it
+	if !lp.Operator.Equals(lp.Proposer) && !lp.checkOperatorSign() {
+		return sdk.ErrUnauthorized("failed to submit list proposal because the operator's signature is invalid")
+	}
+
+	if len(lp.BaseAsset) == 0 || len(lp.QuoteAsset) == 0 || lp.BaseAsset == lp.QuoteAsset {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("failed to submit list proposal because baseasset is same as quoteasset or empty"))
+	}
+
+	if lp.InitPrice.IsNil() || !lp.InitPrice.IsPositive() {
+		return sdk.ErrUnknownRequest("failed to submit list proposal because init price is not positive")
+	}
+
+	if !IsValidAuctionType(lp.AuctionType) {
+		return ErrInvalidAuctionType(lp.AuctionType)
+	}
+
+	return ValidateTradingRules(lp.MaxPriceDigit, lp.MaxQuantityDigit, lp.MinQuantity)
+}
+
+// GetSignBytes returns the bytes of the proposal for the operator to sign
+func (lp ListProposal) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(lp)
+	return sdk.MustSortJSON(bz)
+}
+
+func (lp ListProposal) checkOperatorSign() bool {
+	// check pubkey
+	if lp.OperatorSignature.PubKey == nil {
+		return false
+	}
+
+	if !sdk.AccAddress(lp.OperatorSignature.PubKey.Address()).Equals(lp.Operator) {
+		return false
+	}
+
+	// check the operator's signature
+	operatorSignature := lp.OperatorSignature
+	lp.OperatorSignature = auth.StdSignature{}
+	return operatorSignature.VerifyBytes(lp.GetSignBytes(), operatorSignature.Signature)
+}
+
+// String converts list proposal object to string
+func (lp ListProposal) String() string {
+	return fmt.Sprintf(`ListProposal:
+ Title:               %s
+ Description:         %s
+ Type:                %s
+ Proposer:            %s
+ ListAsset            %s
+ QuoteAsset           %s
+ InitPrice            %s
+ Operator             %s
+ MaxPriceDigit        %d
+ MaxSizeDigit         %d
+ MinTradeSize         %s
+ AuctionType          %s
+`, lp.Title, lp.Description,
+		lp.ProposalType(), lp.Proposer,
+		lp.BaseAsset, lp.QuoteAsset, lp.InitPrice, lp.Operator,
+		lp.MaxPriceDigit, lp.MaxQuantityDigit, lp.MinQuantity, lp.AuctionType,
+	)
+}
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 func TestDelistProposal_ValidateBasic(t *testing.T) {
@@ -47,6 +49,88 @@ func TestDelistProposal_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestAuctionTypeProposal_ValidateBasic(t *testing.T) {
+	addr, err := sdk.AccAddressFromBech32(TestTokenPairOwner)
+	require.Nil(t, err)
+
+	proposal := NewAuctionTypeProposal("proposal", "right auction type proposal", addr, "eth", "btc",
+		AuctionTypeContinuous)
+	require.Equal(t, "proposal", proposal.GetTitle())
+	require.Equal(t, "right auction type proposal", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, proposalTypeAuctionType, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+
+	tests := []struct {
+		name   string
+		atp    AuctionTypeProposal
+		result bool
+	}{
+		{"auction-type-proposal", proposal, true},
+
+		{"no-title", AuctionTypeProposal{"", "proposal", addr, "eth", "btc", AuctionTypeContinuous}, false},
+		{"no-description", AuctionTypeProposal{"proposal", "", addr, "eth", "btc", AuctionTypeContinuous}, false},
+		{"no-proposer", AuctionTypeProposal{"proposal", "proposal", nil, "eth", "btc", AuctionTypeContinuous}, false},
+		{"no-product", AuctionTypeProposal{"proposal", "proposal", addr, "btc", "btc", AuctionTypeContinuous}, false},
+		{"no-auction-type", AuctionTypeProposal{"proposal", "proposal", addr, "eth", "btc", ""}, false},
+		{"invalid-auction-type", AuctionTypeProposal{"proposal", "proposal", addr, "eth", "btc", "dutch"}, false},
+
+		{"long-title", AuctionTypeProposal{getLongString(15),
+			"proposal", addr, "eth", "btc", AuctionTypeContinuous}, false},
+		{"long-description", AuctionTypeProposal{"proposal",
+			getLongString(501), addr, "eth", "btc", AuctionTypeContinuous}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result {
+				require.Nil(t, tt.atp.ValidateBasic(), "test: %v", tt.name)
+			} else {
+				require.NotNil(t, tt.atp.ValidateBasic(), "test: %v", tt.name)
+			}
+		})
+	}
+}
+
+func TestPriceBandProposal_ValidateBasic(t *testing.T) {
+	addr, err := sdk.AccAddressFromBech32(TestTokenPairOwner)
+	require.Nil(t, err)
+
+	band := sdk.MustNewDecFromStr("0.1")
+	proposal := NewPriceBandProposal("proposal", "right price band proposal", addr, "eth", "btc", band)
+	require.Equal(t, "proposal", proposal.GetTitle())
+	require.Equal(t, "right price band proposal", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, proposalTypePriceBand, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+
+	tests := []struct {
+		name   string
+		pbp    PriceBandProposal
+		result bool
+	}{
+		{"price-band-proposal", proposal, true},
+		{"zero-price-band", PriceBandProposal{"proposal", "proposal", addr, "eth", "btc", sdk.ZeroDec()}, true},
+
+		{"no-title", PriceBandProposal{"", "proposal", addr, "eth", "btc", band}, false},
+		{"no-description", PriceBandProposal{"proposal", "", addr, "eth", "btc", band}, false},
+		{"no-proposer", PriceBandProposal{"proposal", "proposal", nil, "eth", "btc", band}, false},
+		{"no-product", PriceBandProposal{"proposal", "proposal", addr, "btc", "btc", band}, false},
+		{"no-price-band", PriceBandProposal{"proposal", "proposal", addr, "eth", "btc", sdk.Dec{}}, false},
+		{"negative-price-band", PriceBandProposal{"proposal", "proposal", addr, "eth", "btc",
+			sdk.MustNewDecFromStr("-0.1")}, false},
+		{"too-large-price-band", PriceBandProposal{"proposal", "proposal", addr, "eth", "btc", sdk.OneDec()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result {
+				require.Nil(t, tt.pbp.ValidateBasic(), "test: %v", tt.name)
+			} else {
+				require.NotNil(t, tt.pbp.ValidateBasic(), "test: %v", tt.name)
+			}
+		})
+	}
+}
+
 func getLongString(n int) (s string) {
 	str := "0123456789"
 	for i := 0; i < n; i++ {
@@ -55,3 +139,73 @@ func getLongString(n int) (s string) {
 	fmt.Println(len(s))
 	return s
 }
+
+func TestListProposal_ValidateBasic(t *testing.T) {
+	addr, err := sdk.AccAddressFromBech32(TestTokenPairOwner)
+	require.Nil(t, err)
+
+	price := sdk.MustNewDecFromStr("10")
+	minQuantity := sdk.MustNewDecFromStr("0.01")
+	proposal := NewListProposal("proposal", "right list proposal", addr, "eth", "btc", price, addr, 4, 2, minQuantity,
+		AuctionTypeContinuous)
+	require.Equal(t, "proposal", proposal.GetTitle())
+	require.Equal(t, "right list proposal", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, proposalTypeList, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+
+	// the operator signs the proposal submitted by another proposer
+	operatorPriKey := secp256k1.GenPrivKey()
+	operator := sdk.AccAddress(operatorPriKey.PubKey().Address())
+	signedProposal := NewListProposal("proposal", "list proposal signed by the operator", addr, "eth", "btc", price,
+		operator, 4, 2, minQuantity, AuctionTypeContinuous)
+	signature, err := operatorPriKey.Sign(signedProposal.GetSignBytes())
+	require.Nil(t, err)
+	signedProposal.OperatorSignature = auth.StdSignature{PubKey: operatorPriKey.PubKey(), Signature: signature}
+	// the signature doesn't cover a different proposer
+	wrongProposerProposal := signedProposal
+	wrongProposerProposal.Proposer = sdk.AccAddress("other")
+	// the signature of someone else
+	otherPriKey := secp256k1.GenPrivKey()
+	otherSignature, err := otherPriKey.Sign(signedProposal.GetSignBytes())
+	require.Nil(t, err)
+	wrongSignerProposal := NewListProposal("proposal", "list proposal signed by the operator", addr, "eth", "btc",
+		price, operator, 4, 2, minQuantity, AuctionTypeContinuous)
+	wrongSignerProposal.OperatorSignature = auth.StdSignature{PubKey: otherPriKey.PubKey(), Signature: otherSignature}
+
+	tests := []struct {
+		name   string
+		lp     ListProposal
+		result bool
+	}{
+		{"list-proposal", proposal, true},
+		{"signed-by-operator", signedProposal, true},
+		{"signed-for-another-proposer", wrongProposerProposal, false},
+		{"signed-by-others", wrongSignerProposal, false},
+
+		{"no-title", ListProposal{"", "proposal", addr, "eth", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"long-title", ListProposal{getLongString(15), "proposal", addr, "eth", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-description", ListProposal{"proposal", "", addr, "eth", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-proposer", ListProposal{"proposal", "proposal", nil, "eth", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-operator", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, nil, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"same-asset", ListProposal{"proposal", "proposal", addr, "btc", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-base-asset", ListProposal{"proposal", "proposal", addr, "", "btc", price, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-init-price", ListProposal{"proposal", "proposal", addr, "eth", "btc", sdk.Dec{}, addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"zero-init-price", ListProposal{"proposal", "proposal", addr, "eth", "btc", sdk.ZeroDec(), addr, 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"negative-price-digit", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, addr, -1, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"too-large-quantity-digit", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, addr, 4, 19, minQuantity, "", auth.StdSignature{}}, false},
+		{"no-min-quantity", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, addr, 4, 2, sdk.Dec{}, "", auth.StdSignature{}}, false},
+		{"min-quantity-beyond-digit", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, addr, 4, 1, minQuantity, "", auth.StdSignature{}}, false},
+		{"operator-not-signed", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, sdk.AccAddress("other"), 4, 2, minQuantity, "", auth.StdSignature{}}, false},
+		{"invalid-auction-type", ListProposal{"proposal", "proposal", addr, "eth", "btc", price, addr, 4, 2, minQuantity, "invalid", auth.StdSignature{}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result {
+				require.Nil(t, tt.lp.ValidateBasic(), "test: %v", tt.name)
+			} else {
+				require.NotNil(t, tt.lp.ValidateBasic(), "test: %v", tt.name)
+			}
+		})
+	}
+}
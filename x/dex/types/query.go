@@ -65,3 +65,40 @@ func NewQueryDepositParams(address, baseAsset, quoteAsset string, page, perPage
 		PerPage:    perPage,
 	}
 }
+
+// QueryOperatorFeesParams defines query params of the fees accrued by an operator
+type QueryOperatorFeesParams struct {
+	Operator sdk.AccAddress
+	Product  string
+}
+
+// NewQueryOperatorFeesParams creates a new instance of QueryOperatorFeesParams
+func NewQueryOperatorFeesParams(operator sdk.AccAddress, product string) QueryOperatorFeesParams {
+	return QueryOperatorFeesParams{
+		Operator: operator,
+		Product:  product,
+	}
+}
+
+// QueryOperatorFeeStatementsParams defines query params of the fee statements of an operator.
+// A negative epoch queries the statements of all epochs
+type QueryOperatorFeeStatementsParams struct {
+	Operator sdk.AccAddress
+	Epoch    int64
+	Page     int
+	PerPage  int
+}
+
+// NewQueryOperatorFeeStatementsParams creates a new instance of QueryOperatorFeeStatementsParams
+func NewQueryOperatorFeeStatementsParams(operator sdk.AccAddress, epoch int64, page, perPage int) QueryOperatorFeeStatementsParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QueryOperatorFeeStatementsParams{
+		Operator: operator,
+		Epoch:    epoch,
+		Page:     page,
+		PerPage:  perPage,
+	}
+}
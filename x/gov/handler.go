@@ -141,11 +141,14 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg MsgVote) sdk.Result {
 func handleProposalAfterTally(
 	ctx sdk.Context, k keeper.Keeper, proposal *types.Proposal, distribute bool, status ProposalStatus,
 ) (string, string) {
-	if distribute {
-		k.DistributeDeposits(ctx, proposal.ProposalID)
-	} else {
-		k.RefundDeposits(ctx, proposal.ProposalID)
-	}
+	// the deposits are refunded or distributed after the proposal handler, which may charge a fee out of them
+	defer func() {
+		if distribute {
+			k.DistributeDeposits(ctx, proposal.ProposalID)
+		} else {
+			k.RefundDeposits(ctx, proposal.ProposalID)
+		}
+	}()
 
 	if status == StatusPassed {
 		handler := k.Router().GetRoute(proposal.ProposalRoute())
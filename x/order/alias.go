@@ -14,32 +14,43 @@ const (
 	DefaultParamspace = types.DefaultParamspace
 	DefaultCodespace  = types.DefaultCodespace
 	OrderStoreKey     = types.OrderStoreKey
+	TStoreKey         = types.TStoreKey
 )
 
 // nolint
 // types aliases
 type (
-	Keeper           = keeper.Keeper
-	Order            = types.Order
-	DepthBook        = types.DepthBook
-	MatchResult      = types.MatchResult
-	Deal             = types.Deal
-	Params           = types.Params
-	MsgNewOrder      = types.MsgNewOrder
-	MsgCancelOrder   = types.MsgCancelOrder
-	MsgNewOrders     = types.MsgNewOrders
-	MsgCancelOrders  = types.MsgCancelOrders
-	BlockMatchResult = types.BlockMatchResult
+	Keeper               = keeper.Keeper
+	Order                = types.Order
+	DepthBook            = types.DepthBook
+	MatchResult          = types.MatchResult
+	Deal                 = types.Deal
+	Params               = types.Params
+	MsgNewOrder          = types.MsgNewOrder
+	MsgCancelOrder       = types.MsgCancelOrder
+	MsgNewOrders         = types.MsgNewOrders
+	MsgCancelOrders      = types.MsgCancelOrders
+	MsgCancelAllOrders   = types.MsgCancelAllOrders
+	MsgAmendOrders       = types.MsgAmendOrders
+	AmendOrderItem       = types.AmendOrderItem
+	BlockMatchResult     = types.BlockMatchResult
+	MsgDepositOrderRent  = types.MsgDepositOrderRent
+	MsgWithdrawOrderRent = types.MsgWithdrawOrderRent
+	OrderRent            = types.OrderRent
 )
 
 // nolint
 // functions aliases
 var (
-	RegisterCodec     = types.RegisterCodec
-	DefaultParams     = types.DefaultParams
-	NewMsgNewOrder    = types.NewMsgNewOrder
-	NewMsgCancelOrder = types.NewMsgCancelOrder
-	NewKeeper         = keeper.NewKeeper
-	NewQuerier        = keeper.NewQuerier
-	FormatOrderIDsKey = types.FormatOrderIDsKey
+	RegisterCodec         = types.RegisterCodec
+	DefaultParams         = types.DefaultParams
+	NewMsgNewOrder        = types.NewMsgNewOrder
+	NewMsgCancelOrder     = types.NewMsgCancelOrder
+	NewMsgCancelAllOrders = types.NewMsgCancelAllOrders
+	NewMsgAmendOrders     = types.NewMsgAmendOrders
+	NewAmendOrderItem     = types.NewAmendOrderItem
+	NewOrderRent          = types.NewOrderRent
+	NewKeeper             = keeper.NewKeeper
+	NewQuerier            = keeper.NewQuerier
+	FormatOrderIDsKey     = types.FormatOrderIDsKey
 )
@@ -27,6 +27,7 @@ type MockApp struct {
 	*mock.App
 
 	keyOrder     *sdk.KVStoreKey
+	tkeyOrder    *sdk.TransientStoreKey
 	keyToken     *sdk.KVStoreKey
 	keyLock      *sdk.KVStoreKey
 	keyDex       *sdk.KVStoreKey
@@ -58,8 +59,9 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	registerCodec(mapp.Cdc)
 
 	mockApp = &MockApp{
-		App:      mapp,
-		keyOrder: sdk.NewKVStoreKey(OrderStoreKey),
+		App:       mapp,
+		keyOrder:  sdk.NewKVStoreKey(OrderStoreKey),
+		tkeyOrder: sdk.NewTransientStoreKey(TStoreKey),
 
 		keyToken:     sdk.NewKVStoreKey(token.StoreKey),
 		keyLock:      sdk.NewKVStoreKey(token.KeyLock),
@@ -80,6 +82,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName: nil,
 		token.ModuleName:      {supply.Minter, supply.Burner},
+		ModuleName:            nil,
 	}
 	mockApp.supplyKeeper = supply.NewKeeper(mockApp.Cdc, mockApp.keySupply, mockApp.AccountKeeper,
 		mockApp.bankKeeper, maccPerms)
@@ -112,6 +115,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 		mockApp.ParamsKeeper.Subspace(DefaultParamspace),
 		auth.FeeCollectorName,
 		mockApp.keyOrder,
+		mockApp.tkeyOrder,
 		mockApp.Cdc,
 		true,
 		monitor.NopOrderMetrics())
@@ -138,6 +142,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	app := mockApp
 	require.NoError(t, app.CompleteSetup(
 		app.keyOrder,
+		app.tkeyOrder,
 		app.keyToken,
 		app.keyDex,
 		app.keyTokenPair,
@@ -0,0 +1,224 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/okchain/x/common/monitor"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order"
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/params"
+	"github.com/okex/okchain/x/token"
+)
+
+// DefaultBlockInterval is the time between two replayed blocks whose time is not specified
+const DefaultBlockInterval = 3 * time.Second
+
+// the modules whose state is needed to replay the order flow, in the order of init genesis
+var genesisModules = []string{genaccounts.ModuleName, supply.ModuleName, token.ModuleName, dex.ModuleName,
+	order.ModuleName}
+
+var maccPerms = map[string][]string{
+	auth.FeeCollectorName: nil,
+	token.ModuleName:      {supply.Minter, supply.Burner},
+	order.ModuleName:      nil,
+	dex.ModuleName:        nil,
+}
+
+// Block is a block of the order flow to replay
+type Block struct {
+	Time time.Time `json:"time"`
+	Msgs []sdk.Msg `json:"msgs"`
+}
+
+// TxResult is the result of a msg delivered in the replayed block
+type TxResult struct {
+	Code sdk.CodeType `json:"code"`
+	Log  string       `json:"log"`
+}
+
+// BlockResult is the outcome of a replayed block
+type BlockResult struct {
+	Height      int64                   `json:"height"`
+	TxResults   []TxResult              `json:"tx_results"`
+	MatchResult *types.BlockMatchResult `json:"match_result"`
+	Fees        []*token.FeeDetail      `json:"fees"`
+}
+
+// Simulator replays the order flow block by block against an in-memory store, with the real order handler and
+// match engines. The signatures and the gas fees of the msgs are not taken into account
+type Simulator struct {
+	cdc         *codec.Codec
+	ms          sdk.CommitMultiStore
+	mm          *module.Manager
+	handler     sdk.Handler
+	orderKeeper keeper.Keeper
+	tokenKeeper token.Keeper
+	header      abci.Header
+	logger      log.Logger
+}
+
+// MakeCodec creates the codec to decode the genesis state and the order flow
+func MakeCodec() *codec.Codec {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	token.RegisterCodec(cdc)
+	dex.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	return cdc
+}
+
+// NewSimulator creates a simulator from the exported app state, which must contain the state of the dex & order
+// modules. The first replayed block is at startHeight, right after the genesis time
+func NewSimulator(appState map[string]json.RawMessage, startHeight int64, genesisTime time.Time,
+	logger log.Logger) (*Simulator, error) {
+	for _, moduleName := range []string{dex.ModuleName, order.ModuleName} {
+		if appState[moduleName] == nil {
+			return nil, fmt.Errorf("the state of %s module is missing in the app state", moduleName)
+		}
+	}
+	if startHeight <= 0 {
+		return nil, fmt.Errorf("invalid start height: %d", startHeight)
+	}
+
+	db := dbm.NewMemDB()
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyToken := sdk.NewKVStoreKey(token.StoreKey)
+	keyLock := sdk.NewKVStoreKey(token.KeyLock)
+	keyDex := sdk.NewKVStoreKey(dex.StoreKey)
+	keyTokenPair := sdk.NewKVStoreKey(dex.TokenPairStoreKey)
+	keyOrder := sdk.NewKVStoreKey(order.OrderStoreKey)
+	tkeyOrder := sdk.NewTransientStoreKey(order.TStoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []sdk.StoreKey{keyAcc, keySupply, keyParams, keyToken, keyLock, keyDex, keyTokenPair,
+		keyOrder} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(tkeyOrder, sdk.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		return nil, err
+	}
+
+	blacklistedAddrs := make(map[string]bool)
+	for acc := range maccPerms {
+		blacklistedAddrs[supply.NewModuleAddress(acc).String()] = true
+	}
+
+	cdc := MakeCodec()
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, paramsKeeper.Subspace(auth.DefaultParamspace),
+		auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace),
+		bank.DefaultCodespace, blacklistedAddrs)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	tokenKeeper := token.NewKeeper(bankKeeper, paramsKeeper.Subspace(token.DefaultParamspace),
+		auth.FeeCollectorName, supplyKeeper, keyToken, keyLock, cdc, true)
+	dexKeeper := dex.NewKeeper(auth.FeeCollectorName, supplyKeeper, paramsKeeper.Subspace(dex.DefaultParamspace),
+		tokenKeeper, nil, bankKeeper, keyDex, keyTokenPair, cdc)
+	orderKeeper := keeper.NewKeeper(tokenKeeper, supplyKeeper, dexKeeper,
+		paramsKeeper.Subspace(order.DefaultParamspace), auth.FeeCollectorName, keyOrder, tkeyOrder,
+		cdc, true,
+		monitor.NopOrderMetrics())
+
+	mm := module.NewManager(
+		genaccounts.NewAppModule(accountKeeper),
+		supply.NewAppModule(supplyKeeper, accountKeeper),
+		token.NewAppModule(version.ProtocolVersionV0, tokenKeeper, supplyKeeper),
+		dex.NewAppModule(version.ProtocolVersionV0, dexKeeper, supplyKeeper),
+		order.NewAppModule(version.ProtocolVersionV0, orderKeeper, supplyKeeper),
+	)
+	mm.SetOrderInitGenesis(genesisModules...)
+	mm.SetOrderBeginBlockers(order.ModuleName, token.ModuleName)
+	mm.SetOrderEndBlockers(dex.ModuleName, order.ModuleName)
+
+	s := &Simulator{
+		cdc:         cdc,
+		ms:          ms,
+		mm:          mm,
+		handler:     order.NewOrderHandler(orderKeeper),
+		orderKeeper: orderKeeper,
+		tokenKeeper: tokenKeeper,
+		header:      abci.Header{Height: startHeight - 1, Time: genesisTime},
+		logger:      logger,
+	}
+	mm.InitGenesis(s.newContext(), appState)
+	return s, nil
+}
+
+// Codec returns the codec of the simulator
+func (s *Simulator) Codec() *codec.Codec {
+	return s.cdc
+}
+
+// DeliverBlock replays the msgs of the block one by one as the txs of the next block, the state changes of the
+// failed msgs are discarded
+func (s *Simulator) DeliverBlock(block Block) (result BlockResult) {
+	s.header.Height++
+	if block.Time.IsZero() {
+		s.header.Time = s.header.Time.Add(DefaultBlockInterval)
+	} else {
+		s.header.Time = block.Time
+	}
+	ctx := s.newContext()
+
+	s.mm.BeginBlock(ctx, abci.RequestBeginBlock{Header: s.header})
+	for i, msg := range block.Msgs {
+		result.TxResults = append(result.TxResults, s.deliverMsg(ctx, i, msg))
+	}
+	s.mm.EndBlock(ctx, abci.RequestEndBlock{Height: s.header.Height})
+
+	result.Height = s.header.Height
+	result.MatchResult = s.orderKeeper.GetBlockMatchResult()
+	result.Fees = s.tokenKeeper.GetFeeDetailList()
+	return result
+}
+
+func (s *Simulator) deliverMsg(ctx sdk.Context, index int, msg sdk.Msg) TxResult {
+	if msg.Route() != order.RouterKey {
+		return TxResult{Code: sdk.CodeUnknownRequest, Log: fmt.Sprintf("unrecognized msg type: %s", msg.Type())}
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return TxResult{Code: err.Code(), Log: err.Result().Log}
+	}
+
+	// the tx bytes mix in the height and the index of the msg in the block, so the identical msgs of a block are
+	// replayed as different txs with their own tx hashes
+	txBytes := append(msg.GetSignBytes(), []byte(fmt.Sprintf("/%d/%d", ctx.BlockHeight(), index))...)
+
+	// the state changes are written only if the msg succeeds, the same as the txs on chain
+	msCache := ctx.MultiStore().CacheMultiStore()
+	res := s.handler(ctx.WithMultiStore(msCache).WithTxBytes(txBytes), msg)
+	if res.IsOK() {
+		msCache.Write()
+	}
+	return TxResult{Code: res.Code, Log: res.Log}
+}
+
+func (s *Simulator) newContext() sdk.Context {
+	return sdk.NewContext(s.ms, s.header, false, s.logger)
+}
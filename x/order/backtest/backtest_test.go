@@ -0,0 +1,150 @@
+package backtest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order"
+	"github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
+	tokentypes "github.com/okex/okchain/x/token/types"
+)
+
+func newTestAppState(t *testing.T, addrs ...sdk.AccAddress) map[string]json.RawMessage {
+	cdc := MakeCodec()
+	coins, err := sdk.ParseDecCoins("100" + common.NativeToken + ",100" + common.TestToken)
+	require.Nil(t, err)
+	var accounts genaccounts.GenesisState
+	for _, addr := range addrs {
+		accounts = append(accounts, genaccounts.GenesisAccount{Address: addr, Coins: coins})
+	}
+
+	dexGenesis := dex.DefaultGenesisState()
+	dexGenesis.TokenPairs = []*dex.TokenPair{dex.GetBuiltInTokenPair()}
+	dexGenesis.MaxTokenPairID = 1
+	orderGenesis := order.DefaultGenesisState()
+	orderGenesis.Params = types.DefaultTestParams()
+
+	return map[string]json.RawMessage{
+		genaccounts.ModuleName: cdc.MustMarshalJSON(accounts),
+		supply.ModuleName:      cdc.MustMarshalJSON(supply.DefaultGenesisState()),
+		token.ModuleName:       token.AppModuleBasic{}.DefaultGenesis(),
+		dex.ModuleName:         cdc.MustMarshalJSON(dexGenesis),
+		order.ModuleName:       cdc.MustMarshalJSON(orderGenesis),
+	}
+}
+
+func TestSimulator(t *testing.T) {
+	addr0 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr1 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	appState := newTestAppState(t, addr0, addr1)
+	genesisTime := time.Unix(1000, 0)
+
+	// the state of the order module is required
+	invalidAppState := newTestAppState(t)
+	delete(invalidAppState, order.ModuleName)
+	_, err := NewSimulator(invalidAppState, 1, genesisTime, log.NewNopLogger())
+	require.Error(t, err)
+	_, err = NewSimulator(appState, 0, genesisTime, log.NewNopLogger())
+	require.Error(t, err)
+
+	simulator, err := NewSimulator(appState, 1, genesisTime, log.NewNopLogger())
+	require.Nil(t, err)
+
+	// decode the order flow
+	buy := types.NewMsgNewOrders(addr0, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+	})
+	sell := types.NewMsgNewOrders(addr1, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	})
+	tooLarge := types.NewMsgNewOrders(addr1, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1000.0"),
+	})
+	bz := simulator.Codec().MustMarshalJSON(Block{Msgs: []sdk.Msg{buy, sell, tooLarge}})
+	var block Block
+	simulator.Codec().MustUnmarshalJSON(bz, &block)
+	require.Equal(t, 3, len(block.Msgs))
+
+	result := simulator.DeliverBlock(block)
+	require.EqualValues(t, 1, result.Height)
+	require.Equal(t, 3, len(result.TxResults))
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[0].Code)
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[1].Code)
+	require.NotEqual(t, sdk.CodeOK, result.TxResults[2].Code)
+	require.NotNil(t, result.MatchResult)
+	require.Equal(t, genesisTime.Add(DefaultBlockInterval).Unix(), result.MatchResult.TimeStamp)
+	matchResult := result.MatchResult.ResultMap[types.TestTokenPair]
+	require.Equal(t, sdk.MustNewDecFromStr("10.0"), matchResult.Price)
+	require.Equal(t, 2, len(matchResult.Deals))
+	require.NotEmpty(t, result.Fees)
+
+	// the resting order is cancelled in the next block, the msgs of other modules are rejected
+	blockTime := time.Unix(2000, 0)
+	result = simulator.DeliverBlock(Block{Time: blockTime, Msgs: []sdk.Msg{
+		types.NewMsgCancelOrders(addr0, []string{types.FormatOrderID(1, 2)}),
+		tokentypes.NewMsgTokenBurn(sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec()), addr0),
+	}})
+	require.EqualValues(t, 2, result.Height)
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[0].Code)
+	require.Equal(t, sdk.CodeUnknownRequest, result.TxResults[1].Code)
+	require.Nil(t, result.MatchResult.ResultMap)
+
+	var refunded bool
+	for _, fee := range result.Fees {
+		refunded = refunded || fee.FeeType == types.FeeTypeOrderReceive
+	}
+	require.True(t, refunded)
+}
+
+func TestSimulator_IdenticalMsgs(t *testing.T) {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	simulator, err := NewSimulator(newTestAppState(t, addr), 1, time.Unix(1000, 0), log.NewNopLogger())
+	require.Nil(t, err)
+
+	// the same order is placed twice in the block, the second one can't afford the coins
+	buy := types.NewMsgNewOrders(addr, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "6.0"),
+	})
+	result := simulator.DeliverBlock(Block{Msgs: []sdk.Msg{buy, buy}})
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[0].Code)
+	require.NotEqual(t, sdk.CodeOK, result.TxResults[1].Code)
+
+	// the identical msgs are different txs, the changes of the failed one are discarded
+	ctx := simulator.newContext()
+	order1 := simulator.orderKeeper.GetOrder(ctx, types.FormatOrderID(1, 1))
+	require.NotNil(t, order1)
+	require.Nil(t, simulator.orderKeeper.GetOrder(ctx, types.FormatOrderID(1, 2)))
+	depthBook := simulator.orderKeeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(depthBook.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("6.0"), depthBook.Items[0].BuyQuantity)
+
+	// both identical msgs are placed in the next block with their own tx hashes
+	lowBuy := types.NewMsgNewOrders(addr, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "1.0", "1.0"),
+	})
+	result = simulator.DeliverBlock(Block{Msgs: []sdk.Msg{lowBuy, lowBuy}})
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[0].Code)
+	require.EqualValues(t, sdk.CodeOK, result.TxResults[1].Code)
+	ctx = simulator.newContext()
+	order2 := simulator.orderKeeper.GetOrder(ctx, types.FormatOrderID(2, 1))
+	order3 := simulator.orderKeeper.GetOrder(ctx, types.FormatOrderID(2, 2))
+	require.NotNil(t, order2)
+	require.NotNil(t, order3)
+	require.NotEqual(t, order2.TxHash, order3.TxHash)
+	require.NotEqual(t, order1.TxHash, order2.TxHash)
+	depthBook = simulator.orderKeeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 2, len(depthBook.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("2.0"), depthBook.Items[1].BuyQuantity)
+}
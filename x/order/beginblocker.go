@@ -10,10 +10,11 @@ import (
 )
 
 // BeginBlocker runs the logic of BeginBlocker with version 0.
-// BeginBlocker resets keeper cache.
+// BeginBlocker indexes the orders stored before the senders' open order index existed, and resets keeper cache.
 func BeginBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 	seq := perf.GetPerf().OnBeginBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnBeginBlockExit(ctx, types.ModuleName, seq)
 
+	keeper.BuildSenderOrderIndex(ctx)
 	keeper.ResetCache(ctx)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -25,6 +26,12 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(client.GetCommands(
 		GetCmdQueryOrder(queryRoute, cdc),
 		GetCmdDepthBook(queryRoute, cdc),
+		GetCmdDepthBookDiff(queryRoute, cdc),
+		GetCmdBookL3(queryRoute, cdc),
+		GetCmdOpenOrders(queryRoute, cdc),
+		GetCmdOrderRent(queryRoute, cdc),
+		GetCmdTriggerBook(queryRoute, cdc),
+		GetCmdHaltedProducts(queryRoute, cdc),
 		GetCmdQueryStore(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
 	)...)
@@ -94,6 +101,229 @@ The 'product' is a trading pair in full name of the tokens: ${base-asset-symbol}
 	return cmd
 }
 
+// GetCmdDepthBookDiff queries the changes of a product's depth book after a sequence number
+func GetCmdDepthBookDiff(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depthbook-diff [product]",
+		Short: "Query the changes of the depth book of a trading pair after a sequence number",
+		Long: strings.TrimSpace(`Query the changes of the depth book of a trading pair after a sequence number:
+
+$ okchaincli query order depthbook-diff mytoken_okt --sequence 1024
+
+The snapshot of the depth book is returned instead, if the sequence is 0 or the changes after it are not kept by the node any more.
+The checksum covers the best 25 levels of each side.
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			product := args[0]
+			params := keeper.NewQueryDepthBookDiffParams(product, viper.GetInt64("sequence"), viper.GetUint("size"))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDepthBookDiff),
+				bz)
+			if err != nil {
+				fmt.Printf("get depth book diff of %s failed: %v\n", product, err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int64("sequence", 0, "the sequence number of the local depth book, 0 for the snapshot")
+	cmd.Flags().Uint("size", keeper.DefaultBookSize, "depth book single-side size of the snapshot")
+	return cmd
+}
+
+// GetCmdBookL3 queries the open orders per price level of a product, or the queue position of an order
+func GetCmdBookL3(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "book-l3 [product]",
+		Short: "Query the open orders per price level of a trading pair",
+		Long: strings.TrimSpace(`Query the open orders per price level of a side of a trading pair, from the best price and
+in priority order at each price:
+
+$ okchaincli query order book-l3 mytoken_okt --side SELL --page 1 --per-page 10
+
+Query the position of an order in the queue of its price level:
+
+$ okchaincli query order book-l3 mytoken_okt --order-id ID0000000010-1
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			product := args[0]
+
+			if orderID := viper.GetString("order-id"); orderID != "" {
+				res, _, err := cliCtx.QueryWithData(
+					fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryQueuePosition, orderID),
+					nil)
+				if err != nil {
+					fmt.Printf("get queue position of %s failed: %v\n", orderID, err.Error())
+					return nil
+				}
+				fmt.Println(string(res))
+				return nil
+			}
+
+			params := keeper.NewQueryBookL3Params(product, viper.GetString("side"), viper.GetInt("page"),
+				viper.GetInt("per-page"))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBookL3),
+				bz)
+			if err != nil {
+				fmt.Printf("get l3 book of %s failed: %v\n", product, err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String("side", types.BuyOrder, "BUY or SELL")
+	cmd.Flags().Int("page", keeper.DefaultPage, "page num of the price levels")
+	cmd.Flags().Int("per-page", keeper.DefaultPerPage, "price levels per page")
+	cmd.Flags().String("order-id", "", "query the queue position of the order instead")
+	return cmd
+}
+
+// GetCmdOpenOrders queries the open and untriggered orders of an address
+func GetCmdOpenOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "open-orders [address]",
+		Short: "Query the open orders of an address",
+		Long: strings.TrimSpace(`Query the open and untriggered orders of an address, optionally filtered by trading pair:
+
+$ okchaincli query order open-orders okchain1... --product mytoken_okt --page 1 --per-page 10
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := keeper.NewQueryOpenOrdersParams(addr, viper.GetString("product"), viper.GetInt("page"),
+				viper.GetInt("per-page"))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOpenOrders),
+				bz)
+			if err != nil {
+				fmt.Printf("get open orders of %s failed: %v\n", args[0], err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String("product", "", "only query the orders of the trading pair")
+	cmd.Flags().Int("page", keeper.DefaultPage, "page num of the orders")
+	cmd.Flags().Int("per-page", keeper.DefaultPerPage, "orders per page")
+	return cmd
+}
+
+// GetCmdTriggerBook queries the untriggered stop-loss/take-profit orders of a product
+func GetCmdTriggerBook(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "triggerbook [product]",
+		Short: "Query the untriggered stop-loss/take-profit orders of a trading pair",
+		Long: strings.TrimSpace(`Query the untriggered stop-loss/take-profit orders of a trading pair:
+
+$ okchaincli query order triggerbook mytoken_okt
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			product := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryTriggerBook, product),
+				nil)
+			if err != nil {
+				fmt.Printf("get trigger book of %s failed: %v\n", product, err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdOrderRent queries the prepaid order rent balance of an address
+func GetCmdOrderRent(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rent [address]",
+		Short: "Query the prepaid order rent balance of an address",
+		Long: strings.TrimSpace(`Query the prepaid order rent balance of an address:
+
+$ okchaincli query order rent okchain1...
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryOrderRent, args[0]),
+				nil)
+			if err != nil {
+				return err
+			}
+
+			var rent types.OrderRent
+			cdc.MustUnmarshalJSON(res, &rent)
+			return cliCtx.PrintOutput(rent)
+		},
+	}
+}
+
+// GetCmdHaltedProducts queries the products halted because their match prices broke the price bands
+func GetCmdHaltedProducts(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "halted",
+		Short: "Query the trading pairs halted by their price bands",
+		Long: strings.TrimSpace(`Query the trading pairs halted because their match prices broke the price bands:
+
+$ okchaincli query order halted
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryHalted),
+				nil)
+			if err != nil {
+				fmt.Printf("get halted trading pairs failed: %v\n", err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
 // GetCmdQueryStore queries store statistic
 func GetCmdQueryStore(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -25,6 +26,10 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		getCmdNewOrder(cdc),
 		getCmdCancelOrder(cdc),
+		getCmdCancelAllOrders(cdc),
+		getCmdAmendOrder(cdc),
+		getCmdDepositOrderRent(cdc),
+		getCmdWithdrawOrderRent(cdc),
 	)...)
 
 	return txCmd
@@ -36,6 +41,11 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var orderType string
+	var timeInForce string
+	var expireHeight string
+	var triggerPrice string
+	var atomic bool
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
@@ -52,7 +62,8 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cdc, product, side, price, quantity)
+			err := handleNewOrder(cdc, product, side, price, quantity, orderType, timeInForce, expireHeight,
+				triggerPrice, atomic)
 			return err
 
 		},
@@ -60,12 +71,18 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 
 	cmd.Flags().StringVarP(&product, "product", "", "", "Trading pair in full name of the tokens: ${baseAssetSymbol}_${quoteAssetSymbol}, for example \"mycoin_okt\".")
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
-	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
-	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order, 0 for market order")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order, the amount of quote token to spend at most for market buy order, which is divided by the protection price into the quantity")
+	cmd.Flags().StringVarP(&orderType, "type", "", "", "LIMIT, MARKET, STOP_LOSS or TAKE_PROFIT (default \"LIMIT\")")
+	cmd.Flags().StringVarP(&timeInForce, "time-in-force", "", "", "GTC, IOC, FOK, POST_ONLY or GTB (default \"GTC\")")
+	cmd.Flags().StringVarP(&expireHeight, "expire-height", "", "", "The block height at which the GTB order expires")
+	cmd.Flags().StringVarP(&triggerPrice, "trigger-price", "", "", "The trigger price of the STOP_LOSS or TAKE_PROFIT order")
+	cmd.Flags().BoolVarP(&atomic, "atomic", "", false, "Reject all the orders if any of them fails")
 	return cmd
 }
 
-func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string,
+	orderType string, timeInForce string, expireHeight string, triggerPrice string, atomic bool) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
@@ -83,6 +100,58 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 		return errors.New("invalid param quantity counts")
 	}
 
+	typeArr := make([]string, len(productArr))
+	if len(orderType) > 0 {
+		typeArr = strings.Split(orderType, ",")
+		if len(productArr) != len(typeArr) {
+			return errors.New("invalid param type counts")
+		}
+	}
+
+	timeInForceArr := make([]string, len(productArr))
+	if len(timeInForce) > 0 {
+		timeInForceArr = strings.Split(timeInForce, ",")
+		if len(productArr) != len(timeInForceArr) {
+			return errors.New("invalid param time-in-force counts")
+		}
+	}
+
+	expireHeightArr := make([]int64, len(productArr))
+	if len(expireHeight) > 0 {
+		heights := strings.Split(expireHeight, ",")
+		if len(productArr) != len(heights) {
+			return errors.New("invalid param expire-height counts")
+		}
+		for i, height := range heights {
+			if len(height) == 0 {
+				continue
+			}
+			h, err := strconv.ParseInt(height, 10, 64)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			expireHeightArr[i] = h
+		}
+	}
+
+	triggerPriceArr := make([]sdk.Dec, len(productArr))
+	if len(triggerPrice) > 0 {
+		prices := strings.Split(triggerPrice, ",")
+		if len(productArr) != len(prices) {
+			return errors.New("invalid param trigger-price counts")
+		}
+		for i, p := range prices {
+			if len(p) == 0 {
+				continue
+			}
+			tp, err := sdk.NewDecFromStr(p)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			triggerPriceArr[i] = tp
+		}
+	}
+
 	for i := 0; i < len(productArr); i++ {
 		product := productArr[i]
 		side := sideArr[i]
@@ -95,10 +164,14 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 			return errors.New(err.Error())
 		}
 		items = append(items, types.OrderItem{
-			Product:  product,
-			Side:     side,
-			Price:    price,
-			Quantity: quantity,
+			Product:      product,
+			Side:         side,
+			Price:        price,
+			Quantity:     quantity,
+			Type:         typeArr[i],
+			TimeInForce:  timeInForceArr[i],
+			ExpireHeight: expireHeightArr[i],
+			TriggerPrice: triggerPriceArr[i],
 		})
 	}
 
@@ -106,6 +179,7 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 	cliCtx := context.NewCLIContext().WithCodec(cdc)
 
 	msg := types.NewMsgNewOrders(cliCtx.GetFromAddress(), items)
+	msg.Atomic = atomic
 	err := utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 	return err
 }
@@ -130,3 +204,112 @@ func getCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func getCmdCancelAllOrders(cdc *codec.Codec) *cobra.Command {
+	var product string
+	var side string
+	cmd := &cobra.Command{
+		Use:   "cancel-all",
+		Short: "cancel all the open orders, optionally filtered by product or side",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgCancelAllOrders(cliCtx.GetFromAddress(), product, side)
+			err := utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			if err != nil {
+				fmt.Println(err)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&product, "product", "", "", "Only cancel the orders of the trading pair")
+	cmd.Flags().StringVarP(&side, "side", "s", "", "Only cancel the orders of BUY or SELL side")
+	return cmd
+}
+
+func getCmdAmendOrder(cdc *codec.Codec) *cobra.Command {
+	var price string
+	var quantity string
+	cmd := &cobra.Command{
+		Use:   "amend [order-id]",
+		Short: "cancel orders and place new ones at the new prices and quantities atomically",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orderIDs := strings.Split(args[0], ",")
+			priceArr := strings.Split(price, ",")
+			quantityArr := strings.Split(quantity, ",")
+			if len(orderIDs) != len(priceArr) {
+				return errors.New("invalid param price counts")
+			}
+			if len(orderIDs) != len(quantityArr) {
+				return errors.New("invalid param quantity counts")
+			}
+
+			var items []types.AmendOrderItem
+			for i, orderID := range orderIDs {
+				price, err := sdk.NewDecFromStr(priceArr[i])
+				if err != nil {
+					return errors.New(err.Error())
+				}
+				quantity, err := sdk.NewDecFromStr(quantityArr[i])
+				if err != nil {
+					return errors.New(err.Error())
+				}
+				items = append(items, types.AmendOrderItem{OrderID: orderID, Price: price, Quantity: quantity})
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgAmendOrders(cliCtx.GetFromAddress(), items)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The new price of the order")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The new quantity of the order")
+	return cmd
+}
+
+func getCmdDepositOrderRent(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deposit-rent [amount]",
+		Short: "top up the prepaid balance paying the rent of the resting orders per block",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgDepositOrderRent(cliCtx.GetFromAddress(), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdWithdrawOrderRent(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-rent [amount]",
+		Short: "withdraw from the prepaid order rent balance, the resting orders are cancelled if it runs out",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawOrderRent(cliCtx.GetFromAddress(), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
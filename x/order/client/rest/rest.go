@@ -8,6 +8,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/gorilla/mux"
 
@@ -19,6 +20,8 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/order/depthbook", orderBookHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/open", openOrdersHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/rent/{address}", orderRentHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/order/{orderID}", orderDetailHandler(cliCtx)).Methods("GET")
 }
 
@@ -91,3 +94,65 @@ func orderBookHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, resBytes)
 	}
 }
+
+func orderRentHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			common.HandleErrorMsg(w, cliCtx, fmt.Sprintf("Bad request: invalid address: %s", err.Error()))
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s/%s", types.QueryOrderRent, address), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		rent := types.OrderRent{}
+		codec.Cdc.MustUnmarshalJSON(res, &rent)
+		response := common.GetBaseResponse(rent)
+		resBytes, err2 := json.Marshal(response)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, err2.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func openOrdersHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr, err := sdk.AccAddressFromBech32(r.URL.Query().Get("address"))
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, fmt.Sprintf("Bad request: invalid address: %s", err.Error()))
+			return
+		}
+		var page, perPage int
+		if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+			if page, err = strconv.Atoi(pageStr); err != nil {
+				common.HandleErrorMsg(w, cliCtx, err.Error())
+				return
+			}
+		}
+		if perPageStr := r.URL.Query().Get("per_page"); perPageStr != "" {
+			if perPage, err = strconv.Atoi(perPageStr); err != nil {
+				common.HandleErrorMsg(w, cliCtx, err.Error())
+				return
+			}
+		}
+		params := keeper.NewQueryOpenOrdersParams(addr, r.URL.Query().Get("product"), page, perPage)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s", types.QueryOpenOrders), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
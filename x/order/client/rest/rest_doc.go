@@ -78,3 +78,36 @@ type BookResponse struct {
 //     Schemes: http, https
 //     Responses:
 //       200: BookResponse
+
+// OpenOrdersParam : open orders param
+// swagger:parameters getOpenOrders
+type OpenOrdersParam struct {
+	// address of the order sender
+	// Required: true
+	// in: query
+	Address string `json:"address"`
+	// token pair string
+	// in: query
+	Product string `json:"product"`
+	// page num
+	// in: query
+	Page int `json:"page"`
+	// orders per page
+	// in: query
+	PerPage int `json:"per_page"`
+}
+
+// OpenOrdersResponse : open and untriggered orders of an address
+// swagger:response OpenOrdersResponse
+type OpenOrdersResponse struct {
+	// in: body
+	Body []types.Order
+}
+
+// swagger:route GET /order/open order getOpenOrders
+//
+// Get the open orders of an address
+//
+//     Schemes: http, https
+//     Responses:
+//       200: OpenOrdersResponse
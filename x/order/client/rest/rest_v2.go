@@ -21,6 +21,7 @@ func RegisterRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/instruments/{instrument_id}/book", depthBookHandlerV2(cliCtx)).Methods("GET")
 	r.HandleFunc("/order/placeorder", broadcastPlaceOrderRequest(cliCtx)).Methods("POST")
 	r.HandleFunc("/order/cancelorder", broadcastCancelOrderRequest(cliCtx)).Methods("POST")
+	r.HandleFunc("/order/cancelallorders", broadcastCancelAllOrdersRequest(cliCtx)).Methods("POST")
 }
 
 func depthBookHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
@@ -183,3 +184,53 @@ func broadcastCancelOrderRequest(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res2)
 	}
 }
+
+func broadcastCancelAllOrdersRequest(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BroadcastReq
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		err = cliCtx.Codec.UnmarshalJSON(body, &req)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		txBytes, err := cliCtx.Codec.MarshalBinaryLengthPrefixed(req.Tx)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithBroadcastMode(req.Mode)
+
+		res, err := cliCtx.BroadcastTx(txBytes)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// the cancelled orders are in the events of the result
+		res2 := placeCancelOrderResponse{
+			res,
+			"",
+			"",
+			true,
+			"",
+			"",
+		}
+		if res.Code != 0 {
+			res2.Result = false
+			res2.ErrorCode = strconv.Itoa(int(res.Code))
+			res2.ErrorMessage = res.Logs[0].Log
+
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res2)
+	}
+}
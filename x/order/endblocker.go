@@ -13,13 +13,19 @@ import (
 
 // EndBlocker called every block
 // 1. execute matching engine
-// 2. flush cache
+// 2. charge the rent of the resting orders
+// 3. flush cache
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnEndBlockExit(ctx, types.ModuleName, seq)
 
-	match.GetEngine().Run(ctx, keeper)
+	// drop the memory cache changes of the failed txs before the caches are used
+	keeper.SettleCacheChanges(ctx)
+
+	match.Run(ctx, keeper)
+
+	keeper.ChargeOrderRent(ctx, ctx.Logger().With("module", "order"))
 
 	// flush cache at the end
 	keeper.Cache2Disk(ctx)
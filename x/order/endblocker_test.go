@@ -695,3 +695,361 @@ func TestEndBlocker(t *testing.T) {
 		fmt.Println(k.GetOrder(ctx, types.FormatOrderID(blockHeight, 1)))
 	}
 }
+
+func TestEndBlockerMarketOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	})
+	result := handler(ctx, msg)
+	require.True(t, result.Code.IsOK())
+	sellOrderID := getOrderID(result)
+
+	buyerCoins := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address).GetCoins()
+	// market buy order spends 5.25okt at most, protection price is 10 * (1 + 0.05)
+	msg = types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewMarketOrderItem(types.TestTokenPair, types.BuyOrder, "5.25"),
+	})
+	result = handler(ctx, msg)
+	require.True(t, result.Code.IsOK())
+	buyOrder := k.GetOrder(ctx, getOrderID(result))
+	require.EqualValues(t, types.MarketOrder, buyOrder.Type)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.5"), buyOrder.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), buyOrder.Quantity)
+
+	EndBlocker(ctx, k)
+
+	buyOrder = k.GetOrder(ctx, buyOrder.OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, buyOrder.Status)
+	require.True(t, buyOrder.RemainLocked.IsZero())
+	sellOrder := k.GetOrder(ctx, sellOrderID)
+	require.EqualValues(t, types.OrderStatusOpen, sellOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), sellOrder.RemainQuantity)
+	// the buy order fills at 10 and spends 5okt of the 5.25okt, the budget divided by 1 + slippage,
+	// the rest of the budget is refunded
+	buyerCoinsAfter := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address).GetCoins()
+	require.EqualValues(t, sdk.MustNewDecFromStr("5"),
+		buyerCoins.AmountOf(common.NativeToken).Sub(buyerCoinsAfter.AmountOf(common.NativeToken)))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.4995"),
+		buyerCoinsAfter.AmountOf(common.TestToken).Sub(buyerCoins.AmountOf(common.TestToken)))
+
+	// market sell order without buyers is cancelled in EndBlock, the locked coins are refunded
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, k)
+	msg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewMarketOrderItem(types.TestTokenPair, types.SellOrder, "0.2"),
+	})
+	result = handler(ctx, msg)
+	require.True(t, result.Code.IsOK())
+	sellOrderID = getOrderID(result)
+
+	EndBlocker(ctx, k)
+
+	sellOrder = k.GetOrder(ctx, sellOrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, sellOrder.Status)
+	require.True(t, sellOrder.RemainLocked.IsZero())
+	depthBook := k.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[0].SellQuantity)
+	acc := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[1].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("99"), acc.GetCoins().AmountOf(common.TestToken))
+}
+
+func TestEndBlockerTimeInForce(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	handler := NewOrderHandler(k)
+	newOrder := func(addr sdk.AccAddress, side, price, quantity, timeInForce string, expireHeight int64) sdk.Result {
+		item := types.NewOrderItem(types.TestTokenPair, side, price, quantity)
+		item.TimeInForce = timeInForce
+		item.ExpireHeight = expireHeight
+		return handler(ctx, types.NewMsgNewOrders(addr, []types.OrderItem{item}))
+	}
+
+	result := newOrder(addrKeysSlice[1].Address, types.SellOrder, "10.0", "1.0", "", 0)
+	require.True(t, result.Code.IsOK())
+	sellOrderID := getOrderID(result)
+
+	// post-only order would match on entry
+	result = newOrder(addrKeysSlice[0].Address, types.BuyOrder, "10.0", "1.0", types.TimeInForcePostOnly, 0)
+	require.False(t, result.Code.IsOK())
+	result = newOrder(addrKeysSlice[0].Address, types.BuyOrder, "9.0", "1.0", types.TimeInForcePostOnly, 0)
+	require.True(t, result.Code.IsOK())
+	postOnlyOrderID := getOrderID(result)
+
+	// only 1.0 of the fill-or-kill order can be filled
+	result = newOrder(addrKeysSlice[0].Address, types.BuyOrder, "10.0", "2.0", types.TimeInForceFOK, 0)
+	require.True(t, result.Code.IsOK())
+	fokOrderID := getOrderID(result)
+	result = newOrder(addrKeysSlice[0].Address, types.BuyOrder, "10.0", "1.5", types.TimeInForceIOC, 0)
+	require.True(t, result.Code.IsOK())
+	iocOrderID := getOrderID(result)
+
+	// invalid expire height
+	result = newOrder(addrKeysSlice[1].Address, types.SellOrder, "12.0", "1.0", types.TimeInForceGTB, startHeight)
+	require.False(t, result.Code.IsOK())
+	result = newOrder(addrKeysSlice[1].Address, types.SellOrder, "12.0", "1.0", types.TimeInForceGTB,
+		startHeight+feeParams.OrderExpireBlocks+1)
+	require.False(t, result.Code.IsOK())
+	result = newOrder(addrKeysSlice[1].Address, types.SellOrder, "12.0", "1.0", types.TimeInForceGTB, startHeight+2)
+	require.True(t, result.Code.IsOK())
+	gtbOrder := k.GetOrder(ctx, getOrderID(result))
+	// the fee is locked for 2 blocks
+	require.EqualValues(t, 2, gtbOrder.OrderExpireBlocks)
+	require.EqualValues(t, "0.00000200"+common.NativeToken,
+		gtbOrder.GetExtraInfoWithKey(types.OrderExtraInfoKeyNewFee))
+
+	EndBlocker(ctx, k)
+
+	fokOrder := k.GetOrder(ctx, fokOrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, fokOrder.Status)
+	require.True(t, fokOrder.RemainLocked.IsZero())
+	iocOrder := k.GetOrder(ctx, iocOrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, iocOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), iocOrder.RemainQuantity)
+	require.True(t, iocOrder.RemainLocked.IsZero())
+	require.EqualValues(t, types.OrderStatusFilled, k.GetOrder(ctx, sellOrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, postOnlyOrderID).Status)
+
+	// good-til-block order expires at the expire height
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, k)
+	EndBlocker(ctx, k)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, gtbOrder.OrderID).Status)
+
+	ctx = ctx.WithBlockHeight(startHeight + 2)
+	BeginBlocker(ctx, k)
+	EndBlocker(ctx, k)
+	gtbOrder = k.GetOrder(ctx, gtbOrder.OrderID)
+	require.EqualValues(t, types.OrderStatusExpired, gtbOrder.Status)
+	require.True(t, gtbOrder.RemainLocked.IsZero())
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, postOnlyOrderID).Status)
+	depthBook := k.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), depthBook.Items[0].Price)
+}
+
+func TestEndBlockerConditionalOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	handler := NewOrderHandler(k)
+	newOrder := func(addr sdk.AccAddress, item types.OrderItem) sdk.Result {
+		return handler(ctx, types.NewMsgNewOrders(addr, []types.OrderItem{item}))
+	}
+
+	// the last price 10.0 has crossed the trigger price already
+	result := newOrder(addrKeysSlice[1].Address, types.NewConditionalOrderItem(types.StopLossOrder,
+		types.TestTokenPair, types.SellOrder, "11.0", "8.5", "1.0"))
+	require.False(t, result.Code.IsOK())
+
+	result = newOrder(addrKeysSlice[1].Address, types.NewConditionalOrderItem(types.StopLossOrder,
+		types.TestTokenPair, types.SellOrder, "9.0", "8.5", "1.0"))
+	require.True(t, result.Code.IsOK())
+	stopLossOrderID := getOrderID(result)
+	result = newOrder(addrKeysSlice[1].Address, types.NewConditionalOrderItem(types.TakeProfitOrder,
+		types.TestTokenPair, types.SellOrder, "12.0", "12.0", "1.0"))
+	require.True(t, result.Code.IsOK())
+	takeProfitOrderID := getOrderID(result)
+
+	// the coins are locked while waiting to be triggered
+	stopLossOrder := k.GetOrder(ctx, stopLossOrderID)
+	require.EqualValues(t, types.OrderStatusUntriggered, stopLossOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), stopLossOrder.RemainLocked)
+	require.EqualValues(t, 2, len(k.GetTriggerOrders(ctx, types.TestTokenPair)))
+	require.EqualValues(t, 0, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// cancel the untriggered order
+	result = handler(ctx, types.NewMsgCancelOrder(addrKeysSlice[1].Address, takeProfitOrderID))
+	require.True(t, result.Code.IsOK())
+	takeProfitOrder := k.GetOrder(ctx, takeProfitOrderID)
+	require.EqualValues(t, types.OrderStatusUntriggeredCancelled, takeProfitOrder.Status)
+	require.True(t, takeProfitOrder.RemainLocked.IsZero())
+	require.EqualValues(t, 1, len(k.GetTriggerOrders(ctx, types.TestTokenPair)))
+
+	// the last price falls to 9.0
+	result = newOrder(addrKeysSlice[1].Address, types.NewOrderItem(types.TestTokenPair, types.SellOrder, "9.0", "1.0"))
+	require.True(t, result.Code.IsOK())
+	result = newOrder(addrKeysSlice[0].Address, types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"))
+	require.True(t, result.Code.IsOK())
+	EndBlocker(ctx, k)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), k.GetLastPrice(ctx, types.TestTokenPair))
+
+	// the stop-loss order is triggered right after the auction sets the last price
+	stopLossOrder = k.GetOrder(ctx, stopLossOrderID)
+	require.EqualValues(t, types.OrderStatusOpen, stopLossOrder.Status)
+	require.EqualValues(t, 0, len(k.GetTriggerOrders(ctx, types.TestTokenPair)))
+	require.EqualValues(t, 1, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// and matched in the auction of the next block
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, k)
+	result = newOrder(addrKeysSlice[0].Address, types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "8.5", "1.0"))
+	require.True(t, result.Code.IsOK())
+	EndBlocker(ctx, k)
+
+	stopLossOrder = k.GetOrder(ctx, stopLossOrderID)
+	require.EqualValues(t, types.OrderStatusFilled, stopLossOrder.Status)
+	require.EqualValues(t, 0, len(k.GetTriggerOrders(ctx, types.TestTokenPair)))
+	require.EqualValues(t, sdk.MustNewDecFromStr("8.5"), k.GetLastPrice(ctx, types.TestTokenPair))
+}
+
+func TestEndBlockerUntriggeredGTBOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	// the good-til-block stop-loss order is never triggered
+	handler := NewOrderHandler(k)
+	item := types.NewConditionalOrderItem(types.StopLossOrder, types.TestTokenPair, types.SellOrder, "9.0", "8.5", "1.0")
+	item.TimeInForce = types.TimeInForceGTB
+	item.ExpireHeight = startHeight + 2
+	result := handler(ctx, types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{item}))
+	require.True(t, result.Code.IsOK())
+	orderID := getOrderID(result)
+	EndBlocker(ctx, k)
+	require.EqualValues(t, []string{orderID}, k.GetExpireOrderIDs(ctx, startHeight+2))
+
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, k)
+	EndBlocker(ctx, k)
+	require.EqualValues(t, types.OrderStatusUntriggered, k.GetOrder(ctx, orderID).Status)
+
+	// it expires at the expire height, and the locked coins are refunded
+	ctx = ctx.WithBlockHeight(startHeight + 2)
+	BeginBlocker(ctx, k)
+	EndBlocker(ctx, k)
+	order := k.GetOrder(ctx, orderID)
+	require.EqualValues(t, types.OrderStatusUntriggeredExpired, order.Status)
+	require.True(t, order.RemainLocked.IsZero())
+	require.EqualValues(t, 0, len(k.GetTriggerOrders(ctx, types.TestTokenPair)))
+	acc := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[1].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("100"), acc.GetCoins().AmountOf(common.TestToken))
+}
+
+func TestEndBlockerCancelOrdersBreakingTradingRules(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	mapp.dexKeeper.SetParams(ctx, *dex.DefaultParams())
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(k)
+	newOrder := func(addr sdk.AccAddress, item types.OrderItem) string {
+		result := handler(ctx, types.NewMsgNewOrders(addr, []types.OrderItem{item}))
+		require.True(t, result.Code.IsOK())
+		return getOrderID(result)
+	}
+	// the sell order is partially filled, and remains less than the new min quantity
+	partialOrderID := newOrder(addrKeysSlice[1].Address,
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "12.0", "1.0"))
+	newOrder(addrKeysSlice[0].Address, types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "12.0", "0.7"))
+	EndBlocker(ctx, k)
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, k)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.3"), k.GetOrder(ctx, partialOrderID).RemainQuantity)
+
+	tooPreciseOrderID := newOrder(addrKeysSlice[0].Address,
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.95", "1.0"))
+	tooSmallOrderID := newOrder(addrKeysSlice[0].Address,
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.9", "0.2"))
+	stopLossOrderID := newOrder(addrKeysSlice[1].Address, types.NewConditionalOrderItem(types.StopLossOrder,
+		types.TestTokenPair, types.SellOrder, "8.5", "8.5", "1.05"))
+	fitOrderID := newOrder(addrKeysSlice[1].Address,
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "11.0", "1.0"))
+
+	// the owner edits the trading rules of the token pair
+	result := dex.NewHandler(mapp.dexKeeper)(ctx, dex.NewMsgEditTokenPair(tokenPair.Owner, types.TestTokenPair,
+		1, 1, sdk.MustNewDecFromStr("0.5")))
+	require.True(t, result.Code.IsOK())
+	EndBlocker(ctx, k)
+
+	for _, orderID := range []string{tooPreciseOrderID, tooSmallOrderID, stopLossOrderID} {
+		order := k.GetOrder(ctx, orderID)
+		require.True(t, order.Status == types.OrderStatusCancelled ||
+			order.Status == types.OrderStatusUntriggeredCancelled, orderID)
+		require.True(t, order.RemainLocked.IsZero())
+	}
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, fitOrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, partialOrderID).Status)
+	require.Empty(t, k.GetTriggerOrders(ctx, types.TestTokenPair))
+	require.Equal(t, []string{partialOrderID, fitOrderID}, k.GetSenderOpenOrderIDs(ctx, addrKeysSlice[1].Address))
+	require.Empty(t, mapp.dexKeeper.GetEditedTokenPairs(ctx))
+}
@@ -10,8 +10,10 @@ import (
 
 // GenesisState - all order state that must be provided at genesis
 type GenesisState struct {
-	Params     types.Params   `json:"params"`
-	OpenOrders []*types.Order `json:"open_orders"`
+	Params        types.Params   `json:"params"`
+	OpenOrders    []*types.Order `json:"open_orders"`
+	TriggerOrders []*types.Order `json:"trigger_orders"`
+	OrderRents    []OrderRent    `json:"order_rents"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -22,8 +24,55 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-// ValidateGenesis validates the slashing genesis parameters
+// ValidateGenesis validates the order genesis state. The index of the senders' open orders is rebuilt from
+// the orders by InitGenesis, so the orders should be unique, with senders, and in the status of their books
 func ValidateGenesis(data GenesisState) error {
+	orderIDs := make(map[string]bool, len(data.OpenOrders)+len(data.TriggerOrders))
+	validate := func(order *types.Order, status int64) error {
+		if order == nil {
+			return fmt.Errorf("nil order in genesis")
+		}
+		if orderIDs[order.OrderID] {
+			return fmt.Errorf("duplicate order %s in genesis", order.OrderID)
+		}
+		orderIDs[order.OrderID] = true
+		if order.Sender.Empty() {
+			return fmt.Errorf("order %s has no sender", order.OrderID)
+		}
+		if order.Status != status {
+			return fmt.Errorf("order %s has invalid status %d, expected %d", order.OrderID, order.Status, status)
+		}
+		return nil
+	}
+
+	for _, order := range data.OpenOrders {
+		if err := validate(order, types.OrderStatusOpen); err != nil {
+			return err
+		}
+	}
+	for _, order := range data.TriggerOrders {
+		if err := validate(order, types.OrderStatusUntriggered); err != nil {
+			return err
+		}
+	}
+
+	addrs := make(map[string]bool, len(data.OrderRents))
+	for _, rent := range data.OrderRents {
+		if rent.Address.Empty() {
+			return fmt.Errorf("order rent has no address")
+		}
+		if addrs[rent.Address.String()] {
+			return fmt.Errorf("duplicate order rent of %s in genesis", rent.Address)
+		}
+		addrs[rent.Address.String()] = true
+		if rent.Balance.Amount.IsNil() || !rent.Balance.IsValid() {
+			return fmt.Errorf("order rent of %s has invalid balance %s", rent.Address, rent.Balance)
+		}
+		if rent.OrderNum < 0 || rent.ChargedHeight < 0 {
+			return fmt.Errorf("order rent of %s has negative order num %d or charged height %d",
+				rent.Address, rent.OrderNum, rent.ChargedHeight)
+		}
+	}
 	return nil
 }
 
@@ -34,26 +83,50 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 
 	// reset open order& depth book
 	for _, order := range data.OpenOrders {
-		if order == nil {
-			panic("the nil pointer is not expected")
-		}
-		height := types.GetBlockHeightFromOrderID(order.OrderID)
-
-		futureHeight := height + data.Params.OrderExpireBlocks
-		futureExpireHeightList := keeper.GetExpireBlockHeight(ctx, futureHeight)
-		futureExpireHeightList = append(futureExpireHeightList, height)
-		keeper.SetExpireBlockHeight(ctx, futureHeight, futureExpireHeightList)
-
-		orderNum := keeper.GetBlockOrderNum(ctx, height)
-		keeper.SetBlockOrderNum(ctx, height, orderNum+1)
-		keeper.SetOrder(ctx, order.OrderID, order)
+		initOrder(ctx, keeper, order, data.Params.OrderExpireBlocks)
 
 		// update depth book and orderIDsMap in cache
 		keeper.InsertOrderIntoDepthBook(order)
 	}
-	if len(data.OpenOrders) > 0 {
+
+	// reset untriggered order& trigger book
+	for _, order := range data.TriggerOrders {
+		initOrder(ctx, keeper, order, data.Params.OrderExpireBlocks)
+		keeper.InsertOrderIntoTriggerBook(ctx, order)
+	}
+
+	if len(data.OpenOrders) > 0 || len(data.TriggerOrders) > 0 {
 		keeper.Cache2Disk(ctx)
 	}
+
+	for _, rent := range data.OrderRents {
+		keeper.SetOrderRent(ctx, rent)
+	}
+
+	// the orders above are indexed by SetOrder, so the index needs no building after the genesis
+	keeper.BuildSenderOrderIndex(ctx)
+}
+
+func initOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order, orderExpireBlocks int64) {
+	if order == nil {
+		panic("the nil pointer is not expected")
+	}
+	height := types.GetBlockHeightFromOrderID(order.OrderID)
+
+	futureHeight := height + orderExpireBlocks
+	futureExpireHeightList := keeper.GetExpireBlockHeight(ctx, futureHeight)
+	futureExpireHeightList = append(futureExpireHeightList, height)
+	keeper.SetExpireBlockHeight(ctx, futureHeight, futureExpireHeightList)
+
+	if order.TimeInForce == types.TimeInForceGTB {
+		expireHeight := height + order.OrderExpireBlocks
+		expireOrderIDs := keeper.GetExpireOrderIDs(ctx, expireHeight)
+		keeper.SetExpireOrderIDs(ctx, expireHeight, append(expireOrderIDs, order.OrderID))
+	}
+
+	orderNum := keeper.GetBlockOrderNum(ctx, height)
+	keeper.SetBlockOrderNum(ctx, height, orderNum+1)
+	keeper.SetOrder(ctx, order.OrderID, order)
 }
 
 // ExportGenesis writes the current store values
@@ -64,6 +137,7 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 	tokenPairs := keeper.GetDexKeeper().GetTokenPairs(ctx)
 
 	var openOrders []*types.Order
+	var triggerOrders []*types.Order
 	var num int64 = 1
 	for _, pair := range tokenPairs {
 		if pair == nil {
@@ -107,10 +181,15 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 			openOrders = append(openOrders, order)
 			num++
 		}
+
+		// get untriggered orders
+		triggerOrders = append(triggerOrders, keeper.GetTriggerOrders(ctx, product)...)
 	}
 
 	return GenesisState{
-		Params:     *params,
-		OpenOrders: openOrders,
+		Params:        *params,
+		OpenOrders:    openOrders,
+		TriggerOrders: triggerOrders,
+		OrderRents:    keeper.GetAllOrderRents(ctx),
 	}
 }
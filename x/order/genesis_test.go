@@ -1,6 +1,7 @@
 package order
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/okex/okchain/x/order/keeper"
 	"github.com/okex/okchain/x/order/types"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/types/time"
 )
 
@@ -17,6 +19,43 @@ func TestValidateGenesis(t *testing.T) {
 	genesisState := DefaultGenesisState()
 	err := ValidateGenesis(genesisState)
 	require.NoError(t, err)
+
+	order := types.MockOrder(types.FormatOrderID(10, 1), types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.Sender = sdk.AccAddress([]byte("order-genesis-sender"))
+	genesisState.OpenOrders = []*types.Order{order}
+	require.NoError(t, ValidateGenesis(genesisState))
+
+	// duplicate order
+	genesisState.OpenOrders = []*types.Order{order, order}
+	require.Error(t, ValidateGenesis(genesisState))
+
+	// open order in the trigger orders
+	genesisState.OpenOrders = nil
+	genesisState.TriggerOrders = []*types.Order{order}
+	require.Error(t, ValidateGenesis(genesisState))
+
+	// order without sender
+	genesisState.TriggerOrders = nil
+	order.Sender = nil
+	genesisState.OpenOrders = []*types.Order{order}
+	require.Error(t, ValidateGenesis(genesisState))
+
+	// order rents
+	genesisState.OpenOrders = nil
+	rent := types.NewOrderRent(sdk.AccAddress([]byte("order-genesis-sender")),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec()))
+	genesisState.OrderRents = []types.OrderRent{rent}
+	require.NoError(t, ValidateGenesis(genesisState))
+
+	// duplicate order rent
+	genesisState.OrderRents = []types.OrderRent{rent, rent}
+	require.Error(t, ValidateGenesis(genesisState))
+
+	// order rent without address or balance
+	genesisState.OrderRents = []types.OrderRent{types.NewOrderRent(nil, rent.Balance)}
+	require.Error(t, ValidateGenesis(genesisState))
+	genesisState.OrderRents = []types.OrderRent{types.NewOrderRent(rent.Address, sdk.DecCoin{})}
+	require.Error(t, ValidateGenesis(genesisState))
 }
 
 func TestExportGenesis(t *testing.T) {
@@ -48,9 +87,12 @@ func TestExportGenesis(t *testing.T) {
 
 	orders = append(orders, order1)
 
+	rents := []types.OrderRent{types.NewOrderRent(testInput.TestAddrs[1],
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec()))}
 	initGenesis := GenesisState{
 		Params:     params,
 		OpenOrders: orders,
+		OrderRents: rents,
 	}
 
 	InitGenesis(ctx, orderKeeper, initGenesis)
@@ -82,6 +124,7 @@ func TestExportGenesis(t *testing.T) {
 	exportGenesis := ExportGenesis(ctx, orderKeeper)
 	require.Equal(t, params, exportGenesis.Params)
 	require.Equal(t, order1, exportGenesis.OpenOrders[0])
+	require.Equal(t, rents, exportGenesis.OrderRents)
 
 	params.MaxDealsPerBlock = 1
 	params.FeePerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec())
@@ -118,6 +161,7 @@ func TestExportGenesis(t *testing.T) {
 	require.NoError(t, err)
 	InitGenesis(newCtx, newOrderKeeper, exportGenesis)
 	require.Equal(t, exportGenesis.Params, *newOrderKeeper.GetParams(newCtx))
+	require.Equal(t, rents, newOrderKeeper.GetAllOrderRents(newCtx))
 	// 0x11
 	require.Equal(t, order1, newOrderKeeper.GetOrder(newCtx, order1.OrderID))
 	require.Equal(t, order2, newOrderKeeper.GetOrder(newCtx, order2.OrderID))
@@ -147,4 +191,62 @@ func TestExportGenesis(t *testing.T) {
 	require.Equal(t, int64(2), newOrderKeeper.GetOpenOrderNum(newCtx))
 	// 0x20
 	require.Equal(t, int64(2), newOrderKeeper.GetStoreOrderNum(newCtx))
+	// 0x23
+	require.Equal(t, []string{order1.OrderID, order2.OrderID},
+		newOrderKeeper.GetSenderOpenOrderIDs(newCtx, testInput.TestAddrs[0]))
+}
+
+func TestGenesisSenderOrderIndex(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	orderKeeper := testInput.OrderKeeper
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.NoError(t, err)
+	product := fmt.Sprintf("%s_%s", tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol)
+
+	orderKeeper.ResetCache(ctx)
+	openOrder := types.NewOrder("txHash", testInput.TestAddrs[0], product, types.BuyOrder,
+		sdk.NewDec(2), sdk.NewDec(5), time.Now().Unix(), 5,
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1)))
+	require.NoError(t, orderKeeper.PlaceOrder(ctx, openOrder))
+	triggerOrder := types.NewOrder("txHash", testInput.TestAddrs[0], product, types.SellOrder,
+		sdk.NewDec(1), sdk.NewDec(5), time.Now().Unix(), 5,
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1)))
+	triggerOrder.Type = types.StopLossOrder
+	triggerPrice := sdk.NewDec(1)
+	triggerOrder.TriggerPrice = &triggerPrice
+	require.NoError(t, orderKeeper.PlaceTriggerOrder(ctx, triggerOrder))
+	orderKeeper.Cache2Disk(ctx)
+
+	exportGenesis := ExportGenesis(ctx, orderKeeper)
+	require.Len(t, exportGenesis.OpenOrders, 1)
+	require.Len(t, exportGenesis.TriggerOrders, 1)
+
+	newTestInput := keeper.CreateTestInput(t)
+	newCtx := newTestInput.Ctx
+	newOrderKeeper := newTestInput.OrderKeeper
+	err = newTestInput.DexKeeper.SaveTokenPair(newCtx, tokenPair)
+	require.NoError(t, err)
+	InitGenesis(newCtx, newOrderKeeper, exportGenesis)
+
+	orderIDs := []string{openOrder.OrderID, triggerOrder.OrderID}
+	require.Equal(t, orderIDs, newOrderKeeper.GetSenderOpenOrderIDs(newCtx, testInput.TestAddrs[0]))
+
+	// the open orders query serves the orders from the genesis
+	params := keeper.NewQueryOpenOrdersParams(testInput.TestAddrs[0], "", 1, 10)
+	bz, sdkErr := keeper.NewQuerier(newOrderKeeper)(newCtx, []string{types.QueryOpenOrders},
+		abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+	require.Nil(t, sdkErr)
+	var res struct {
+		Data struct {
+			Data []types.Order `json:"data"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &res))
+	require.Len(t, res.Data.Data, 2)
+	for i, order := range res.Data.Data {
+		require.Equal(t, orderIDs[i], order.OrderID)
+	}
 }
@@ -14,7 +14,9 @@ import (
 	"github.com/willf/bitset"
 
 	"github.com/okex/okchain/x/common/perf"
+	dex "github.com/okex/okchain/x/dex/types"
 	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/match"
 	"github.com/okex/okchain/x/order/types"
 )
 
@@ -24,6 +26,13 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	case types.MsgCancelOrders:
 		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgAmendOrders:
+		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit + params.NewOrderMsgGasUnit)
+	case types.MsgCancelAllOrders:
+		// the gas of the cancelled orders is consumed in handler, since the number is unknown until then
+		gas = params.CancelOrderMsgGasUnit
+	case types.MsgDepositOrderRent, types.MsgWithdrawOrderRent:
+		gas = params.CancelOrderMsgGasUnit
 	default:
 		gas = math.MaxUint64
 	}
@@ -39,14 +48,16 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 		// consume gas that msg required, it will panic if gas is insufficient
 		ctx.GasMeter().ConsumeGas(gas, storetypes.GasWriteCostFlatDesc)
 
+		gasMeter := ctx.GasMeter()
 		if ctx.IsCheckTx() {
 			return sdk.Result{}
 		} else {
 			// set an infinite gas meter and recovery it when return
-			gasMeter := ctx.GasMeter()
 			ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 			defer func() { ctx = ctx.WithGasMeter(gasMeter) }()
 		}
+		// the changes of the memory caches are kept only if the tx is delivered successfully
+		keeper.TrackCacheChanges(ctx)
 
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 		var handlerFun func() sdk.Result
@@ -63,6 +74,26 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgCancelOrders(ctx, keeper, msg, logger)
 			}
+		case types.MsgCancelAllOrders:
+			name = "handleMsgCancelAllOrders"
+			handlerFun = func() sdk.Result {
+				return handleMsgCancelAllOrders(ctx, keeper, msg, gasMeter, logger)
+			}
+		case types.MsgAmendOrders:
+			name = "handleMsgAmendOrders"
+			handlerFun = func() sdk.Result {
+				return handleMsgAmendOrders(ctx, keeper, msg, logger)
+			}
+		case types.MsgDepositOrderRent:
+			name = "handleMsgDepositOrderRent"
+			handlerFun = func() sdk.Result {
+				return handleMsgDepositOrderRent(ctx, keeper, msg, logger)
+			}
+		case types.MsgWithdrawOrderRent:
+			name = "handleMsgWithdrawOrderRent"
+			handlerFun = func() sdk.Result {
+				return handleMsgWithdrawOrderRent(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -89,6 +120,14 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 		return errors.Errorf("trading pair '%s' is delisting", msg.Product)
 	}
 
+	if err := checkTimeInForce(ctx, keeper, msg); err != nil {
+		return err
+	}
+
+	if msg.Type == types.MarketOrder {
+		return checkMarketOrderNewMsg(ctx, keeper, tokenPair, msg)
+	}
+
 	priceDigit := tokenPair.MaxPriceDigit
 	quantityDigit := tokenPair.MaxQuantityDigit
 	roundedPrice := msg.Price.RoundDecimal(priceDigit)
@@ -103,41 +142,143 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 	if msg.Quantity.LT(tokenPair.MinQuantity) {
 		return fmt.Errorf("quantity should be greater than %s", tokenPair.MinQuantity)
 	}
+
+	if msg.Type == types.StopLossOrder || msg.Type == types.TakeProfitOrder {
+		return checkConditionalOrderNewMsg(ctx, keeper, tokenPair, msg)
+	}
+	return nil
+}
+
+func checkConditionalOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, tokenPair *dex.TokenPair,
+	msg types.MsgNewOrder) error {
+	if !msg.TriggerPrice.RoundDecimal(tokenPair.MaxPriceDigit).Equal(msg.TriggerPrice) {
+		return fmt.Errorf("trigger price(%v) over accuracy(%d)", msg.TriggerPrice, tokenPair.MaxPriceDigit)
+	}
+
+	order := types.Order{Side: msg.Side, Type: msg.Type, TriggerPrice: &msg.TriggerPrice}
+	if lastPrice := keeper.GetLastPrice(ctx, msg.Product); order.IsTriggered(lastPrice) {
+		return fmt.Errorf("trigger price(%v) has been crossed by the last price(%v)", msg.TriggerPrice, lastPrice)
+	}
+	return nil
+}
+
+func checkMarketOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, tokenPair *dex.TokenPair,
+	msg types.MsgNewOrder) error {
+	// the amount of quote token to spend is not limited by the quantity digit
+	if msg.Side == types.SellOrder && !msg.Quantity.RoundDecimal(tokenPair.MaxQuantityDigit).Equal(msg.Quantity) {
+		return fmt.Errorf("quantity(%v) over accuracy(%d)", msg.Quantity, tokenPair.MaxQuantityDigit)
+	}
+
+	price, quantity := getMarketOrderPriceAndQuantity(ctx, keeper, tokenPair, msg)
+	if !price.IsPositive() {
+		return fmt.Errorf("no valid price for market order of %s", msg.Product)
+	}
+	if !quantity.IsPositive() || quantity.LT(tokenPair.MinQuantity) {
+		return fmt.Errorf("quantity should be greater than %s", tokenPair.MinQuantity)
+	}
+	return nil
+}
+
+// checkTimeInForce: check the expire height of good-til-block order, and reject the post-only order which
+// would match on entry
+func checkTimeInForce(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrder) error {
+	switch msg.TimeInForce {
+	case types.TimeInForceGTB:
+		maxExpireHeight := ctx.BlockHeight() + keeper.GetParams(ctx).OrderExpireBlocks
+		if msg.ExpireHeight <= ctx.BlockHeight() || msg.ExpireHeight > maxExpireHeight {
+			return fmt.Errorf("expire height(%d) should be in (%d, %d]",
+				msg.ExpireHeight, ctx.BlockHeight(), maxExpireHeight)
+		}
+	case types.TimeInForcePostOnly:
+		if isCrossedOnEntry(keeper.GetDepthBookCopy(msg.Product), msg.Side, msg.Price) {
+			return fmt.Errorf("post-only order would match on entry at price %s", msg.Price)
+		}
+	}
 	return nil
 }
 
+// isCrossedOnEntry returns true if the price reaches the best price of the opposite side in depth book
+func isCrossedOnEntry(book *types.DepthBook, side string, price sdk.Dec) bool {
+	for _, item := range book.Items {
+		if side == types.BuyOrder && item.SellQuantity.IsPositive() && item.Price.LTE(price) {
+			return true
+		}
+		if side == types.SellOrder && item.BuyQuantity.IsPositive() && item.Price.GTE(price) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMarketOrderPriceAndQuantity returns the protection price and the quantity of base token of the market order
+func getMarketOrderPriceAndQuantity(ctx sdk.Context, k keeper.Keeper, tokenPair *dex.TokenPair,
+	msg types.MsgNewOrder) (sdk.Dec, sdk.Dec) {
+	return types.GetMarketOrderPriceAndQuantity(msg.Side, msg.Quantity, k.GetLastPrice(ctx, msg.Product),
+		k.GetParams(ctx).MarketOrderSlippage, tokenPair.MaxPriceDigit, tokenPair.MaxQuantityDigit)
+}
+
 func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ratio string) *types.Order {
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
+	if feeParams.IsOrderRentEnabled() {
+		// the rent is paid per block from the prepaid balance instead of the fee locked
+		feePerBlockAmount = sdk.ZeroDec()
+	}
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+
+	price, quantity := msg.Price, msg.Quantity
+	if msg.Type == types.MarketOrder {
+		// market order rests in the depth book at the protection price until matched
+		if tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product); tokenPair != nil {
+			price, quantity = getMarketOrderPriceAndQuantity(ctx, k, tokenPair, msg)
+		}
+	}
+
+	order := types.NewOrder(
 		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
 		msg.Sender,
 		msg.Product,
 		msg.Side,
-		msg.Price,
-		msg.Quantity,
+		price,
+		quantity,
 		ctx.BlockHeader().Time.Unix(),
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.Type = msg.Type
+	order.TimeInForce = msg.TimeInForce
+	if feeParams.IsOrderRentEnabled() {
+		order.RecordOrderRent(feeParams.OrderRentPerBlock)
+	}
+	if order.IsConditionalOrder() {
+		triggerPrice := msg.TriggerPrice
+		order.TriggerPrice = &triggerPrice
+	}
+	if msg.TimeInForce == types.TimeInForceGTB && msg.ExpireHeight > ctx.BlockHeight() &&
+		msg.ExpireHeight-ctx.BlockHeight() < order.OrderExpireBlocks {
+		// the fee is locked for the blocks until the expire height
+		order.OrderExpireBlocks = msg.ExpireHeight - ctx.BlockHeight()
+	}
+	return order
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
-	item types.OrderItem, ratio string, logger log.Logger) (types.OrderResult, sdk.CacheMultiStore, error) {
+	item types.OrderItem, ratio string, logger log.Logger) (types.OrderResult, error) {
 
-	cacheItem := ctx.MultiStore().CacheMultiStore()
-	ctxItem := ctx.WithMultiStore(cacheItem)
 	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
+		Sender:       sender,
+		Product:      item.Product,
+		Side:         item.Side,
+		Price:        item.Price,
+		Quantity:     item.Quantity,
+		Type:         item.Type,
+		TimeInForce:  item.TimeInForce,
+		ExpireHeight: item.ExpireHeight,
+		TriggerPrice: item.TriggerPrice,
 	}
-	order := getOrderFromMsg(ctxItem, k, msg, ratio)
+	order := getOrderFromMsg(ctx, k, msg, ratio)
 	code := sdk.CodeOK
-	err := checkOrderNewMsg(ctxItem, k, msg)
+	err := checkOrderNewMsg(ctx, k, msg)
 
 	if err != nil {
 		code = sdk.CodeUnknownRequest
@@ -145,8 +286,17 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 		if k.IsProductLocked(ctx, msg.Product) {
 			code = sdk.CodeInternal
 			err = fmt.Errorf("the trading pair (%s) is locked, please retry later", order.Product)
-		} else if err = k.PlaceOrder(ctxItem, order); err != nil {
+		} else if order.IsConditionalOrder() {
+			// conditional order waits in the trigger book until the last price crosses the trigger price
+			if err = k.PlaceTriggerOrder(ctx, order); err != nil {
+				code = sdk.CodeInsufficientCoins
+			}
+		} else if err = k.PlaceOrder(ctx, order); err != nil {
 			code = sdk.CodeInsufficientCoins
+		} else {
+			// the orders of continuous auction products are matched as soon as placed
+			tokenPair := k.GetDexKeeper().GetTokenPair(ctx, order.Product)
+			match.GetEngine(tokenPair.GetAuctionType()).MatchOrder(ctx, k, order)
 		}
 	}
 
@@ -168,7 +318,7 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 		res.Message = err.Error()
 	}
 
-	return res, cacheItem, err
+	return res, err
 }
 
 func handleMsgNewOrders(ctx sdk.Context, k Keeper, msg types.MsgNewOrders,
@@ -180,16 +330,33 @@ func handleMsgNewOrders(ctx sdk.Context, k Keeper, msg types.MsgNewOrders,
 		ratio = "0.8"
 	}
 
+	batchCtx, writeBatch, discardBatch := ctx, func() {}, func() {}
+	if msg.Atomic {
+		// all the items and their matches are done on one cache context, which is written only if all succeed
+		batchCtx, writeBatch, discardBatch = k.CacheContext(ctx)
+	}
+
 	rs := make([]types.OrderResult, 0, len(msg.OrderItems))
 	var handlerResult bitset.BitSet
 	for idx, item := range msg.OrderItems {
-		res, cacheItem, err := handleNewOrder(ctx, k, msg.Sender, item, ratio, logger)
-		if err == nil {
-			cacheItem.Write()
+		ctxItem, writeCache, discardCache := k.CacheContext(batchCtx)
+		res, err := handleNewOrder(ctxItem, k, msg.Sender, item, ratio, logger)
+		if err != nil {
+			discardCache()
+			if msg.Atomic {
+				discardBatch()
+				return sdk.Result{
+					Code: res.Code,
+					Log:  fmt.Sprintf("order item %d failed: %s", idx, res.Message),
+				}
+			}
+		} else {
+			writeCache()
 			handlerResult.Set(uint(idx))
 		}
 		rs = append(rs, res)
 	}
+	writeBatch()
 	rss, err := json.Marshal(&rs)
 	if err != nil {
 		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
@@ -216,11 +383,15 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 
 	for _, item := range msg.OrderItems {
 		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
+			Sender:       msg.Sender,
+			Product:      item.Product,
+			Side:         item.Side,
+			Price:        item.Price,
+			Quantity:     item.Quantity,
+			Type:         item.Type,
+			TimeInForce:  item.TimeInForce,
+			ExpireHeight: item.ExpireHeight,
+			TriggerPrice: item.TriggerPrice,
 		}
 		err := checkOrderNewMsg(ctx, k, msg)
 		if err != nil {
@@ -250,11 +421,8 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 
 }
 
-func handleCancelOrder(context sdk.Context, k Keeper, sender sdk.AccAddress, orderID string, logger log.Logger) (
-	types.OrderResult, sdk.CacheMultiStore) {
-
-	cacheItem := context.MultiStore().CacheMultiStore()
-	ctx := context.WithMultiStore(cacheItem)
+func handleCancelOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress, orderID string,
+	logger log.Logger) types.OrderResult {
 
 	// Check order
 	msg := MsgCancelOrder{
@@ -279,7 +447,7 @@ func handleCancelOrder(context sdk.Context, k Keeper, sender sdk.AccAddress, ord
 		OrderID: orderID,
 	}
 
-	return cancelRes, cacheItem
+	return cancelRes
 }
 
 func handleMsgCancelOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelOrders, logger log.Logger) sdk.Result {
@@ -287,9 +455,10 @@ func handleMsgCancelOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelOrders,
 	var handlerResult bitset.BitSet
 	for idx, orderID := range msg.OrderIDs {
 
-		res, cacheItem := handleCancelOrder(ctx, k, msg.Sender, orderID, logger)
+		ctxItem, writeCache, _ := k.CacheContext(ctx)
+		res := handleCancelOrder(ctxItem, k, msg.Sender, orderID, logger)
 		cancelRes = append(cancelRes, res)
-		cacheItem.Write()
+		writeCache()
 		if res.Code == sdk.CodeOK {
 			handlerResult.Set(uint(idx))
 		}
@@ -320,6 +489,32 @@ func handleMsgCancelOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelOrders,
 	}
 }
 
+// getCancelAllOrderIDs returns the IDs of the sender's orders which are filtered by the product and side of the msg
+func getCancelAllOrderIDs(ctx sdk.Context, k keeper.Keeper, msg types.MsgCancelAllOrders) []string {
+	var orderIDs []string
+	for _, orderID := range k.GetSenderOpenOrderIDs(ctx, msg.Sender) {
+		if order := k.GetOrder(ctx, orderID); order != nil && msg.IsMatched(order) {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	return orderIDs
+}
+
+func handleMsgCancelAllOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelAllOrders, gasMeter sdk.GasMeter,
+	logger log.Logger) sdk.Result {
+	orderIDs := getCancelAllOrderIDs(ctx, k, msg)
+	// consume gas for each order to be cancelled, it will panic if gas is insufficient
+	gasMeter.ConsumeGas(uint64(len(orderIDs))*k.GetParams(ctx).CancelOrderMsgGasUnit,
+		storetypes.GasWriteCostFlatDesc)
+
+	res := handleMsgCancelOrders(ctx, k, types.NewMsgCancelOrders(msg.Sender, orderIDs), logger)
+	if res.IsOK() {
+		// backend builds the cancel transactions from the order IDs along with the handler result
+		k.AddTxHandlerMsgOrderIDs(orderIDs)
+	}
+	return res
+}
+
 func validateCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelOrder) sdk.Result {
 	order := keeper.GetOrder(ctx, msg.OrderID)
 
@@ -330,7 +525,7 @@ func validateCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCan
 			Log:  fmt.Sprintf("order(%s) does not exist or already closed", msg.OrderID),
 		}
 	}
-	if order.Status != types.OrderStatusOpen {
+	if order.Status != types.OrderStatusOpen && order.Status != types.OrderStatusUntriggered {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
 			Log:  fmt.Sprintf("cannot cancel order with status(%d)", order.Status),
@@ -366,3 +561,181 @@ func ValidateMsgCancelOrders(ctx sdk.Context, keeper keeper.Keeper, msg types.Ms
 
 	return sdk.Result{}
 }
+
+// getAmendMsgNewOrder returns the new order msg which replaces the old order with the new price and quantity
+func getAmendMsgNewOrder(order *types.Order, item types.AmendOrderItem) MsgNewOrder {
+	msg := MsgNewOrder{
+		Sender:      order.Sender,
+		Product:     order.Product,
+		Side:        order.Side,
+		Price:       item.Price,
+		Quantity:    item.Quantity,
+		Type:        order.Type,
+		TimeInForce: order.TimeInForce,
+	}
+	if order.TriggerPrice != nil {
+		msg.TriggerPrice = *order.TriggerPrice
+	}
+	if order.TimeInForce == types.TimeInForceGTB {
+		// the new order expires at the same height as the old one
+		msg.ExpireHeight = types.GetBlockHeightFromOrderID(order.OrderID) + order.OrderExpireBlocks
+	}
+	return msg
+}
+
+func validateAmendOrder(ctx sdk.Context, k keeper.Keeper, sender sdk.AccAddress,
+	item types.AmendOrderItem) sdk.Result {
+	res := validateCancelOrder(ctx, k, MsgCancelOrder{Sender: sender, OrderID: item.OrderID})
+	if !res.IsOK() {
+		return res
+	}
+
+	order := k.GetOrder(ctx, item.OrderID)
+	if order.IsMarketOrder() {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  fmt.Sprintf("cannot amend market order(%s)", item.OrderID),
+		}
+	}
+	if err := checkOrderNewMsg(ctx, k, getAmendMsgNewOrder(order, item)); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  err.Error(),
+		}
+	}
+	return sdk.Result{}
+}
+
+func handleAmendOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress, item types.AmendOrderItem,
+	ratio string, logger log.Logger) (types.OrderResult, error) {
+
+	res := types.OrderResult{OrderID: item.OrderID}
+	validateResult := validateAmendOrder(ctx, k, sender, item)
+	if !validateResult.IsOK() {
+		res.Code = validateResult.Code
+		res.Message = validateResult.Log
+		return res, errors.New(validateResult.Log)
+	}
+
+	oldOrder := k.GetOrder(ctx, item.OrderID)
+	newOrder := getOrderFromMsg(ctx, k, getAmendMsgNewOrder(oldOrder, item), ratio)
+	fee, err := k.AmendOrder(ctx, oldOrder, newOrder)
+	if err != nil {
+		res.Code = sdk.CodeInsufficientCoins
+		res.Message = err.Error()
+		return res, err
+	}
+
+	if !newOrder.IsConditionalOrder() {
+		// the orders of continuous auction products are matched as soon as placed
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, newOrder.Product)
+		match.GetEngine(tokenPair.GetAuctionType()).MatchOrder(ctx, k, newOrder)
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Sender:%s,ID:%s,Price:%s,Quantity:%s>\n"+
+		"    result<The User have amended an order {ID:%s} to {ID:%s}, cancel fee:%s >\n",
+		ctx.BlockHeight(), "handleMsgAmendOrder",
+		sender, item.OrderID, item.Price.String(), item.Quantity.String(),
+		item.OrderID, newOrder.OrderID, fee.String()))
+
+	res.Message = newOrder.OrderID
+	return res, nil
+}
+
+func handleMsgAmendOrders(ctx sdk.Context, k Keeper, msg types.MsgAmendOrders, logger log.Logger) sdk.Result {
+	ratio := "1"
+	if len(msg.AmendItems) > 1 {
+		ratio = "0.8"
+	}
+
+	amendRes := make([]types.OrderResult, 0, len(msg.AmendItems))
+	var handlerResult bitset.BitSet
+	for idx, item := range msg.AmendItems {
+		ctxItem, writeCache, discardCache := k.CacheContext(ctx)
+		res, err := handleAmendOrder(ctxItem, k, msg.Sender, item, ratio, logger)
+		if err == nil {
+			writeCache()
+			handlerResult.Set(uint(idx))
+		} else {
+			discardCache()
+		}
+		amendRes = append(amendRes, res)
+	}
+	rss, err := json.Marshal(&amendRes)
+	if err != nil {
+		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
+	}
+
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("orders", string(rss)))
+	ctx.EventManager().EmitEvent(event)
+
+	if handlerResult.None() {
+		return sdk.Result{Code: sdk.CodeInternal}
+	}
+
+	k.AddTxHandlerMsgResult(handlerResult)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// ValidateMsgAmendOrders validates whether the msg of amendOrders is valid.
+func ValidateMsgAmendOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgAmendOrders) sdk.Result {
+	for _, item := range msg.AmendItems {
+		res := validateAmendOrder(ctx, k, msg.Sender, item)
+		if !res.IsOK() {
+			return res
+		}
+	}
+
+	return sdk.Result{}
+}
+
+// ValidateMsgCancelAllOrders validates whether the msg of cancelAllOrders is valid.
+func ValidateMsgCancelAllOrders(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelAllOrders) sdk.Result {
+	if msg.Product != "" && keeper.GetDexKeeper().GetTokenPair(ctx, msg.Product) == nil {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  fmt.Sprintf("trading pair '%s' does not exist", msg.Product),
+		}
+	}
+	if len(getCancelAllOrderIDs(ctx, keeper, msg)) == 0 {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  "no open orders to cancel",
+		}
+	}
+	return sdk.Result{}
+}
+
+func handleMsgDepositOrderRent(ctx sdk.Context, k Keeper, msg types.MsgDepositOrderRent,
+	logger log.Logger) sdk.Result {
+	if err := k.DepositOrderRent(ctx, msg.Sender, msg.Amount); err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Sender:%s,Amount:%s>\n",
+		ctx.BlockHeight(), "handleMsgDepositOrderRent", msg.Sender, msg.Amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName)))
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgWithdrawOrderRent(ctx sdk.Context, k Keeper, msg types.MsgWithdrawOrderRent,
+	logger log.Logger) sdk.Result {
+	if err := k.WithdrawOrderRent(ctx, msg.Sender, msg.Amount); err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Sender:%s,Amount:%s>\n",
+		ctx.BlockHeight(), "handleMsgWithdrawOrderRent", msg.Sender, msg.Amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName)))
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -515,6 +515,103 @@ func TestHandleMsgMultiNewOrder(t *testing.T) {
 	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
 }
 
+func TestHandleMsgNewOrdersAtomic(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+
+	// the second order locks more coins than the balance, the whole batch is rejected
+	orderItems := []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "10.0"),
+	}
+	msg := types.NewMsgNewOrdersAtomic(addrKeysSlice[0].Address, orderItems)
+	require.Equal(t, CalculateGas(types.NewMsgNewOrders(addrKeysSlice[0].Address, orderItems), &feeParams),
+		CalculateGas(msg, &feeParams))
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInsufficientCoins, result.Code)
+	require.EqualValues(t, 0, keeper.GetBlockOrderNum(ctx, 10))
+	require.Equal(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.Equal(t, 0, len(keeper.GetTxHandlerMsgResult()))
+	acc := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("100"), acc.GetCoins().AmountOf(common.NativeToken))
+
+	// the product is locked, the whole batch is rejected
+	orderItems[1] = types.NewOrderItem(types.TestTokenPair, types.SellOrder, "20.0", "1.0")
+	msg = types.NewMsgNewOrdersAtomic(addrKeysSlice[0].Address, orderItems)
+	keeper.SetProductLock(ctx, types.TestTokenPair, &types.ProductLock{})
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, msg).Code)
+	require.EqualValues(t, 0, keeper.GetBlockOrderNum(ctx, 10))
+	keeper.UnlockProduct(ctx, types.TestTokenPair)
+
+	// all the orders are placed
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderIDs := getOrderIDList(result)
+	require.Equal(t, 2, len(orderIDs))
+	for _, orderID := range orderIDs {
+		require.NotNil(t, keeper.GetOrder(ctx, orderID))
+	}
+	require.EqualValues(t, 2, keeper.GetBlockOrderNum(ctx, 10))
+	handlerResults := keeper.GetTxHandlerMsgResult()
+	require.Equal(t, 1, len(handlerResults))
+	require.EqualValues(t, 2, handlerResults[0].Count())
+}
+
+func TestHandleMsgNewOrdersAtomicContinuous(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.AuctionType = dex.AuctionTypeContinuous
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	})
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	sellOrderID := getOrderID(result)
+
+	// the first order is matched with the sell order as soon as placed, but the last order locks more coins than
+	// the balance, so the whole batch is rejected along with the match
+	buyer := addrKeysSlice[1].Address
+	coins := mapp.AccountKeeper.GetAccount(ctx, buyer).GetCoins()
+	msg = types.NewMsgNewOrdersAtomic(buyer, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "10.0"),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInsufficientCoins, result.Code)
+	require.Nil(t, keeper.GetOrder(ctx, types.FormatOrderID(10, 2)))
+	require.Nil(t, keeper.GetOrder(ctx, types.FormatOrderID(10, 3)))
+	require.EqualValues(t, 1, keeper.GetBlockOrderNum(ctx, 10))
+	require.Equal(t, 0, len(mapp.tokenKeeper.GetLockedCoins(ctx, buyer)))
+	require.Equal(t, coins, mapp.AccountKeeper.GetAccount(ctx, buyer).GetCoins())
+
+	sellOrder := keeper.GetOrder(ctx, sellOrderID)
+	require.EqualValues(t, types.OrderStatusOpen, sellOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), sellOrder.RemainQuantity)
+	require.Equal(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.Equal(t, tokenPair.InitPrice, keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.Equal(t, 1, len(keeper.GetTxHandlerMsgResult()))
+}
+
 func TestHandleMsgMultiCancelOrder(t *testing.T) {
 	mapp, addrKeysSlice := getMockApp(t, 1)
 	keeper := mapp.orderKeeper
@@ -847,6 +944,233 @@ func TestHandleMsgCancelOrder(t *testing.T) {
 	require.EqualValues(t, 0, len(orderIDs))
 }
 
+func TestHandleMsgCancelAllOrders(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	handler := NewOrderHandler(keeper)
+	orderItems := []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+		types.NewConditionalOrderItem(types.StopLossOrder, types.TestTokenPair, types.SellOrder, "8.0", "7.5", "1.0"),
+	}
+	result := handler(ctx, types.NewMsgNewOrders(addrKeysSlice[0].Address, orderItems))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderIDs := getOrderIDList(result)
+	result = handler(ctx, types.NewMsgNewOrder(addrKeysSlice[1].Address, types.TestTokenPair, types.SellOrder,
+		"10.0", "1.0"))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	otherOrderID := getOrderID(result)
+	require.EqualValues(t, orderIDs, keeper.GetSenderOpenOrderIDs(ctx, addrKeysSlice[0].Address))
+
+	// non-exist product
+	msg := types.NewMsgCancelAllOrders(addrKeysSlice[0].Address, "nonexist_"+common.NativeToken, "")
+	require.False(t, ValidateMsgCancelAllOrders(ctx, keeper, msg).IsOK())
+
+	// cancel all the sell orders, and consume gas for each cancelled order
+	msg = types.NewMsgCancelAllOrders(addrKeysSlice[0].Address, types.TestTokenPair, types.SellOrder)
+	require.True(t, ValidateMsgCancelAllOrders(ctx, keeper, msg).IsOK())
+	keeper.GetTxHandlerMsgResult()
+	gasMeter := sdk.NewInfiniteGasMeter()
+	result = handler(ctx.WithGasMeter(gasMeter), msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, 3*feeParams.CancelOrderMsgGasUnit, gasMeter.GasConsumed())
+	// the cancelled orders are recorded for backend
+	require.EqualValues(t, 1, len(keeper.GetTxHandlerMsgResult()))
+	require.EqualValues(t, [][]string{{orderIDs[0], orderIDs[2]}}, keeper.GetTxHandlerMsgOrderIDs())
+
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orderIDs[0]).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orderIDs[1]).Status)
+	require.EqualValues(t, types.OrderStatusUntriggeredCancelled, keeper.GetOrder(ctx, orderIDs[2]).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, otherOrderID).Status)
+	require.EqualValues(t, []string{orderIDs[1]}, keeper.GetSenderOpenOrderIDs(ctx, addrKeysSlice[0].Address))
+
+	// no sell orders to cancel
+	require.False(t, ValidateMsgCancelAllOrders(ctx, keeper, msg).IsOK())
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, msg).Code)
+
+	// cancel all the orders of all products
+	msg = types.NewMsgCancelAllOrders(addrKeysSlice[0].Address, "", "")
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orderIDs[1]).Status)
+	require.EqualValues(t, 0, len(keeper.GetSenderOpenOrderIDs(ctx, addrKeysSlice[0].Address)))
+	require.EqualValues(t, 1, len(keeper.GetSenderOpenOrderIDs(ctx, addrKeysSlice[1].Address)))
+}
+
+func TestHandleMsgAmendOrders(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	mapp.dexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	handler := NewOrderHandler(keeper)
+	result := handler(ctx, types.NewMsgNewOrder(addrKeysSlice[0].Address, types.TestTokenPair, types.SellOrder,
+		"10.0", "1.0"))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	oldOrderID := getOrderID(result)
+	EndBlocker(ctx, keeper)
+
+	ctx = ctx.WithBlockHeight(startHeight + 2)
+	BeginBlocker(ctx, keeper)
+
+	// not the owner of the order
+	amendMsg := types.NewMsgAmendOrders(addrKeysSlice[1].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(oldOrderID, "11.0", "2.0")})
+	require.False(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, amendMsg).Code)
+
+	// insufficient coins, the old order is left untouched
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(oldOrderID, "11.0", "101.0")})
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, amendMsg).Code)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, oldOrderID).Status)
+	key := types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("10.0"), types.SellOrder)
+	require.EqualValues(t, []string{oldOrderID}, keeper.GetProductPriceOrderIDs(key))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"),
+		keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].SellQuantity)
+	require.EqualValues(t, 0, len(keeper.GetDiskCache().GetClosedOrderIDs()))
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+	require.True(t, mapp.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins().IsZero())
+
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(oldOrderID, "11.0", "2.0")})
+	require.True(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	result = handler(ctx, amendMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderRes := parseOrderResult(result)
+	newOrderID := orderRes[0].Message
+
+	// the old order is cancelled with the fee cost in 2 blocks
+	oldOrder := keeper.GetOrder(ctx, oldOrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, oldOrder.Status)
+	require.EqualValues(t, "0.00000200"+common.NativeToken,
+		oldOrder.GetExtraInfoWithKey(types.OrderExtraInfoKeyCancelFee))
+	require.EqualValues(t, newOrderID, oldOrder.GetExtraInfoWithKey(types.OrderExtraInfoKeyAmendTo))
+	feeCollector := mapp.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.EqualValues(t, "0.00000200"+common.NativeToken, feeCollector.GetCoins().String())
+
+	newOrder := keeper.GetOrder(ctx, newOrderID)
+	require.EqualValues(t, types.OrderStatusOpen, newOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), newOrder.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), newOrder.RemainLocked)
+	require.EqualValues(t, oldOrderID, newOrder.GetExtraInfoWithKey(types.OrderExtraInfoKeyAmendFrom))
+	acc0 := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("98"), acc0.GetCoins().AmountOf(common.TestToken))
+
+	// check depth book
+	require.EqualValues(t, 0, len(keeper.GetProductPriceOrderIDs(key)))
+	key = types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("11.0"), types.SellOrder)
+	require.EqualValues(t, []string{newOrderID}, keeper.GetProductPriceOrderIDs(key))
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), depthBook.Items[0].SellQuantity)
+
+	// the cancelled order cannot be amended again
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, amendMsg).Code)
+}
+
+func TestHandleMsgOrderRent(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	feeParams.OrderRentPerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.1"))
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	sender := addrKeysSlice[0].Address
+	handler := NewOrderHandler(keeper)
+	newOrderMsg := types.NewMsgNewOrder(sender, types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	depositMsg := types.NewMsgDepositOrderRent(sender,
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.15")))
+	require.EqualValues(t, feeParams.CancelOrderMsgGasUnit, CalculateGas(depositMsg, &feeParams))
+
+	// no order rent balance
+	require.False(t, ValidateMsgNewOrders(ctx, keeper, newOrderMsg).IsOK())
+	require.EqualValues(t, sdk.CodeInternal, handler(ctx, newOrderMsg).Code)
+
+	// deposit in the denom other than the rent one
+	result := handler(ctx, types.NewMsgDepositOrderRent(sender, sdk.NewDecCoinFromDec(common.TestToken, sdk.OneDec())))
+	require.False(t, result.IsOK())
+
+	result = handler(ctx, depositMsg)
+	require.True(t, result.IsOK())
+	result = handler(ctx, newOrderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+
+	// the order locks no fee
+	order := keeper.GetOrder(ctx, getOrderID(result))
+	require.True(t, order.IsRentOrder())
+	require.True(t, order.FeePerBlock.IsZero())
+	acc := mapp.AccountKeeper.GetAccount(ctx, sender)
+	require.EqualValues(t, sdk.MustNewDecFromStr("89.85"), acc.GetCoins().AmountOf(common.NativeToken))
+
+	// the rent is charged at the end of the block
+	EndBlocker(ctx, keeper)
+	rent, found := keeper.GetOrderRent(ctx, sender)
+	require.True(t, found)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.05"), rent.Balance.Amount)
+	feeCollector := mapp.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.EqualValues(t, "0.10000000"+common.NativeToken, feeCollector.GetCoins().String())
+
+	// withdraw more than the balance
+	result = handler(ctx, types.NewMsgWithdrawOrderRent(sender,
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.1"))))
+	require.False(t, result.IsOK())
+
+	// the balance left should afford the rent of the resting order for one block
+	ctx = ctx.WithBlockHeight(startHeight + 1)
+	BeginBlocker(ctx, keeper)
+	result = handler(ctx, types.NewMsgWithdrawOrderRent(sender, rent.Balance))
+	require.False(t, result.IsOK())
+
+	// the balance can't afford the rent of the last block, the order is cancelled before withdrawing all
+	ctx = ctx.WithBlockHeight(startHeight + 2)
+	BeginBlocker(ctx, keeper)
+	result = handler(ctx, types.NewMsgWithdrawOrderRent(sender, rent.Balance))
+	require.True(t, result.IsOK())
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, order.OrderID).Status)
+	EndBlocker(ctx, keeper)
+	acc = mapp.AccountKeeper.GetAccount(ctx, sender)
+	require.EqualValues(t, sdk.MustNewDecFromStr("99.9"), acc.GetCoins().AmountOf(common.NativeToken))
+}
+
 func TestFeesTable(t *testing.T) {
 	//test xxb_okt
 	orders0 := []*types.Order{
@@ -0,0 +1,299 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/okex/okchain/x/order/types"
+)
+
+// cacheJournal records the values of the memory caches before they are changed, so that the changes can be rolled
+// back along with the store changes made by the same tx or cache context when those are discarded
+type cacheJournal struct {
+	txHash    string // hash of the tx delivered, empty for the journal of a cache context
+	markerKey []byte // transient key written along with the tx, nil for the journal of a cache context
+
+	depthBooks map[string]*depthBookRecord
+	orderIDs   map[string]*orderIDsRecord
+	lastPrices map[string]*sdk.Dec // nil if the last price was not cached
+
+	storeOrderNum     int64
+	openNum           int64
+	closedOrderIDsLen int
+
+	updatedOrderIDsLen      int
+	txHandlerMsgResultLen   int
+	txHandlerMsgOrderIDsLen int
+	blockMatchResult        *types.BlockMatchResult
+	cancelNum               int64
+	expireNum               int64
+	partialFillNum          int64
+	fullFillNum             int64
+}
+
+type depthBookRecord struct {
+	book    *types.DepthBook // nil if the depth book did not exist
+	updated bool
+	isNew   bool
+}
+
+type orderIDsRecord struct {
+	orderIDs []string
+	exists   bool
+	updated  bool
+}
+
+// pushJournal begins recording the changes of the memory caches in a new journal
+func (c *DiskCache) pushJournal(cache *Cache, txHash string, markerKey []byte) *cacheJournal {
+	journal := &cacheJournal{
+		txHash:     txHash,
+		markerKey:  markerKey,
+		depthBooks: make(map[string]*depthBookRecord),
+		orderIDs:   make(map[string]*orderIDsRecord),
+		lastPrices: make(map[string]*sdk.Dec),
+
+		storeOrderNum:     c.storeOrderNum,
+		openNum:           c.openNum,
+		closedOrderIDsLen: len(c.closedOrderIDs),
+
+		updatedOrderIDsLen:      len(cache.updatedOrderIDs),
+		txHandlerMsgResultLen:   len(cache.handlerTxMsgResult),
+		txHandlerMsgOrderIDsLen: len(cache.handlerTxMsgOrderIDs),
+		blockMatchResult:        copyBlockMatchResult(cache.blockMatchResult),
+		cancelNum:               cache.cancelNum,
+		expireNum:               cache.expireNum,
+		partialFillNum:          cache.partialFillNum,
+		fullFillNum:             cache.fullFillNum,
+	}
+	c.journals = append(c.journals, journal)
+	return journal
+}
+
+// popJournal stops recording the changes in the innermost journal. The changes are kept in the outer journal if
+// there is one, otherwise they are final
+func (c *DiskCache) popJournal(cache *Cache, keep bool) {
+	journal := c.journals[len(c.journals)-1]
+	c.journals = c.journals[:len(c.journals)-1]
+	if !keep {
+		c.rollback(cache, journal)
+		return
+	}
+	if parent := c.journal(); parent != nil {
+		parent.merge(journal)
+	}
+}
+
+// journal returns the innermost journal, nil if the changes are not recorded
+func (c *DiskCache) journal() *cacheJournal {
+	if len(c.journals) == 0 {
+		return nil
+	}
+	return c.journals[len(c.journals)-1]
+}
+
+func (c *DiskCache) recordDepthBook(product string) {
+	journal := c.journal()
+	if journal == nil {
+		return
+	}
+	if _, ok := journal.depthBooks[product]; ok {
+		return
+	}
+	record := &depthBookRecord{}
+	if book, ok := c.depthBookMap.data[product]; ok {
+		record.book = book.Copy()
+	}
+	_, record.updated = c.depthBookMap.updatedItems[product]
+	_, record.isNew = c.depthBookMap.newItems[product]
+	journal.depthBooks[product] = record
+}
+
+func (c *DiskCache) recordOrderIDs(key string) {
+	journal := c.journal()
+	if journal == nil {
+		return
+	}
+	if _, ok := journal.orderIDs[key]; ok {
+		return
+	}
+	record := &orderIDsRecord{}
+	if orderIDs, ok := c.orderIDsMap.Data[key]; ok {
+		// the order ids are removed in place
+		record.orderIDs = append([]string{}, orderIDs...)
+		record.exists = true
+	}
+	_, record.updated = c.orderIDsMap.updatedItems[key]
+	journal.orderIDs[key] = record
+}
+
+func (c *DiskCache) recordLastPrice(product string) {
+	journal := c.journal()
+	if journal == nil {
+		return
+	}
+	if _, ok := journal.lastPrices[product]; ok {
+		return
+	}
+	var record *sdk.Dec
+	if price, ok := c.priceMap[product]; ok {
+		record = &price
+	}
+	journal.lastPrices[product] = record
+}
+
+// rollback restores the memory caches to the values recorded in the journal
+func (c *DiskCache) rollback(cache *Cache, journal *cacheJournal) {
+	for product, record := range journal.depthBooks {
+		if record.book != nil {
+			c.depthBookMap.data[product] = record.book
+		} else {
+			delete(c.depthBookMap.data, product)
+		}
+		restoreItem(c.depthBookMap.updatedItems, product, record.updated)
+		restoreItem(c.depthBookMap.newItems, product, record.isNew)
+	}
+	for key, record := range journal.orderIDs {
+		if record.exists {
+			c.orderIDsMap.Data[key] = record.orderIDs
+		} else {
+			delete(c.orderIDsMap.Data, key)
+		}
+		restoreItem(c.orderIDsMap.updatedItems, key, record.updated)
+	}
+	for product, record := range journal.lastPrices {
+		if record != nil {
+			c.priceMap[product] = *record
+		} else {
+			delete(c.priceMap, product)
+		}
+	}
+	c.storeOrderNum = journal.storeOrderNum
+	c.openNum = journal.openNum
+	c.closedOrderIDs = c.closedOrderIDs[:journal.closedOrderIDsLen]
+
+	cache.updatedOrderIDs = cache.updatedOrderIDs[:journal.updatedOrderIDsLen]
+	// the results of the tx handlers may have been taken by backend
+	if len(cache.handlerTxMsgResult) > journal.txHandlerMsgResultLen {
+		cache.handlerTxMsgResult = cache.handlerTxMsgResult[:journal.txHandlerMsgResultLen]
+	}
+	if len(cache.handlerTxMsgOrderIDs) > journal.txHandlerMsgOrderIDsLen {
+		cache.handlerTxMsgOrderIDs = cache.handlerTxMsgOrderIDs[:journal.txHandlerMsgOrderIDsLen]
+	}
+	cache.blockMatchResult = journal.blockMatchResult
+	cache.cancelNum = journal.cancelNum
+	cache.expireNum = journal.expireNum
+	cache.partialFillNum = journal.partialFillNum
+	cache.fullFillNum = journal.fullFillNum
+}
+
+// merge keeps the records of the inner journal which are older than the ones of the journal
+func (j *cacheJournal) merge(inner *cacheJournal) {
+	for product, record := range inner.depthBooks {
+		if _, ok := j.depthBooks[product]; !ok {
+			j.depthBooks[product] = record
+		}
+	}
+	for key, record := range inner.orderIDs {
+		if _, ok := j.orderIDs[key]; !ok {
+			j.orderIDs[key] = record
+		}
+	}
+	for product, record := range inner.lastPrices {
+		if _, ok := j.lastPrices[product]; !ok {
+			j.lastPrices[product] = record
+		}
+	}
+}
+
+func restoreItem(items map[string]struct{}, key string, exists bool) {
+	if exists {
+		items[key] = struct{}{}
+	} else {
+		delete(items, key)
+	}
+}
+
+func copyBlockMatchResult(result *types.BlockMatchResult) *types.BlockMatchResult {
+	if result == nil {
+		return nil
+	}
+	resultCopy := *result
+	if result.ResultMap != nil {
+		resultCopy.ResultMap = make(map[string]types.MatchResult, len(result.ResultMap))
+		for product, matchResult := range result.ResultMap {
+			resultCopy.ResultMap[product] = matchResult
+		}
+	}
+	return &resultCopy
+}
+
+// TrackCacheChanges records the memory cache changes made by the tx being delivered. The changes are kept only if the
+// tx is written into the deliver state, which is known from the transient store when the changes are settled
+func (k Keeper) TrackCacheChanges(ctx sdk.Context) {
+	txHash := fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes()))
+	k.settleCacheChanges(ctx, txHash)
+	if journal := k.diskCache.journal(); journal != nil && journal.txHash == txHash {
+		// another msg of the same tx
+		return
+	}
+
+	k.diskCache.journalSeq++
+	markerKey := types.GetCacheJournalKey(k.diskCache.journalSeq)
+	ctx.TransientStore(k.transientStoreKey).Set(markerKey, []byte{1})
+	k.diskCache.pushJournal(k.cache, txHash, markerKey)
+}
+
+// SettleCacheChanges keeps the memory cache changes made by the txs written into the deliver state, and rolls back
+// the others. It must be called before the memory caches are used in EndBlock
+func (k Keeper) SettleCacheChanges(ctx sdk.Context) {
+	k.settleCacheChanges(ctx, "")
+}
+
+// settleCacheChanges settles the memory cache changes of the txs other than the one being delivered
+func (k Keeper) settleCacheChanges(ctx sdk.Context, txHash string) {
+	for journal := k.diskCache.journal(); journal != nil; journal = k.diskCache.journal() {
+		if txHash != "" && journal.txHash == txHash {
+			return
+		}
+		written := journal.markerKey != nil && ctx.TransientStore(k.transientStoreKey).Has(journal.markerKey)
+		k.diskCache.popJournal(k.cache, written)
+	}
+}
+
+// CacheContext branches the ctx along with the memory caches. writeCache writes the store changes made on the cache
+// ctx into the ctx and keeps the memory cache changes, while discardCache rolls the memory cache changes back.
+// Exactly one of them must be called before the memory caches are changed on the ctx again
+func (k Keeper) CacheContext(ctx sdk.Context) (cacheCtx sdk.Context, writeCache, discardCache func()) {
+	cacheStore := ctx.MultiStore().CacheMultiStore()
+	journal := k.diskCache.pushJournal(k.cache, "", nil)
+	writeCache = func() {
+		if k.settleCacheContext(journal) {
+			cacheStore.Write()
+			k.diskCache.popJournal(k.cache, true)
+		}
+	}
+	discardCache = func() {
+		if k.settleCacheContext(journal) {
+			k.diskCache.popJournal(k.cache, false)
+		}
+	}
+	return ctx.WithMultiStore(cacheStore), writeCache, discardCache
+}
+
+// settleCacheContext rolls back the inner cache contexts which are neither written nor discarded, returns false if
+// the journal of the cache context has been settled already
+func (k Keeper) settleCacheContext(journal *cacheJournal) bool {
+	found := false
+	for _, j := range k.diskCache.journals {
+		found = found || j == journal
+	}
+	if !found {
+		return false
+	}
+	for k.diskCache.journal() != journal {
+		k.diskCache.popJournal(k.cache, false)
+	}
+	return true
+}
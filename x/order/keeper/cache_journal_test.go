@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/types"
+)
+
+func TestKeeper_CacheContext(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 1, 100)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// the memory caches are rolled back along with the discarded store
+	cacheCtx, _, discardCache := keeper.CacheContext(ctx)
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "8", "1")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(cacheCtx, order))
+	keeper.SetLastPrice(cacheCtx, types.TestTokenPair, order.Price)
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	discardCache()
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 0, len(keeper.GetProductPriceOrderIDs(
+		types.FormatOrderIDsKey(types.TestTokenPair, order.Price, order.Side))))
+	require.EqualValues(t, 0, len(keeper.GetDiskCache().GetUpdatedDepthbookKeys()))
+	require.EqualValues(t, 0, keeper.GetOperationMetric().OpenNum)
+	require.Nil(t, keeper.GetOrder(ctx, order.OrderID))
+	require.Equal(t, tokenPair.InitPrice, keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// the inner changes are kept only if the outer cache context is written too
+	cacheCtx, writeCache, _ := keeper.CacheContext(ctx)
+	innerCtx, writeInnerCache, _ := keeper.CacheContext(cacheCtx)
+	order = mockOrder("", types.TestTokenPair, types.BuyOrder, "8", "1")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(innerCtx, order))
+	writeInnerCache()
+	writeCache()
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+	require.NotNil(t, keeper.GetOrder(ctx, order.OrderID))
+
+	cacheCtx, _, discardCache = keeper.CacheContext(ctx)
+	innerCtx, writeInnerCache, _ = keeper.CacheContext(cacheCtx)
+	keeper.CancelOrder(innerCtx, keeper.GetOrder(innerCtx, order.OrderID), nil)
+	writeInnerCache()
+	discardCache()
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+	require.EqualValues(t, 0, keeper.GetOperationMetric().CancelNum)
+	require.EqualValues(t, 0, len(keeper.GetDiskCache().GetClosedOrderIDs()))
+}
+
+func TestKeeper_TrackCacheChanges(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 1, 100)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	deliverTx := func(txBytes string, price string, write bool) *types.Order {
+		txStore := ctx.MultiStore().CacheMultiStore()
+		txCtx := ctx.WithMultiStore(txStore).WithTxBytes([]byte(txBytes))
+		keeper.TrackCacheChanges(txCtx)
+		order := mockOrder("", types.TestTokenPair, types.BuyOrder, price, "1")
+		order.Sender = testInput.TestAddrs[0]
+		require.Nil(t, keeper.PlaceOrder(txCtx, order))
+		if write {
+			txStore.Write()
+		}
+		return order
+	}
+
+	// the changes of a failed tx are rolled back when the next tx is delivered
+	deliverTx("tx1", "8", false)
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	order := deliverTx("tx2", "9", true)
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, order.Price, keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].Price)
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+
+	// the changes of the last tx are settled before EndBlock
+	deliverTx("tx3", "10", false)
+	keeper.SettleCacheChanges(ctx)
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+	require.EqualValues(t, []string{types.TestTokenPair}, keeper.GetDiskCache().GetUpdatedDepthbookKeys())
+
+	keeper.Cache2Disk(ctx)
+	require.EqualValues(t, 1, len(keeper.GetDepthBookFromDB(ctx, types.TestTokenPair).Items))
+	require.EqualValues(t, 1, keeper.GetOpenOrderNum(ctx))
+}
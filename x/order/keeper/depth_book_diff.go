@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/types"
+)
+
+// DepthBookDiffRetention is the number of the latest depth book diffs kept for every product
+const DepthBookDiffRetention = 300
+
+// depthBookDiffCache keeps the latest depth book diffs of every product in memory. The diffs are not part of the
+// consensus state, so a node only serves the diffs made since it started, the clients fall back to the snapshot
+type depthBookDiffCache struct {
+	mtx   sync.RWMutex
+	diffs map[string][]types.DepthBookDiff
+}
+
+func newDepthBookDiffCache() *depthBookDiffCache {
+	return &depthBookDiffCache{diffs: make(map[string][]types.DepthBookDiff)}
+}
+
+// add appends the diff of the product, replacing the ones with the same or later sequences made by a block executed
+// again, and drops the ones out of DepthBookDiffRetention
+func (c *depthBookDiffCache) add(product string, diff types.DepthBookDiff) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	diffs := c.diffs[product]
+	for len(diffs) > 0 && diffs[len(diffs)-1].Sequence >= diff.Sequence {
+		diffs = diffs[:len(diffs)-1]
+	}
+	diffs = append(diffs, diff)
+	if len(diffs) > DepthBookDiffRetention {
+		diffs = append([]types.DepthBookDiff{}, diffs[len(diffs)-DepthBookDiffRetention:]...)
+	}
+	c.diffs[product] = diffs
+}
+
+// get returns the diffs of the product in (from, to], false if they are not all kept
+func (c *depthBookDiffCache) get(product string, from, to int64) ([]types.DepthBookDiff, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	result := []types.DepthBookDiff{}
+	if from == to {
+		return result, true
+	}
+	for _, diff := range c.diffs[product] {
+		if diff.Sequence <= from {
+			continue
+		}
+		if diff.Sequence > to {
+			break
+		}
+		if diff.Sequence != from+int64(len(result))+1 {
+			return nil, false
+		}
+		result = append(result, diff)
+	}
+	return result, len(result) > 0 && result[len(result)-1].Sequence == to
+}
+
+// GetDepthBookSequence returns the sequence number of the product's depth book, which is bumped by every change
+func (k Keeper) GetDepthBookSequence(ctx sdk.Context, product string) int64 {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetDepthBookSeqKey(product))
+	if bz == nil {
+		return 0
+	}
+	var sequence int64
+	k.cdc.MustUnmarshalBinaryBare(bz, &sequence)
+	return sequence
+}
+
+// GetDepthBookDiffs returns the diffs of the product's depth book after the sequence number up to the sequence of
+// the ctx. It returns false if the diffs after the sequence are not all kept in memory, or the sequence is unknown
+func (k Keeper) GetDepthBookDiffs(ctx sdk.Context, product string, sequence int64) ([]types.DepthBookDiff, bool) {
+	current := k.GetDepthBookSequence(ctx, product)
+	if sequence < 0 || sequence > current || current-sequence > DepthBookDiffRetention {
+		return nil, false
+	}
+	return k.depthBookDiffs.get(product, sequence, current)
+}
+
+// storeDepthBookDiff records the changes of the product's depth book against the one in KVStore into the memory,
+// and bumps the sequence number in KVStore
+func (k Keeper) storeDepthBookDiff(ctx sdk.Context, product string, depthBook *types.DepthBook) {
+	items := types.DiffDepthBooks(k.GetDepthBookFromDB(ctx, product), depthBook)
+	if len(items) == 0 {
+		return
+	}
+
+	sequence := k.GetDepthBookSequence(ctx, product) + 1
+	ctx.KVStore(k.orderStoreKey).Set(types.GetDepthBookSeqKey(product), k.cdc.MustMarshalBinaryBare(sequence))
+	k.depthBookDiffs.add(product, types.DepthBookDiff{
+		Sequence:    sequence,
+		BlockHeight: ctx.BlockHeight(),
+		Items:       items,
+	})
+}
@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okchain/x/order/types"
+)
+
+func TestDepthBookDiffCache(t *testing.T) {
+	cache := newDepthBookDiffCache()
+	product := types.TestTokenPair
+	for sequence := int64(1); sequence <= DepthBookDiffRetention+2; sequence++ {
+		cache.add(product, types.DepthBookDiff{Sequence: sequence, BlockHeight: sequence})
+	}
+
+	// the diffs out of the retention are dropped
+	_, ok := cache.get(product, 1, 5)
+	require.False(t, ok)
+	diffs, ok := cache.get(product, 2, 5)
+	require.True(t, ok)
+	require.Len(t, diffs, 3)
+	require.EqualValues(t, 3, diffs[0].Sequence)
+
+	// the diffs up to the sequence only
+	diffs, ok = cache.get(product, DepthBookDiffRetention, DepthBookDiffRetention+1)
+	require.True(t, ok)
+	require.Len(t, diffs, 1)
+	diffs, ok = cache.get(product, DepthBookDiffRetention+2, DepthBookDiffRetention+2)
+	require.True(t, ok)
+	require.Empty(t, diffs)
+
+	// the diffs not made yet
+	_, ok = cache.get(product, DepthBookDiffRetention+2, DepthBookDiffRetention+3)
+	require.False(t, ok)
+	_, ok = cache.get("unknown_product", 0, 1)
+	require.False(t, ok)
+
+	// the diffs of a block executed again replace the old ones
+	cache.add(product, types.DepthBookDiff{Sequence: DepthBookDiffRetention + 1, BlockHeight: 1000})
+	diffs, ok = cache.get(product, DepthBookDiffRetention, DepthBookDiffRetention+1)
+	require.True(t, ok)
+	require.EqualValues(t, 1000, diffs[0].BlockHeight)
+	_, ok = cache.get(product, DepthBookDiffRetention, DepthBookDiffRetention+2)
+	require.False(t, ok)
+}
@@ -31,6 +31,9 @@ type DiskCache struct {
 	storeOrderNum  int64 // current stored order num
 	openNum        int64 // current open orders num
 	closedOrderIDs []string
+
+	journals   []*cacheJournal // the changes not sure to be kept, the innermost one at the end
+	journalSeq int64
 }
 
 func newDiskCache() *DiskCache {
@@ -49,6 +52,7 @@ func (c *DiskCache) reset() {
 	c.orderIDsMap.updatedItems = make(map[string]struct{})
 	c.depthBookMap.updatedItems = make(map[string]struct{})
 	c.depthBookMap.newItems = make(map[string]struct{})
+	c.journals = nil
 }
 
 // nolint
@@ -57,6 +61,7 @@ func (c *DiskCache) GetClosedOrderIDs() []string {
 }
 
 func (c *DiskCache) setLastPrice(product string, price sdk.Dec) {
+	c.recordLastPrice(product)
 	c.priceMap[product] = price
 }
 
@@ -114,7 +119,7 @@ func (c *DiskCache) addDepthBook(product string, book *types.DepthBook) {
 
 // setOrderIDs updates or removes unfilled order ids
 func (c *DiskCache) setOrderIDs(key string, orderIDs []string) {
-
+	c.recordOrderIDs(key)
 	if len(orderIDs) == 0 {
 		// remove empty element immediately, not do it by the end of endblock
 		delete(c.orderIDsMap.Data, key)
@@ -126,6 +131,7 @@ func (c *DiskCache) setOrderIDs(key string, orderIDs []string) {
 
 // setDepthBook updates or removes a depth book
 func (c *DiskCache) setDepthBook(product string, book *types.DepthBook) {
+	c.recordDepthBook(product)
 	if book != nil && len(book.Items) > 0 {
 		c.depthBookMap.data[product] = book
 	} else {
@@ -179,6 +185,18 @@ func (c *DiskCache) GetNewDepthbookKeys() []string {
 
 // insertOrder inserts a new order into orderIDsMap
 func (c *DiskCache) insertOrder(order *types.Order) {
+	c.insertOrderIntoDepthBook(order)
+
+	c.openNum++
+	c.storeOrderNum++
+}
+
+// insert a triggered conditional order, which has been counted when placed
+func (c *DiskCache) insertOrderIntoDepthBook(order *types.Order) {
+	c.recordDepthBook(order.Product)
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	c.recordOrderIDs(key)
+
 	// 1. update depthBookMap
 	depthBook, ok := c.depthBookMap.data[order.Product]
 	if !ok {
@@ -191,7 +209,6 @@ func (c *DiskCache) insertOrder(order *types.Order) {
 
 	// 2. update orderIDsMap
 	orderIDsMap := c.orderIDsMap
-	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
 	orderIDs, ok := orderIDsMap.Data[key]
 	if !ok {
 		orderIDs = []string{}
@@ -199,7 +216,10 @@ func (c *DiskCache) insertOrder(order *types.Order) {
 	orderIDs = append(orderIDs, order.OrderID)
 	orderIDsMap.Data[key] = orderIDs
 	c.orderIDsMap.updatedItems[key] = struct{}{}
+}
 
+// count a conditional order placed into the trigger book
+func (c *DiskCache) addTriggerOrder() {
 	c.openNum++
 	c.storeOrderNum++
 }
@@ -210,7 +230,19 @@ func (c *DiskCache) closeOrder(orderID string) {
 }
 
 // remove an order from orderIDsMap when order cancelled/expired
+func (c *DiskCache) decreaseOrder(order *types.Order, quantity sdk.Dec) {
+	c.recordDepthBook(order.Product)
+	depthBook := c.getDepthBook(order.Product)
+	if depthBook != nil {
+		depthBook.DecreaseOrder(order, quantity)
+		c.setDepthBook(order.Product, depthBook)
+	}
+}
+
 func (c *DiskCache) removeOrder(order *types.Order) {
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	c.recordDepthBook(order.Product)
+	c.recordOrderIDs(key)
 
 	// update depth book map
 	depthBook := c.getDepthBook(order.Product)
@@ -221,7 +253,6 @@ func (c *DiskCache) removeOrder(order *types.Order) {
 
 	// update order id map
 	orderIDsMap := c.orderIDsMap
-	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
 	orderIDs := orderIDsMap.Data[key]
 	orderIDsLen := len(orderIDs)
 	for i := 0; i < orderIDsLen; i++ {
@@ -40,11 +40,39 @@ func (k Keeper) DropExpireBlockHeight(ctx sdk.Context, blockHeight int64) {
 	store.Delete(key)
 }
 
+// SetExpireOrderIDs sets the IDs of good-til-block orders which expire at the block height to keeper
+func (k Keeper) SetExpireOrderIDs(ctx sdk.Context, blockHeight int64, orderIDs []string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	key := types.GetExpireOrderIDsKey(blockHeight)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(orderIDs))
+}
+
+// DropExpireOrderIDs deletes the IDs of good-til-block orders which expire at the block height from keeper
+func (k Keeper) DropExpireOrderIDs(ctx sdk.Context, blockHeight int64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	key := types.GetExpireOrderIDsKey(blockHeight)
+	store.Delete(key)
+}
+
 // ===============================================
-// nolint
+// SetOrder sets the order to keeper, and keeps the index of the sender's open orders and the number of the
+// sender's resting rent orders up to date
 func (k Keeper) SetOrder(ctx sdk.Context, orderID string, order *types.Order) {
 	store := ctx.KVStore(k.orderStoreKey)
 	store.Set(types.GetOrderKey(orderID), k.cdc.MustMarshalBinaryBare(order))
+
+	senderOrderKey := types.GetSenderOrderKey(order.Sender, orderID)
+	if order.Status == types.OrderStatusOpen || order.Status == types.OrderStatusUntriggered {
+		if order.IsRentOrder() && !store.Has(senderOrderKey) {
+			k.updateRentOrderNum(ctx, order.Sender, 1)
+		}
+		store.Set(senderOrderKey, []byte(orderID))
+	} else {
+		if order.IsRentOrder() && store.Has(senderOrderKey) {
+			k.updateRentOrderNum(ctx, order.Sender, -1)
+		}
+		store.Delete(senderOrderKey)
+	}
 }
 
 // nolint
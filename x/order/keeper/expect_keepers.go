@@ -29,6 +29,7 @@ type SupplyKeeper interface {
 		amt sdk.Coins) sdk.Error
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress,
 		amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) sdk.Error
 	GetModuleAccount(ctx sdk.Context, moduleName string) exported.ModuleAccountI
 	GetModuleAddress(moduleName string) sdk.AccAddress
 	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error
@@ -49,4 +50,7 @@ type DexKeeper interface {
 	GetLockedProductsCopy(ctx sdk.Context) *types.ProductLockMap
 	IsAnyProductLocked(ctx sdk.Context) bool
 	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator dex.DEXOperator, isExist bool)
+	GetEditedTokenPairs(ctx sdk.Context) []string
+	ClearEditedTokenPairs(ctx sdk.Context)
+	AddOperatorFee(ctx sdk.Context, operator sdk.AccAddress, product string, fee sdk.DecCoins)
 }
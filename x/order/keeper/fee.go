@@ -7,6 +7,7 @@ import (
 
 	"strings"
 
+	"github.com/okex/okchain/x/dex"
 	"github.com/okex/okchain/x/order/types"
 )
 
@@ -49,17 +50,54 @@ func GetZeroFee() sdk.DecCoins {
 // GetDealFee is used to calculate the handling fee when matching an order
 func GetDealFee(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper GetFeeKeeper,
 	feeParams *types.Params) sdk.DecCoins {
+	return getDealFeeAtPrice(order, fillAmt, keeper.GetLastPrice(ctx, order.Product), feeParams)
+}
+
+// getDealFeeAtPrice calculates the handling fee of a deal at the deal price by the global TradeFeeRate
+func getDealFeeAtPrice(order *types.Order, fillAmt, dealPrice sdk.Dec, feeParams *types.Params) sdk.DecCoins {
+	dealFee := GetDealFeeByRate(order, fillAmt, dealPrice, feeParams.TradeFeeRate)
+	if dealFee.IsZero() {
+		return sdk.DecCoins{sdk.NewDecCoinFromDec(dealFee[0].Denom, sdk.MustNewDecFromStr(minFee))}
+	}
+	return dealFee
+}
+
+// GetDealFeeByRate calculates the handling fee of a deal at the fee rate, which is charged in the token received
+func GetDealFeeByRate(order *types.Order, fillAmt, dealPrice, feeRate sdk.Dec) sdk.DecCoins {
 	symbols := strings.Split(order.Product, "_")
 	symbol := symbols[0]
 	quantity := fillAmt
 	if order.Side == types.SellOrder {
 		symbol = symbols[1]
-		quantity = fillAmt.Mul(keeper.GetLastPrice(ctx, order.Product))
+		quantity = fillAmt.Mul(dealPrice)
 	}
 
-	feeAmt := quantity.Mul(feeParams.TradeFeeRate)
-	if feeAmt.IsPositive() {
-		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, feeAmt)}
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, quantity.Mul(feeRate))}
+}
+
+// getDealFee calculates the handling fee of a deal by the fee tiers of the product, which fall back to
+// the default ones of the product owner. If neither is set, the global TradeFeeRate is used
+func (k Keeper) getDealFee(ctx sdk.Context, order *types.Order, fillAmt, fillPrice sdk.Dec, isMaker bool,
+	feeParams *types.Params) sdk.DecCoins {
+	feeTiers := k.getFeeTiers(ctx, order.Product)
+	if len(feeTiers) == 0 {
+		return getDealFeeAtPrice(order, fillAmt, fillPrice, feeParams)
+	}
+	volume := k.GetTradeVolume(ctx, order.Sender, order.Product)
+	return GetDealFeeByRate(order, fillAmt, fillPrice, feeTiers.GetFeeRate(volume, isMaker))
+}
+
+func (k Keeper) getFeeTiers(ctx sdk.Context, product string) dex.FeeTiers {
+	tokenPair := k.dexKeeper.GetTokenPair(ctx, product)
+	if tokenPair == nil {
+		return nil
+	}
+	if len(tokenPair.FeeTiers) > 0 {
+		return tokenPair.FeeTiers
+	}
+	operator, exists := k.dexKeeper.GetOperator(ctx, tokenPair.Owner)
+	if !exists {
+		return nil
 	}
-	return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.MustNewDecFromStr(minFee))}
+	return operator.FeeTiers
 }
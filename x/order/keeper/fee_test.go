@@ -2,8 +2,10 @@ package keeper
 
 import (
 	"testing"
+	"time"
 
 	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -123,3 +125,63 @@ func TestOrderDealFee(t *testing.T) {
 	expectFee = sdk.DecCoins{sdk.NewDecCoinFromDec("xxb", sdk.MustNewDecFromStr("0.00000001"))}
 	require.EqualValues(t, expectFee, feeOther)
 }
+
+func TestDealFeeWithFeeTiers(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockTime(time.Unix(100*secondsPerDay, 0))
+	feeParams := types.DefaultTestParams()
+	tokenPair := dex.GetBuiltInTokenPair()
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+	operator := dex.DEXOperator{Address: tokenPair.Owner, HandlingFeeAddress: tokenPair.Owner}
+	testInput.DexKeeper.SetOperator(ctx, operator)
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.NewDec(10))
+
+	order := mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "100.0")
+	order.Sender = testInput.TestAddrs[0]
+	fillQuantity := sdk.NewDec(10)
+
+	// no fee tiers, the global fee rate is used
+	fee := keeper.getDealFee(ctx, order, fillQuantity, order.Price, true, &feeParams)
+	require.EqualValues(t, "0.10000000"+common.NativeToken, fee.String())
+
+	// the default fee tiers of the operator
+	operator.FeeTiers = dex.FeeTiers{
+		dex.NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.002"), sdk.MustNewDecFromStr("0.003")),
+		dex.NewFeeTier(sdk.NewDec(1000), sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001")),
+	}
+	testInput.DexKeeper.SetOperator(ctx, operator)
+	fee = keeper.getDealFee(ctx, order, fillQuantity, order.Price, true, &feeParams)
+	require.EqualValues(t, "0.20000000"+common.NativeToken, fee.String())
+	fee = keeper.getDealFee(ctx, order, fillQuantity, order.Price, false, &feeParams)
+	require.EqualValues(t, "0.30000000"+common.NativeToken, fee.String())
+
+	// the fee tiers of the token pair take precedence
+	tokenPair.FeeTiers = dex.FeeTiers{
+		dex.NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.004"), sdk.MustNewDecFromStr("0.005")),
+	}
+	testInput.DexKeeper.UpdateTokenPair(ctx, tokenPair.Name(), tokenPair)
+	fee = keeper.getDealFee(ctx, order, fillQuantity, order.Price, false, &feeParams)
+	require.EqualValues(t, "0.50000000"+common.NativeToken, fee.String())
+	tokenPair.FeeTiers = nil
+	testInput.DexKeeper.UpdateTokenPair(ctx, tokenPair.Name(), tokenPair)
+
+	// reach the next tier by the traded volume, zero maker fee is charged without min fee
+	deal := keeper.FillOrder(ctx, order, sdk.NewDec(10), sdk.NewDec(100), false, &feeParams)
+	require.EqualValues(t, "3.00000000"+common.NativeToken, deal.Fee)
+	require.EqualValues(t, sdk.NewDec(1000), keeper.GetTradeVolume(ctx, order.Sender, order.Product))
+	fee = keeper.getDealFee(ctx, order, fillQuantity, order.Price, true, &feeParams)
+	require.True(t, fee.IsZero())
+	fee = keeper.getDealFee(ctx, order, fillQuantity, order.Price, false, &feeParams)
+	require.EqualValues(t, "0.10000000"+common.NativeToken, fee.String())
+
+	// the volume out of the rolling window is not counted and gets pruned
+	ctx = ctx.WithBlockTime(time.Unix((100+dex.FeeTierVolumeDays-1)*secondsPerDay, 0))
+	require.EqualValues(t, sdk.NewDec(1000), keeper.GetTradeVolume(ctx, order.Sender, order.Product))
+	ctx = ctx.WithBlockTime(time.Unix((100+dex.FeeTierVolumeDays)*secondsPerDay, 0))
+	require.True(t, keeper.GetTradeVolume(ctx, order.Sender, order.Product).IsZero())
+	keeper.addTradeVolume(ctx, order.Sender, order.Product, sdk.NewDec(1))
+	store := ctx.KVStore(keeper.orderStoreKey)
+	require.Nil(t, store.Get(types.GetTradeVolumeKey(order.Sender, order.Product, 100)))
+	require.EqualValues(t, sdk.NewDec(1), keeper.GetTradeVolume(ctx, order.Sender, order.Product))
+}
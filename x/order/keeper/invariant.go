@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,12 +12,16 @@ import (
 // RegisterInvariants registers all order invariants
 func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper) {
 	ir.RegisterRoute(types.ModuleName, "module-account", ModuleAccountInvariant(keeper))
+	ir.RegisterRoute(types.ModuleName, "sender-open-orders", SenderOpenOrdersInvariant(keeper))
+	ir.RegisterRoute(types.ModuleName, "order-rent", OrderRentInvariant(keeper))
 }
 
 // ModuleAccountInvariant checks that the module account coins reflects the sum of
 // locks amounts held on store
 func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
+		// invariants are checked before the order EndBlocker
+		keeper.SettleCacheChanges(ctx)
 		var lockedCoins, lockedFees, orderLockedFees sdk.DecCoins
 
 		for _, accCoins := range keeper.tokenKeeper.GetAllLockedCoins(ctx) {
@@ -45,6 +50,13 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 			}
 		}
 
+		// untriggered orders lock their new order fee until they are triggered, cancelled or expired
+		for _, product := range keeper.GetTriggerBookProducts(ctx) {
+			for _, order := range keeper.GetTriggerOrders(ctx, product) {
+				orderLockedFees = orderLockedFees.Add(GetOrderNewFee(order))
+			}
+		}
+
 		if !lockedFees.IsEqual(orderLockedFees) {
 			return sdk.FormatInvariant(types.ModuleName, "locks",
 				fmt.Sprintf("\ttoken LockedFee coins: %s\n\tsum of order locked fee amounts:  %s\n",
@@ -58,3 +70,87 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 				macc.GetCoins(), lockedCoins.Add(lockedFees))), broken
 	}
 }
+
+// SenderOpenOrdersInvariant checks that the index of the senders' open orders holds exactly
+// the open orders in the depth books and the untriggered orders in the trigger books
+func SenderOpenOrdersInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		// invariants are checked before the order EndBlocker
+		keeper.SettleCacheChanges(ctx)
+		bookOrders := make(map[string]*types.Order)
+		for _, product := range keeper.GetProductsFromDepthBookMap() {
+			depthBook := keeper.GetDepthBookCopy(product)
+			for _, item := range depthBook.Items {
+				buyKey := types.FormatOrderIDsKey(product, item.Price, types.BuyOrder)
+				orderIDList := keeper.GetProductPriceOrderIDs(buyKey)
+				sellKey := types.FormatOrderIDsKey(product, item.Price, types.SellOrder)
+				orderIDList = append(orderIDList, keeper.GetProductPriceOrderIDs(sellKey)...)
+				for _, orderID := range orderIDList {
+					bookOrders[orderID] = keeper.GetOrder(ctx, orderID)
+				}
+			}
+		}
+		for _, pair := range keeper.GetDexKeeper().GetTokenPairs(ctx) {
+			for _, order := range keeper.GetTriggerOrders(ctx, pair.Name()) {
+				bookOrders[order.OrderID] = order
+			}
+		}
+
+		var msg string
+		indexed := 0
+		store := ctx.KVStore(keeper.orderStoreKey)
+		iter := sdk.KVStorePrefixIterator(store, types.SenderOrderKey)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			indexed++
+			orderID := string(iter.Value())
+			order, ok := bookOrders[orderID]
+			if !ok || order == nil {
+				msg += fmt.Sprintf("\tindexed order %s is neither open nor untriggered\n", orderID)
+				continue
+			}
+			if !bytes.Equal(iter.Key(), types.GetSenderOrderKey(order.Sender, orderID)) {
+				msg += fmt.Sprintf("\tindexed order %s is not indexed under its sender %s\n", orderID, order.Sender)
+			}
+		}
+		if indexed != len(bookOrders) {
+			msg += fmt.Sprintf("\tsender index orders: %d\n\tsum of open and untriggered orders: %d\n",
+				indexed, len(bookOrders))
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "sender-open-orders", msg), msg != ""
+	}
+}
+
+// OrderRentInvariant checks that the order module account coins reflects the sum of
+// the prepaid order rent balances, and that the balances count the resting rent orders of the addresses
+func OrderRentInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		// invariants are checked before the order EndBlocker
+		keeper.SettleCacheChanges(ctx)
+		var msg string
+		var balances sdk.DecCoins
+		for _, rent := range keeper.GetAllOrderRents(ctx) {
+			if rent.Balance.IsPositive() {
+				balances = balances.Add(sdk.DecCoins{rent.Balance})
+			}
+			var orderNum int64
+			for _, orderID := range keeper.GetSenderOpenOrderIDs(ctx, rent.Address) {
+				if order := keeper.GetOrder(ctx, orderID); order != nil && order.IsRentOrder() {
+					orderNum++
+				}
+			}
+			if orderNum != rent.OrderNum {
+				msg += fmt.Sprintf("\trent orders of %s: %d\n\tresting rent orders: %d\n",
+					rent.Address, rent.OrderNum, orderNum)
+			}
+		}
+
+		macc := keeper.supplyKeeper.GetModuleAccount(ctx, types.ModuleName)
+		if !macc.GetCoins().IsEqual(balances) {
+			msg += fmt.Sprintf("\torder ModuleAccount coins: %s\n\tsum of order rent balances:  %s\n",
+				macc.GetCoins(), balances)
+		}
+		return sdk.FormatInvariant(types.ModuleName, "order-rent", msg), msg != ""
+	}
+}
@@ -61,6 +61,22 @@ func TestModuleAccountInvariant(t *testing.T) {
 	expectedLockCoins = expectedLockCoins.Sub(order2.NeedLockCoins()).Sub(GetOrderNewFee(order2))
 	require.Equal(t, invariantMsg(expectedLockCoins), msg)
 
+	// pending stop order locks its coins and new order fee until it's triggered
+	order3 := mockOrder("", types.TestTokenPair, types.SellOrder, "8.0", "1.0")
+	order3.Sender = testInput.TestAddrs[0]
+	order3.Type = types.StopLossOrder
+	triggerPrice := sdk.MustNewDecFromStr("9.0")
+	order3.TriggerPrice = &triggerPrice
+	err = keeper.PlaceTriggerOrder(ctx, order3)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keeper.GetTriggerOrders(ctx, types.TestTokenPair)))
+
+	msg, broken = invariant(ctx)
+	require.False(t, broken)
+	expectedLockCoins = expectedLockCoins.Add(order3.NeedLockCoins()).Add(GetOrderNewFee(order3))
+	require.True(t, GetOrderNewFee(order3).IsAllPositive())
+	require.Equal(t, invariantMsg(expectedLockCoins), msg)
+
 	// lock LockCoinsTypeQuantity
 	lockCoins := sdk.MustParseCoins(sdk.DefaultBondDenom, "1")
 	err = keeper.tokenKeeper.LockCoins(ctx, testInput.TestAddrs[1], lockCoins, token.LockCoinsTypeQuantity)
@@ -94,3 +110,39 @@ func invariantMsg(lockCoins sdk.DecCoins) string {
 		fmt.Sprintf("\ttoken ModuleAccount coins: %s\n\tsum of locks amounts:  %s\n",
 			lockCoins, lockCoins))
 }
+
+func TestSenderOpenOrdersInvariant(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	invariant := SenderOpenOrdersInvariant(keeper)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.NoError(t, err)
+
+	order1 := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order1.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, order1))
+	order2 := mockOrder("", types.TestTokenPair, types.SellOrder, "20.0", "3.0")
+	order2.Sender = testInput.TestAddrs[1]
+	require.NoError(t, keeper.PlaceOrder(ctx, order2))
+
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	keeper.CancelOrder(ctx, order1, ctx.Logger())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// error case: the index misses an open order
+	store := ctx.KVStore(keeper.orderStoreKey)
+	store.Delete(types.GetSenderOrderKey(order2.Sender, order2.OrderID))
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+
+	// error case: the open order is indexed under another sender
+	store.Set(types.GetSenderOrderKey(order1.Sender, order2.OrderID), []byte(order2.OrderID))
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+}
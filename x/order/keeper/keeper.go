@@ -34,6 +34,8 @@ type Keeper struct {
 
 	// Unexposed key to access name store from sdk.Context
 	orderStoreKey sdk.StoreKey
+	// Unexposed key to access the transient store marking the written memory cache changes
+	transientStoreKey sdk.StoreKey
 
 	cdc           *codec.Codec // The wire codec for binary encoding/decoding.
 	enableBackend bool         // whether open backend plugin
@@ -43,11 +45,13 @@ type Keeper struct {
 	// reset cache data in BeginBlock
 	cache     *Cache
 	diskCache *DiskCache
+	// the latest depth book diffs, kept across blocks
+	depthBookDiffs *depthBookDiffCache
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
 func NewKeeper(tokenKeeper TokenKeeper, supplyKeeper SupplyKeeper, dexKeeper DexKeeper,
-	paramSpace params.Subspace, feeCollectorName string, ordersStoreKey sdk.StoreKey,
+	paramSpace params.Subspace, feeCollectorName string, ordersStoreKey, transientStoreKey sdk.StoreKey,
 	cdc *codec.Codec,
 	enableBackend bool, metrics *monitor.OrderMetric) Keeper {
 
@@ -62,11 +66,13 @@ func NewKeeper(tokenKeeper TokenKeeper, supplyKeeper SupplyKeeper, dexKeeper Dex
 		dexKeeper:    dexKeeper,
 		paramSpace:   paramSpace.WithKeyTable(types.ParamKeyTable()),
 
-		orderStoreKey: ordersStoreKey,
+		orderStoreKey:     ordersStoreKey,
+		transientStoreKey: transientStoreKey,
 
-		cdc:       cdc,
-		cache:     NewCache(),
-		diskCache: newDiskCache(),
+		cdc:            cdc,
+		cache:          NewCache(),
+		diskCache:      newDiskCache(),
+		depthBookDiffs: newDepthBookDiffCache(),
 	}
 }
 
@@ -120,7 +126,9 @@ func (k Keeper) Cache2Disk(ctx sdk.Context) {
 	// update depth book to KVStore
 	updatedBookKeys := k.diskCache.GetUpdatedDepthbookKeys()
 	for _, key := range updatedBookKeys {
-		k.StoreDepthBook(ctx, key, k.diskCache.getDepthBook(key))
+		depthBook := k.diskCache.getDepthBook(key)
+		k.storeDepthBookDiff(ctx, key, depthBook)
+		k.StoreDepthBook(ctx, key, depthBook)
 	}
 
 	updatedItemKeys := k.diskCache.GetUpdatedOrderIDKeys()
@@ -181,6 +189,18 @@ func (k Keeper) GetExpireBlockHeight(ctx sdk.Context, blockHeight int64) []int64
 	return expireBlockNumbers
 }
 
+// GetExpireOrderIDs gets the IDs of good-til-block orders which expire at the block height from KVStore
+func (k Keeper) GetExpireOrderIDs(ctx sdk.Context, blockHeight int64) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetExpireOrderIDsKey(blockHeight))
+	if bz == nil {
+		return []string{}
+	}
+	var orderIDs []string
+	k.cdc.MustUnmarshalBinaryBare(bz, &orderIDs)
+	return orderIDs
+}
+
 // GetOrder gets order from KVStore
 func (k Keeper) GetOrder(ctx sdk.Context, orderID string) *types.Order {
 	store := ctx.KVStore(k.orderStoreKey)
@@ -193,6 +213,44 @@ func (k Keeper) GetOrder(ctx sdk.Context, orderID string) *types.Order {
 	return order
 }
 
+// GetSenderOpenOrderIDs gets the IDs of the open and untriggered orders of the sender from KVStore
+func (k Keeper) GetSenderOpenOrderIDs(ctx sdk.Context, sender sdk.AccAddress) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetSenderOrdersKey(sender))
+	defer iter.Close()
+
+	var orderIDs []string
+	for ; iter.Valid(); iter.Next() {
+		orderIDs = append(orderIDs, string(iter.Value()))
+	}
+	return orderIDs
+}
+
+// BuildSenderOrderIndex indexes the open and untriggered orders stored before the index of the senders' open orders
+// existed. It only runs once, the orders set later are indexed by SetOrder
+func (k Keeper) BuildSenderOrderIndex(ctx sdk.Context) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if store.Has(types.SenderOrderIndexBuiltKey) {
+		return
+	}
+
+	var orders []*types.Order
+	iter := sdk.KVStorePrefixIterator(store, types.OrderKey)
+	for ; iter.Valid(); iter.Next() {
+		order := &types.Order{}
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), order)
+		if order.Status == types.OrderStatusOpen || order.Status == types.OrderStatusUntriggered {
+			orders = append(orders, order)
+		}
+	}
+	iter.Close()
+
+	for _, order := range orders {
+		store.Set(types.GetSenderOrderKey(order.Sender, order.OrderID), []byte(order.OrderID))
+	}
+	store.Set(types.SenderOrderIndexBuiltKey, []byte{1})
+}
+
 // nolint
 func (k Keeper) GetLastPrice(ctx sdk.Context, product string) sdk.Dec {
 	// get last price from cache
@@ -333,6 +391,15 @@ func (k Keeper) GetTxHandlerMsgResult() []bitset.BitSet {
 	return k.cache.toggleCopyTxHandlerMsgResult()
 }
 
+// GetTxHandlerMsgOrderIDs returns the IDs of the orders cancelled by the cancel all msgs of the tx, in the order of
+// the msgs. Be careful, only call by backend module along with GetTxHandlerMsgResult
+func (k Keeper) GetTxHandlerMsgOrderIDs() [][]string {
+	if !k.enableBackend {
+		return nil
+	}
+	return k.cache.toggleCopyTxHandlerMsgOrderIDs()
+}
+
 // nolint
 func (k Keeper) addUpdatedOrderID(orderID string) {
 	if k.enableBackend {
@@ -364,6 +431,14 @@ func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
 	}
 }
 
+// AddBlockMatchResult merges the match result of the product into the block match result,
+// the products of different match engines are matched at different stages of the block
+func (k Keeper) AddBlockMatchResult(ctx sdk.Context, product string, result types.MatchResult) {
+	if k.enableBackend {
+		k.cache.addBlockMatchResult(ctx.BlockHeight(), ctx.BlockHeader().Time.Unix(), product, result)
+	}
+}
+
 // LockCoins locks coins from the specified address,
 func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error {
 	if coins.IsZero() {
@@ -434,6 +509,9 @@ func (k Keeper) SendFeesToProductOwner(ctx sdk.Context, coins sdk.DecCoins, from
 		log.Printf("Send fee(%s) to address(%s) failed\n", coins.String(), to.String())
 		return "", err
 	}
+	// account the fee to the owner of the token pair, i.e. the dex operator
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+	k.GetDexKeeper().AddOperatorFee(ctx, tokenPair.Owner, product, coins)
 	return to.String(), nil
 }
 
@@ -458,9 +536,12 @@ func (k Keeper) GetParams(ctx sdk.Context) *types.Params {
 		return cacheParams
 	}
 
-	// if param not stored in cache, get param from KVStore and cache it
-	var param types.Params
-	k.paramSpace.GetParamSet(ctx, &param)
+	// if param not stored in cache, get param from KVStore and cache it.
+	// The params added after the chain started are not stored until set by governance, their defaults are used
+	param := types.DefaultParams()
+	for _, pair := range param.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
 	k.cache.SetParams(&param)
 	return &param
 }
@@ -555,3 +636,10 @@ func (k Keeper) AddTxHandlerMsgResult(resultSet bitset.BitSet) {
 		k.cache.addTxHandlerMsgResult(resultSet)
 	}
 }
+
+// AddTxHandlerMsgOrderIDs records the IDs of the orders cancelled by a cancel all msg for backend
+func (k Keeper) AddTxHandlerMsgOrderIDs(orderIDs []string) {
+	if k.enableBackend {
+		k.cache.addTxHandlerMsgOrderIDs(orderIDs)
+	}
+}
@@ -54,6 +54,9 @@ func (k Keeper) DumpStore(ctx sdk.Context) {
 	var expireBlockNumbers []int64
 	dumpKvs(orderStore, types.ExpireBlockHeightKey, "ExpireBlockHeightKey", &expireBlockNumbers, unmarshalHandler, dumpIntHandler)
 
+	var expireOrderIDs []string
+	dumpKvs(orderStore, types.ExpireOrderIDsKey, "ExpireOrderIDsKey", &expireOrderIDs, unmarshalHandler, dumpIntHandler)
+
 	dumpKv(orderStore, logger, types.LastExpiredBlockHeightKey, "LastExpiredBlockHeightKey")
 	dumpKv(orderStore, logger, types.OpenOrderNumKey, "OpenOrderNumKey")
 	dumpKv(orderStore, logger, types.StoreOrderNumKey, "StoreOrderNumKey")
@@ -1,12 +1,20 @@
 package keeper
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/monitor"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/okex/okchain/x/params"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/okex/okchain/x/dex"
 	"github.com/okex/okchain/x/order/types"
@@ -335,6 +343,14 @@ func TestKeeper_SendFeesToProductOwner(t *testing.T) {
 
 	_, err = keeper.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product)
 	require.Nil(t, err)
+
+	// the fee is accounted to the operator
+	operatorFees := testInput.DexKeeper.GetOperatorFees(ctx, tokenPair.Owner)
+	require.Equal(t, dex.ProductFees{{Product: order.Product, Fee: dealFee}}, operatorFees.Fees)
+	statement, found := testInput.DexKeeper.GetOperatorFeeStatement(ctx, tokenPair.Owner,
+		dex.GetOperatorFeeEpoch(ctx.BlockHeight()))
+	require.True(t, found)
+	require.Equal(t, dealFee, statement.Total)
 }
 
 func TestKeeper_GetBestBidAndAsk(t *testing.T) {
@@ -379,3 +395,80 @@ func TestFilterDelistedProducts(t *testing.T) {
 	cleanProducts := keeper.FilterDelistedProducts(ctx, productsList)
 	require.EqualValues(t, expectedProductsList, cleanProducts)
 }
+
+func TestKeeper_GetParamsAddedLater(t *testing.T) {
+	db := dbm.NewMemDB()
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	paramsKeeper := params.NewKeeper(MakeTestCodec(), keyParams, tkeyParams, params.DefaultCodespace)
+	keeper := NewKeeper(nil, nil, nil, paramsKeeper.Subspace(types.DefaultParamspace), auth.FeeCollectorName,
+		nil, nil, MakeTestCodec(), false, monitor.NopOrderMetrics())
+
+	// only the params of the chain started before MarketOrderSlippage was added are stored
+	stored := types.DefaultTestParams()
+	stored.OrderExpireBlocks = 100
+	for _, pair := range stored.ParamSetPairs() {
+		if bytes.Equal(pair.Key, types.KeyMarketOrderSlippage) {
+			break
+		}
+		keeper.paramSpace.Set(ctx, pair.Key, pair.Value)
+	}
+
+	// the params not stored take the default values
+	defaultParams := types.DefaultParams()
+	expected := stored
+	expected.MarketOrderSlippage = defaultParams.MarketOrderSlippage
+	expected.SelfTradePrevention = defaultParams.SelfTradePrevention
+	expected.PriceBandHaltBlocks = defaultParams.PriceBandHaltBlocks
+	expected.OrderRentPerBlock = defaultParams.OrderRentPerBlock
+	require.Equal(t, expected, *keeper.GetParams(ctx))
+}
+
+func TestBuildSenderOrderIndex(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	keeper.ResetCache(ctx)
+	openOrder := mockOrder("", types.TestTokenPair, types.BuyOrder, "9.8", "1.0")
+	openOrder.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, openOrder))
+	triggerOrder := mockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "1.0")
+	triggerOrder.Sender = testInput.TestAddrs[0]
+	triggerOrder.Type = types.StopLossOrder
+	triggerPrice := sdk.MustNewDecFromStr("9.5")
+	triggerOrder.TriggerPrice = &triggerPrice
+	require.Nil(t, keeper.PlaceTriggerOrder(ctx, triggerOrder))
+	cancelledOrder := mockOrder("", types.TestTokenPair, types.BuyOrder, "9.7", "1.0")
+	cancelledOrder.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, cancelledOrder))
+	keeper.Cache2Disk(ctx)
+	cancelledOrder.Status = types.OrderStatusCancelled
+	keeper.SetOrder(ctx, cancelledOrder.OrderID, cancelledOrder)
+
+	// the orders stored before the index existed
+	kvStore := ctx.KVStore(keeper.orderStoreKey)
+	for _, orderID := range keeper.GetSenderOpenOrderIDs(ctx, testInput.TestAddrs[0]) {
+		kvStore.Delete(types.GetSenderOrderKey(testInput.TestAddrs[0], orderID))
+	}
+	require.Empty(t, keeper.GetSenderOpenOrderIDs(ctx, testInput.TestAddrs[0]))
+
+	keeper.BuildSenderOrderIndex(ctx)
+	require.Equal(t, []string{openOrder.OrderID, triggerOrder.OrderID},
+		keeper.GetSenderOpenOrderIDs(ctx, testInput.TestAddrs[0]))
+
+	// it only runs once
+	kvStore.Delete(types.GetSenderOrderKey(testInput.TestAddrs[0], openOrder.OrderID))
+	keeper.BuildSenderOrderIndex(ctx)
+	require.Equal(t, []string{triggerOrder.OrderID}, keeper.GetSenderOpenOrderIDs(ctx, testInput.TestAddrs[0]))
+}
@@ -11,6 +11,8 @@ type Cache struct {
 	updatedOrderIDs  []string
 	blockMatchResult *types.BlockMatchResult
 	handlerTxMsgResult []bitset.BitSet
+	// IDs of the orders cancelled by the cancel all msgs, which are not known from the msgs
+	handlerTxMsgOrderIDs [][]string
 
 	params *types.Params
 
@@ -35,6 +37,7 @@ func (c *Cache) reset() {
 	c.updatedOrderIDs = []string{}
 	c.blockMatchResult = &types.BlockMatchResult{}
 	c.handlerTxMsgResult = []bitset.BitSet{}
+	c.handlerTxMsgOrderIDs = [][]string{}
 	c.params = nil
 
 	c.cancelNum = 0
@@ -51,10 +54,31 @@ func (c *Cache) setBlockMatchResult(result *types.BlockMatchResult) {
 	c.blockMatchResult = result
 }
 
+// addBlockMatchResult merges the match result of the product into the block match result
+func (c *Cache) addBlockMatchResult(blockHeight, timestamp int64, product string, result types.MatchResult) {
+	if c.blockMatchResult == nil || c.blockMatchResult.ResultMap == nil {
+		c.blockMatchResult = &types.BlockMatchResult{
+			BlockHeight: blockHeight,
+			ResultMap:   make(map[string]types.MatchResult),
+			TimeStamp:   timestamp,
+		}
+	}
+
+	if existing, ok := c.blockMatchResult.ResultMap[product]; ok {
+		result.Quantity = existing.Quantity.Add(result.Quantity)
+		result.Deals = append(existing.Deals, result.Deals...)
+	}
+	c.blockMatchResult.ResultMap[product] = result
+}
+
 func (c *Cache) addTxHandlerMsgResult(resultSet bitset.BitSet) {
 	c.handlerTxMsgResult = append(c.handlerTxMsgResult, resultSet)
 }
 
+func (c *Cache) addTxHandlerMsgOrderIDs(orderIDs []string) {
+	c.handlerTxMsgOrderIDs = append(c.handlerTxMsgOrderIDs, orderIDs)
+}
+
 // nolint
 func (c *Cache) IncreaseExpireNum() int64 {
 	c.expireNum++
@@ -126,6 +150,15 @@ func (c *Cache) toggleCopyTxHandlerMsgResult() []bitset.BitSet {
 	return txResultCopy
 }
 
+// toggleCopyTxHandlerMsgOrderIDs: copy and reset the handlerTxMsgOrderIDs
+func (c *Cache) toggleCopyTxHandlerMsgOrderIDs() [][]string {
+	orderIDsCopy := make([][]string, 0, len(c.handlerTxMsgOrderIDs))
+	orderIDsCopy = append(orderIDsCopy, c.handlerTxMsgOrderIDs...)
+	c.handlerTxMsgOrderIDs = [][]string{}
+
+	return orderIDsCopy
+}
+
 // nolint
 func (c *Cache) GetFullFillNum() int64 {
 	return c.fullFillNum
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -13,6 +14,11 @@ import (
 // TryPlaceOrder tries to charge fee & lock coins for a new order
 func (k Keeper) TryPlaceOrder(ctx sdk.Context, order *types.Order) (fee sdk.DecCoins, err error) {
 	logger := ctx.Logger().With("module", "order")
+	if order.IsRentOrder() {
+		if err = k.checkOrderRent(ctx, order); err != nil {
+			return fee, err
+		}
+	}
 	// Trying to lock coins
 	needLockCoins := order.NeedLockCoins()
 	err = k.LockCoins(ctx, order.Sender, needLockCoins, token.LockCoinsTypeQuantity)
@@ -64,8 +70,33 @@ func (k Keeper) CancelOrder(ctx sdk.Context, order *types.Order, logger log.Logg
 	return k.quitOrder(ctx, order, types.FeeTypeOrderCancel, logger)
 }
 
+// DecreaseOrder reduces the quantity of the open order without filling it, and unlocks the coins released.
+// The order is cancelled if nothing remains
+func (k Keeper) DecreaseOrder(ctx sdk.Context, order *types.Order, quantity sdk.Dec, logger log.Logger) {
+	if quantity.GTE(order.RemainQuantity) {
+		k.CancelOrder(ctx, order, logger)
+		return
+	}
+
+	k.diskCache.decreaseOrder(order, quantity)
+	unlockCoins := order.Decrease(quantity)
+	k.UnlockCoins(ctx, order.Sender, unlockCoins, token.LockCoinsTypeQuantity)
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+}
+
 // quitOrder unlocks & charges fee, unlocks coins, updates order, and updates DepthBook
 func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, logger log.Logger) (fee sdk.DecCoins) {
+	fee, err := k.tryQuitOrder(ctx, order, feeType)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to charge order(%s) %s fee: %v", feeType, order.OrderID, err))
+	}
+	return fee
+}
+
+// tryQuitOrder quits the order as quitOrder does, and returns the error of charging the fee
+func (k Keeper) tryQuitOrder(ctx sdk.Context, order *types.Order, feeType string) (fee sdk.DecCoins, err error) {
+	untriggered := order.Status == types.OrderStatusUntriggered
 	switch feeType {
 	case types.FeeTypeOrderCancel:
 		order.Cancel()
@@ -76,31 +107,127 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	}
 
 	// unlock coins in this order & charge fee
-	needUnlockCoins := order.NeedUnlockCoins()
-	k.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
-
-	lockedFee := GetOrderNewFee(order)
-	fee = GetOrderCostFee(order, ctx)
-	receiveFee := lockedFee.Sub(fee)
-
-	k.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
+	fee, err = k.unlockOrderAndChargeFee(ctx, order, feeType)
+	receiveFee := GetOrderNewFee(order).Sub(fee)
 	k.AddFeeDetail(ctx, order.Sender, receiveFee, types.FeeTypeOrderReceive)
 	order.RecordOrderReceiveFee(receiveFee)
 
-	err := k.AddCollectedFees(ctx, fee, order.Sender, feeType, false)
+	order.Unlock()
+	k.SetOrder(ctx, order.OrderID, order)
+
+	// remove order from depth book cache, or from trigger book if it's not triggered yet
+	if untriggered {
+		k.RemoveOrderFromTriggerBook(ctx, order, feeType)
+	} else {
+		k.RemoveOrderFromDepthBook(order, feeType)
+	}
+	return fee, err
+}
+
+// unlockOrderAndChargeFee unlocks the coins & fee locked by the order, and charges the fee cost by the order
+func (k Keeper) unlockOrderAndChargeFee(ctx sdk.Context, order *types.Order, feeType string) (
+	fee sdk.DecCoins, err error) {
+	k.UnlockCoins(ctx, order.Sender, order.NeedUnlockCoins(), token.LockCoinsTypeQuantity)
 
+	fee = GetOrderCostFee(order, ctx)
+	k.UnlockCoins(ctx, order.Sender, GetOrderNewFee(order), token.LockCoinsTypeFee)
+	return fee, k.AddCollectedFees(ctx, fee, order.Sender, feeType, false)
+}
+
+// AmendOrder cancels the open order and places the new order in its place. The cost fee of the old order is
+// charged as cancelling, and the fee of the new order is locked again. Both are done on a cache context, so that
+// nothing is changed if either fails, e.g. the coins released from the old order are not enough for the new order.
+func (k Keeper) AmendOrder(ctx sdk.Context, oldOrder, newOrder *types.Order) (fee sdk.DecCoins, err error) {
+	cacheCtx, writeCache, discardCache := k.CacheContext(ctx)
+	if fee, err = k.tryQuitOrder(cacheCtx, oldOrder, types.FeeTypeOrderCancel); err != nil {
+		discardCache()
+		return fee, err
+	}
+	oldOrder.RecordOrderCancelFee(fee)
+
+	newOrder.RecordOrderAmendFrom(oldOrder.OrderID)
+	if newOrder.IsConditionalOrder() {
+		err = k.PlaceTriggerOrder(cacheCtx, newOrder)
+	} else {
+		err = k.PlaceOrder(cacheCtx, newOrder)
+	}
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to charge order(%s) %s fee: %v", feeType, order.OrderID, err))
+		discardCache()
+		return fee, err
 	}
 
-	order.Unlock()
-	k.SetOrder(ctx, order.OrderID, order)
+	oldOrder.RecordOrderAmendTo(newOrder.OrderID)
+	k.SetOrder(cacheCtx, oldOrder.OrderID, oldOrder)
+	writeCache()
+	return fee, nil
+}
 
-	// remove order from depth book cache
-	k.RemoveOrderFromDepthBook(order, feeType)
-	return fee
+// FillOrder updates the order, transfers tokens and charges fees, then returns a deal.
+// If the order is fully filled but still locks some coins, unlock them.
+// isMaker tells whether the order provided the liquidity of the deal, which decides its fee rate
+func (k Keeper) FillOrder(ctx sdk.Context, order *types.Order, fillPrice, fillQuantity sdk.Dec, isMaker bool,
+	feeParams *types.Params) *types.Deal {
+
+	// update order
+	order.Fill(fillPrice, fillQuantity)
+
+	k.balanceAccount(ctx, order, fillPrice, fillQuantity)
+	// if fully filled and still need unlock coins
+	if order.Status == types.OrderStatusFilled && order.RemainLocked.IsPositive() {
+		needUnlockCoins := order.NeedUnlockCoins()
+		k.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
+		order.Unlock()
+	}
+
+	dealFee, feeReceiver := k.chargeFee(ctx, order, fillPrice, fillQuantity, isMaker, feeParams)
+	// the volume of this deal only counts for the fee tiers of the later deals
+	k.addTradeVolume(ctx, order.Sender, order.Product, fillPrice.Mul(fillQuantity))
+	k.UpdateOrder(order, ctx) // update order info on filled
+	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Quantity: fillQuantity, Fee: dealFee.String(),
+		FeeReceiver: feeReceiver, Price: fillPrice}
+}
+
+func (k Keeper) balanceAccount(ctx sdk.Context, order *types.Order, fillPrice, fillQuantity sdk.Dec) {
+	symbols := strings.Split(order.Product, "_")
+	// transfer tokens
+	var outputCoins, inputCoins sdk.DecCoins
+	if order.Side == types.BuyOrder {
+		outputCoins = sdk.DecCoins{{Denom: symbols[1], Amount: fillPrice.Mul(fillQuantity)}}
+		inputCoins = sdk.DecCoins{{Denom: symbols[0], Amount: fillQuantity}}
+	} else {
+		outputCoins = sdk.DecCoins{{Denom: symbols[0], Amount: fillQuantity}}
+		inputCoins = sdk.DecCoins{{Denom: symbols[1], Amount: fillPrice.Mul(fillQuantity)}}
+	}
+	k.BalanceAccount(ctx, order.Sender, outputCoins, inputCoins)
+}
+
+func (k Keeper) chargeFee(ctx sdk.Context, order *types.Order, fillPrice, fillQuantity sdk.Dec, isMaker bool,
+	feeParams *types.Params) (dealFee sdk.DecCoins, feeReceiver string) {
+	// charge fee
+	fee := GetZeroFee()
+	if order.Status == types.OrderStatusFilled {
+		lockedFee := GetOrderNewFee(order)
+		fee = GetOrderCostFee(order, ctx)
+		receiveFee := lockedFee.Sub(fee)
+
+		k.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
+		k.AddFeeDetail(ctx, order.Sender, receiveFee, types.FeeTypeOrderReceive)
+		order.RecordOrderReceiveFee(receiveFee)
+
+		err := k.AddCollectedFees(ctx, fee, order.Sender, types.FeeTypeOrderNew, false)
+		if err != nil {
+			ctx.Logger().Error(fmt.Sprintf("Send fee failed:%s\n", err.Error()))
+		}
+	}
+	dealFee = k.getDealFee(ctx, order, fillQuantity, fillPrice, isMaker, feeParams)
+	feeReceiver, err := k.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product)
+	if err == nil {
+		order.RecordOrderDealFee(dealFee)
+	}
+	return
 }
 
+// DropExpiredOrdersByBlockHeight expires the open orders placed at the specified block height
 func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeight int64) {
 	logger := ctx.Logger().With("module", "order")
 	store := ctx.KVStore(k.orderStoreKey)
@@ -109,9 +236,24 @@ func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeig
 	for ; iter.Valid(); iter.Next() {
 		var order types.Order
 		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &order)
-		if order.Status == types.OrderStatusOpen && !k.IsProductLocked(ctx, order.Product) {
+		if (order.Status == types.OrderStatusOpen || order.Status == types.OrderStatusUntriggered) &&
+			!k.IsProductLocked(ctx, order.Product) {
 			k.ExpireOrder(ctx, &order, logger)
 			logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
 		}
 	}
 }
+
+// DropExpiredOrdersByOrderIDs expires the specified open or untriggered orders, it's used for the good-til-block
+// orders
+func (k Keeper) DropExpiredOrdersByOrderIDs(ctx sdk.Context, orderIDs []string) {
+	logger := ctx.Logger().With("module", "order")
+	for _, orderID := range orderIDs {
+		order := k.GetOrder(ctx, orderID)
+		if order != nil && (order.Status == types.OrderStatusOpen || order.Status == types.OrderStatusUntriggered) &&
+			!k.IsProductLocked(ctx, order.Product) {
+			k.ExpireOrder(ctx, order, logger)
+			logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okchain/x/order/types"
+)
+
+// GetOrderRent returns the prepaid order rent balance of the address
+func (k Keeper) GetOrderRent(ctx sdk.Context, addr sdk.AccAddress) (rent types.OrderRent, found bool) {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.GetOrderRentKey(addr))
+	if bz == nil {
+		return rent, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &rent)
+	return rent, true
+}
+
+// SetOrderRent sets the prepaid order rent balance of the address
+func (k Keeper) SetOrderRent(ctx sdk.Context, rent types.OrderRent) {
+	ctx.KVStore(k.orderStoreKey).Set(types.GetOrderRentKey(rent.Address), k.cdc.MustMarshalBinaryBare(rent))
+}
+
+func (k Keeper) deleteOrderRent(ctx sdk.Context, addr sdk.AccAddress) {
+	ctx.KVStore(k.orderStoreKey).Delete(types.GetOrderRentKey(addr))
+}
+
+// GetAllOrderRents returns the prepaid order rent balances of all the addresses
+func (k Keeper) GetAllOrderRents(ctx sdk.Context) (rents []types.OrderRent) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.orderStoreKey), types.OrderRentKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var rent types.OrderRent
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &rent)
+		rents = append(rents, rent)
+	}
+	return rents
+}
+
+// DepositOrderRent transfers the amount from the address into the order module account, and tops up its
+// prepaid order rent balance
+func (k Keeper) DepositOrderRent(ctx sdk.Context, addr sdk.AccAddress, amount sdk.DecCoin) sdk.Error {
+	rentPerBlock := k.GetParams(ctx).OrderRentPerBlock
+	if amount.Denom != rentPerBlock.Denom {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("order rent must be paid in %s, got %s",
+			rentPerBlock.Denom, amount.Denom))
+	}
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName,
+		sdk.DecCoins{amount}); err != nil {
+		return err
+	}
+
+	rent, found := k.GetOrderRent(ctx, addr)
+	if !found {
+		rent = types.NewOrderRent(addr, sdk.NewDecCoinFromDec(amount.Denom, sdk.ZeroDec()))
+	}
+	rent.Balance = rent.Balance.Add(amount)
+	k.SetOrderRent(ctx, rent)
+	return nil
+}
+
+// WithdrawOrderRent transfers the amount from the prepaid order rent balance back to the address. The rent of the
+// resting rent orders is charged up to the last block first, and the unpaid ones are cancelled. The balance left
+// should afford the rent of the resting rent orders for one block
+func (k Keeper) WithdrawOrderRent(ctx sdk.Context, addr sdk.AccAddress, amount sdk.DecCoin) sdk.Error {
+	rent, found := k.GetOrderRent(ctx, addr)
+	if found {
+		unpaidNum := k.settleOrderRent(ctx, &rent, ctx.BlockHeight()-1)
+		k.SetOrderRent(ctx, rent)
+		if unpaidNum > 0 {
+			k.cancelRentOrders(ctx, addr, unpaidNum, ctx.Logger().With("module", "order"))
+			rent, _ = k.GetOrderRent(ctx, addr)
+		}
+	}
+	if !found || rent.Balance.Denom != amount.Denom || rent.Balance.IsLT(amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient order rent balance to withdraw %s", amount))
+	}
+	if required := k.GetParams(ctx).OrderRentPerBlock.Amount.MulInt64(rent.OrderNum); rent.OrderNum > 0 &&
+		rent.Balance.Amount.Sub(amount.Amount).LT(required) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("failed to withdraw %s, the order rent balance should keep "+
+			"%s%s for the rent of %d resting orders in one block", amount, required, rent.Balance.Denom, rent.OrderNum))
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr,
+		sdk.DecCoins{amount}); err != nil {
+		return err
+	}
+
+	rent.Balance = rent.Balance.Sub(amount)
+	k.SetOrderRent(ctx, rent)
+	return nil
+}
+
+// checkOrderRent checks that the prepaid order rent balance of the sender affords the rent of its resting rent
+// orders and the new order for at least one block
+func (k Keeper) checkOrderRent(ctx sdk.Context, order *types.Order) error {
+	rentPerBlock := k.GetParams(ctx).OrderRentPerBlock
+	rent, found := k.GetOrderRent(ctx, order.Sender)
+	if !found || rent.Balance.Denom != rentPerBlock.Denom ||
+		rent.Balance.Amount.LT(rentPerBlock.Amount.MulInt64(rent.OrderNum+1)) {
+		return fmt.Errorf("insufficient order rent balance, %s per block is required for each rent order",
+			rentPerBlock)
+	}
+	return nil
+}
+
+// updateRentOrderNum charges the rent of the resting rent orders of the address up to the last block, then updates
+// the number of them. It's called when a rent order is placed or closed, which pays no rent for the current block.
+// The orders may be in the middle of matching or expiring, so the unpaid rent orders are marked due and cancelled
+// by ChargeOrderRent at the end of the block
+func (k Keeper) updateRentOrderNum(ctx sdk.Context, addr sdk.AccAddress, delta int64) {
+	rent, found := k.GetOrderRent(ctx, addr)
+	if !found {
+		rentPerBlock := k.GetParams(ctx).OrderRentPerBlock
+		rent = types.NewOrderRent(addr, sdk.NewDecCoinFromDec(rentPerBlock.Denom, sdk.ZeroDec()))
+	}
+	if unpaidNum := k.settleOrderRent(ctx, &rent, ctx.BlockHeight()-1); unpaidNum > 0 {
+		k.addOrderRentDue(ctx, addr, unpaidNum)
+	}
+	rent.OrderNum += delta
+	k.SetOrderRent(ctx, rent)
+}
+
+// addOrderRentDue records that the number of the resting rent orders of the address are unpaid
+func (k Keeper) addOrderRentDue(ctx sdk.Context, addr sdk.AccAddress, unpaidNum int64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	var dueNum int64
+	if bz := store.Get(types.GetOrderRentDueKey(addr)); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &dueNum)
+	}
+	store.Set(types.GetOrderRentDueKey(addr), k.cdc.MustMarshalBinaryBare(dueNum+unpaidNum))
+}
+
+// chargeDueOrderRents charges the rent of the addresses marked due up to the current block, and cancels their
+// unpaid rent orders
+func (k Keeper) chargeDueOrderRents(ctx sdk.Context, logger log.Logger) {
+	store := ctx.KVStore(k.orderStoreKey)
+	dueNums := make(map[string]int64)
+	var addrs []sdk.AccAddress
+	iter := sdk.KVStorePrefixIterator(store, types.OrderRentDueKey)
+	for ; iter.Valid(); iter.Next() {
+		addr := sdk.AccAddress(iter.Key()[len(types.OrderRentDueKey):])
+		var dueNum int64
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &dueNum)
+		dueNums[addr.String()] = dueNum
+		addrs = append(addrs, addr)
+	}
+	iter.Close()
+
+	for _, addr := range addrs {
+		store.Delete(types.GetOrderRentDueKey(addr))
+		rent, found := k.GetOrderRent(ctx, addr)
+		if !found {
+			continue
+		}
+		unpaidNum := k.settleOrderRent(ctx, &rent, ctx.BlockHeight())
+		k.SetOrderRent(ctx, rent)
+		if dueNum := dueNums[addr.String()]; dueNum > unpaidNum {
+			unpaidNum = dueNum
+		}
+		k.cancelRentOrders(ctx, addr, unpaidNum, logger)
+	}
+}
+
+// settleOrderRent charges the rent of the resting rent orders of the address for the blocks up to the height, and
+// returns the number of the orders whose rent the balance can't afford. The rent of those orders is not charged
+func (k Keeper) settleOrderRent(ctx sdk.Context, rent *types.OrderRent, height int64) (unpaidNum int64) {
+	blocks := height - rent.ChargedHeight
+	if blocks <= 0 {
+		return 0
+	}
+	rent.ChargedHeight = height
+	rentPerBlock := k.GetParams(ctx).OrderRentPerBlock
+	if rent.OrderNum <= 0 || rentPerBlock.Amount.IsNil() || !rentPerBlock.IsPositive() {
+		return 0
+	}
+
+	// the rent of a single order for the blocks
+	orderRent := rentPerBlock.Amount.MulInt64(blocks)
+	paidNum := rent.OrderNum
+	if rent.Balance.Denom != rentPerBlock.Denom {
+		paidNum = 0
+	} else if rent.Balance.Amount.LT(orderRent.MulInt64(paidNum)) {
+		paidNum = rent.Balance.Amount.Quo(orderRent).TruncateInt64()
+	}
+
+	cost := sdk.NewDecCoinFromDec(rentPerBlock.Denom, orderRent.MulInt64(paidNum))
+	if cost.IsPositive() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName,
+			sdk.DecCoins{cost}); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to charge order rent %s of %s: %v", cost, rent.Address, err))
+			return rent.OrderNum
+		}
+		k.AddFeeDetail(ctx, rent.Address, sdk.DecCoins{cost}, types.FeeTypeOrderRent)
+		rent.Balance = rent.Balance.Sub(cost)
+	}
+	return rent.OrderNum - paidNum
+}
+
+// ChargeOrderRent cancels the unpaid rent orders found in the block, then visits at most
+// MaxOrderRentChargesPerBlock prepaid balances after the ones visited in the last block, and charges the rent of
+// their resting rent orders up to the current block. The newest rent orders whose rent the balance can't afford are
+// cancelled, while the rest of the balance is left to the address
+func (k Keeper) ChargeOrderRent(ctx sdk.Context, logger log.Logger) {
+	k.chargeDueOrderRents(ctx, logger)
+
+	rentPerBlock := k.GetParams(ctx).OrderRentPerBlock
+	if rentPerBlock.Amount.IsNil() || !rentPerBlock.IsPositive() {
+		return
+	}
+
+	rents := k.getNextOrderRents(ctx, types.MaxOrderRentChargesPerBlock)
+	for _, rent := range rents {
+		unpaidNum := k.settleOrderRent(ctx, &rent, ctx.BlockHeight())
+		if rent.OrderNum == 0 && !rent.Balance.IsPositive() {
+			k.deleteOrderRent(ctx, rent.Address)
+			continue
+		}
+		k.SetOrderRent(ctx, rent)
+		if unpaidNum > 0 {
+			k.cancelRentOrders(ctx, rent.Address, unpaidNum, logger)
+		}
+	}
+	if len(rents) > 0 {
+		ctx.KVStore(k.orderStoreKey).Set(types.OrderRentCursorKey, rents[len(rents)-1].Address)
+	}
+}
+
+// getNextOrderRents returns at most limit prepaid balances following the one visited last, wrapping around to the
+// first ones
+func (k Keeper) getNextOrderRents(ctx sdk.Context, limit int) (rents []types.OrderRent) {
+	store := ctx.KVStore(k.orderStoreKey)
+	start := types.OrderRentKey
+	if cursor := store.Get(types.OrderRentCursorKey); cursor != nil {
+		start = append(types.GetOrderRentKey(cursor), 0)
+	}
+
+	collect := func(iter sdk.Iterator) {
+		defer iter.Close()
+		for ; iter.Valid() && len(rents) < limit; iter.Next() {
+			var rent types.OrderRent
+			k.cdc.MustUnmarshalBinaryBare(iter.Value(), &rent)
+			rents = append(rents, rent)
+		}
+	}
+	collect(store.Iterator(start, sdk.PrefixEndBytes(types.OrderRentKey)))
+	collect(store.Iterator(types.OrderRentKey, start))
+	return rents
+}
+
+// cancelRentOrders cancels the newest num rent orders of the address
+func (k Keeper) cancelRentOrders(ctx sdk.Context, addr sdk.AccAddress, num int64, logger log.Logger) {
+	orderIDs := k.GetSenderOpenOrderIDs(ctx, addr)
+	seqs := make(map[string][2]int64, len(orderIDs))
+	for _, orderID := range orderIDs {
+		var height, num int64
+		if _, err := fmt.Sscanf(orderID, "ID%d-%d", &height, &num); err == nil {
+			seqs[orderID] = [2]int64{height, num}
+		}
+	}
+	sort.Slice(orderIDs, func(i, j int) bool {
+		seqI, seqJ := seqs[orderIDs[i]], seqs[orderIDs[j]]
+		return seqI[0] > seqJ[0] || seqI[0] == seqJ[0] && seqI[1] > seqJ[1]
+	})
+	for _, orderID := range orderIDs {
+		if num <= 0 {
+			return
+		}
+		order := k.GetOrder(ctx, orderID)
+		// the order of a locked product is cancelled in the later blocks
+		if order == nil || !order.IsRentOrder() || k.IsProductLocked(ctx, order.Product) {
+			continue
+		}
+		k.CancelOrder(ctx, order, logger)
+		num--
+		logger.Info(fmt.Sprintf("order(%s) is cancelled since the order rent balance of %s runs out",
+			order.OrderID, addr))
+	}
+}
@@ -0,0 +1,242 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/types"
+)
+
+func TestKeeper_OrderRent(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	addr0, addr1 := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	invariant := OrderRentInvariant(keeper)
+
+	params := types.DefaultTestParams()
+	params.OrderRentPerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.1"))
+	keeper.SetParams(ctx, &params)
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.NoError(t, err)
+
+	okt := func(amount string) sdk.DecCoin {
+		return sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(amount))
+	}
+	newRentOrder := func(sender sdk.AccAddress, price string) *types.Order {
+		order := mockOrder("", types.TestTokenPair, types.BuyOrder, price, "1.0")
+		order.Sender = sender
+		order.FeePerBlock = okt("0")
+		order.RecordOrderRent(params.OrderRentPerBlock)
+		return order
+	}
+
+	// deposit in the denom other than the rent one
+	err = keeper.DepositOrderRent(ctx, addr0, sdk.NewDecCoinFromDec(common.TestToken, sdk.OneDec()))
+	require.Error(t, err)
+	_, found := keeper.GetOrderRent(ctx, addr0)
+	require.False(t, found)
+
+	balance := testInput.TokenKeeper.GetCoins(ctx, addr0).AmountOf(common.NativeToken)
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr0, okt("0.35")))
+	rent, found := keeper.GetOrderRent(ctx, addr0)
+	require.True(t, found)
+	require.Equal(t, okt("0.35"), rent.Balance)
+	require.Equal(t, balance.Sub(sdk.MustNewDecFromStr("0.35")),
+		testInput.TokenKeeper.GetCoins(ctx, addr0).AmountOf(common.NativeToken))
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	// no fee is locked for the rent orders
+	order1, order2 := newRentOrder(addr0, "10.0"), newRentOrder(addr0, "11.0")
+	require.NoError(t, keeper.PlaceOrder(ctx, order1))
+	require.NoError(t, keeper.PlaceOrder(ctx, order2))
+	require.True(t, GetOrderNewFee(order1).IsZero())
+	rent, _ = keeper.GetOrderRent(ctx, addr0)
+	require.EqualValues(t, 2, rent.OrderNum)
+
+	// the sender without any order rent balance
+	require.Error(t, keeper.PlaceOrder(ctx, newRentOrder(addr1, "10.0")))
+
+	// the rent of 2 orders is charged
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	rent, _ = keeper.GetOrderRent(ctx, addr0)
+	require.Equal(t, okt("0.15"), rent.Balance)
+	require.EqualValues(t, 10, rent.ChargedHeight)
+	feeCollector := testInput.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.Equal(t, "0.20000000"+common.NativeToken, feeCollector.GetCoins().String())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// the balance affords only one order, the newest order is cancelled and the rest of the balance is kept
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	rent, found = keeper.GetOrderRent(ctx, addr0)
+	require.True(t, found)
+	require.Equal(t, okt("0.05"), rent.Balance)
+	require.EqualValues(t, 1, rent.OrderNum)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, order1.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, order2.OrderID).Status)
+	feeCollector = testInput.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.Equal(t, "0.30000000"+common.NativeToken, feeCollector.GetCoins().String())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// the balance left should afford the rent of the resting order for one block
+	ctx = ctx.WithBlockHeight(12)
+	require.Error(t, keeper.WithdrawOrderRent(ctx, addr0, okt("0.01")))
+
+	// the balance runs out, the last rent order is cancelled
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	rent, found = keeper.GetOrderRent(ctx, addr0)
+	require.True(t, found)
+	require.Equal(t, okt("0.05"), rent.Balance)
+	require.EqualValues(t, 0, rent.OrderNum)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, order1.OrderID).Status)
+	require.Equal(t, 0, len(keeper.GetSenderOpenOrderIDs(ctx, addr0)))
+	feeCollector = testInput.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.Equal(t, "0.30000000"+common.NativeToken, feeCollector.GetCoins().String())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// the empty balance without any rent order is removed
+	require.NoError(t, keeper.WithdrawOrderRent(ctx, addr0, okt("0.05")))
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	_, found = keeper.GetOrderRent(ctx, addr0)
+	require.False(t, found)
+
+	// the rent is charged up to the last block when the order is closed
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr1, okt("1")))
+	order3 := newRentOrder(addr1, "10.0")
+	require.NoError(t, keeper.PlaceOrder(ctx, order3))
+	ctx = ctx.WithBlockHeight(15)
+	keeper.CancelOrder(ctx, order3, ctx.Logger())
+	rent, _ = keeper.GetOrderRent(ctx, addr1)
+	require.Equal(t, okt("0.7"), rent.Balance)
+	require.EqualValues(t, 0, rent.OrderNum)
+	require.EqualValues(t, 14, rent.ChargedHeight)
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// withdraw
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr1, okt("0.3")))
+	require.Error(t, keeper.WithdrawOrderRent(ctx, addr1, okt("1.1")))
+	require.Error(t, keeper.WithdrawOrderRent(ctx, addr0, okt("0.1")))
+	require.NoError(t, keeper.WithdrawOrderRent(ctx, addr1, okt("0.4")))
+	rent, _ = keeper.GetOrderRent(ctx, addr1)
+	require.Equal(t, okt("0.6"), rent.Balance)
+	require.Equal(t, []types.OrderRent{rent}, keeper.GetAllOrderRents(ctx))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// the order rent is not charged if disabled
+	require.NoError(t, keeper.PlaceOrder(ctx, newRentOrder(addr1, "10.0")))
+	params.OrderRentPerBlock = okt("0")
+	keeper.SetParams(ctx, &params)
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	rent, _ = keeper.GetOrderRent(ctx, addr1)
+	require.Equal(t, okt("0.6"), rent.Balance)
+}
+
+func TestKeeper_OrderRentDue(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	addr := testInput.TestAddrs[0]
+
+	params := types.DefaultTestParams()
+	params.OrderRentPerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.1"))
+	keeper.SetParams(ctx, &params)
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.NoError(t, err)
+
+	okt := func(amount string) sdk.DecCoin {
+		return sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(amount))
+	}
+	orders := make([]*types.Order, 2)
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr, okt("0.2")))
+	for i := range orders {
+		orders[i] = mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+		orders[i].Sender = addr
+		orders[i].FeePerBlock = okt("0")
+		orders[i].RecordOrderRent(params.OrderRentPerBlock)
+		require.NoError(t, keeper.PlaceOrder(ctx, orders[i]))
+	}
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	rent, _ := keeper.GetOrderRent(ctx, addr)
+	require.True(t, rent.Balance.IsZero())
+
+	// the rent of the last block is unpaid when an order is closed, the address is marked due
+	ctx = ctx.WithBlockHeight(12)
+	keeper.CancelOrder(ctx, orders[0], ctx.Logger())
+	require.NotNil(t, ctx.KVStore(keeper.orderStoreKey).Get(types.GetOrderRentDueKey(addr)))
+
+	// the unpaid order is cancelled at the end of the block, even if the balance affords the current block
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr, okt("1")))
+	keeper.ChargeOrderRent(ctx, ctx.Logger())
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.Nil(t, ctx.KVStore(keeper.orderStoreKey).Get(types.GetOrderRentDueKey(addr)))
+	rent, _ = keeper.GetOrderRent(ctx, addr)
+	require.Equal(t, okt("0.9"), rent.Balance)
+	require.EqualValues(t, 0, rent.OrderNum)
+	_, broken := OrderRentInvariant(keeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestKeeper_GetNextOrderRents(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	keeper.SetParams(ctx, &types.Params{OrderRentPerBlock: types.DefaultOrderRentPerBlock})
+
+	addrs := append(testInput.TestAddrs, sdk.AccAddress([]byte("order-rent-address-3")))
+	for _, addr := range addrs {
+		rent := types.NewOrderRent(addr, sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec()))
+		keeper.SetOrderRent(ctx, rent)
+	}
+	rents := keeper.GetAllOrderRents(ctx)
+	require.Equal(t, 3, len(rents))
+
+	// the balances are visited in turns, wrapping around to the first ones
+	require.Equal(t, rents[:2], keeper.getNextOrderRents(ctx, 2))
+	ctx.KVStore(keeper.orderStoreKey).Set(types.OrderRentCursorKey, rents[1].Address)
+	require.Equal(t, []types.OrderRent{rents[2], rents[0]}, keeper.getNextOrderRents(ctx, 2))
+	require.Equal(t, []types.OrderRent{rents[2], rents[0], rents[1]}, keeper.getNextOrderRents(ctx, 5))
+}
+
+func TestQuerier_OrderRent(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	addr := testInput.TestAddrs[0]
+	querier := NewQuerier(keeper)
+	keeper.SetParams(ctx, &types.Params{OrderRentPerBlock: types.DefaultOrderRentPerBlock})
+
+	// never deposited
+	res, err := querier(ctx, []string{types.QueryOrderRent, addr.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var rent types.OrderRent
+	keeper.cdc.MustUnmarshalJSON(res, &rent)
+	require.Equal(t, addr, rent.Address)
+	require.True(t, rent.Balance.IsZero())
+
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec())
+	require.NoError(t, keeper.DepositOrderRent(ctx, addr, amount))
+	res, err = querier(ctx, []string{types.QueryOrderRent, addr.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(res, &rent)
+	require.Equal(t, amount.String(), rent.Balance.String())
+
+	// invalid address
+	_, err = querier(ctx, []string{types.QueryOrderRent, fmt.Sprintf("%X", addr)}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{types.QueryOrderRent}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
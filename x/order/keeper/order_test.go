@@ -206,3 +206,136 @@ func TestPlaceOrderAndExpireOrder(t *testing.T) {
 	require.EqualValues(t, 0, keeper.diskCache.openNum)
 	require.EqualValues(t, 1, keeper.cache.expireNum)
 }
+
+func TestFillOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	// mock orders, DepthBook, and orderIDsMap
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "3.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.2", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[0]
+	orders[2].Sender = testInput.TestAddrs[1]
+	orders[3].Sender = testInput.TestAddrs[1]
+
+	for i := 0; i < 4; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.EqualValues(t, nil, err)
+	}
+
+	fillPrice := sdk.NewDec(10.0)
+	fillQuantity := sdk.NewDec(1.0)
+	feeParams := types.DefaultTestParams()
+
+	for _, order := range orders {
+		retDeals := keeper.FillOrder(ctx, order, fillPrice, fillQuantity, false, &feeParams)
+		require.NotEmpty(t, retDeals)
+	}
+}
+
+func TestTransferTokens(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	buyer, seller := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	buyOrder := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0")
+	buyOrder.Sender = buyer
+	sellOrder := mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "3.0")
+	sellOrder.Sender = seller
+	require.Nil(t, keeper.PlaceOrder(ctx, buyOrder))
+	require.Nil(t, keeper.PlaceOrder(ctx, sellOrder))
+
+	coins := func(addr sdk.AccAddress) (sdk.DecCoins, sdk.DecCoins) {
+		return testInput.TokenKeeper.GetCoins(ctx, addr), testInput.TokenKeeper.GetLockedCoins(ctx, addr)
+	}
+	buyerCoins, buyerLocked := coins(buyer)
+	sellerCoins, sellerLocked := coins(seller)
+
+	// the buyer pays the quote tokens locked by the buy order and receives the base tokens, and vice versa
+	fillPrice := sdk.NewDec(10)
+	fillQuantity := sdk.NewDec(1)
+	keeper.balanceAccount(ctx, buyOrder, fillPrice, fillQuantity)
+	keeper.balanceAccount(ctx, sellOrder, fillPrice, fillQuantity)
+
+	baseCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, fillQuantity)}
+	quoteCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, fillPrice.Mul(fillQuantity))}
+	newBuyerCoins, newBuyerLocked := coins(buyer)
+	require.Equal(t, buyerCoins.Add(baseCoins).String(), newBuyerCoins.String())
+	require.Equal(t, buyerLocked.Sub(quoteCoins).String(), newBuyerLocked.String())
+	newSellerCoins, newSellerLocked := coins(seller)
+	require.Equal(t, sellerCoins.Add(quoteCoins).String(), newSellerCoins.String())
+	require.Equal(t, sellerLocked.Sub(baseCoins).String(), newSellerLocked.String())
+}
+
+func TestChargeFee(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+
+	keeper.ResetCache(ctx)
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.1"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.1"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[0]
+	orders[2].Sender = testInput.TestAddrs[1]
+	orders[3].Sender = testInput.TestAddrs[1]
+	for _, order := range orders {
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+	orders[1].Status = types.OrderStatusFilled
+	orders[2].Status = types.OrderStatusFilled
+
+	ctx = ctx.WithBlockHeight(12)
+	fillQuantity := sdk.NewDec(1.0)
+	feeParams := types.DefaultTestParams()
+	totalDealFee := sdk.DecCoins{}
+	for _, order := range orders {
+		senderCoins := testInput.TokenKeeper.GetCoins(ctx, order.Sender)
+		dealFee, feeReceiver := keeper.chargeFee(ctx, order, order.Price, fillQuantity, false, &feeParams)
+		require.False(t, dealFee.IsZero())
+		require.Equal(t, getDealFeeAtPrice(order, fillQuantity, order.Price, &feeParams).String(), dealFee.String())
+		// the deal fee goes to the handling fee address of the operator owning the token pair
+		require.Equal(t, tokenPair.Owner.String(), feeReceiver)
+		require.Equal(t, dealFee.String(), order.GetExtraInfoWithKey(types.OrderExtraInfoKeyDealFee))
+		totalDealFee = totalDealFee.Add(dealFee)
+
+		// a filled order unlocks the fee locked when placed, and pays the fee of the 2 blocks it stayed open
+		expectCoins := senderCoins.Sub(dealFee)
+		if order.Status == types.OrderStatusFilled {
+			expectCoins = expectCoins.Add(GetOrderNewFee(order)).Sub(GetOrderCostFee(order, ctx))
+		}
+		require.Equal(t, expectCoins.String(), testInput.TokenKeeper.GetCoins(ctx, order.Sender).String())
+	}
+	require.Equal(t, totalDealFee.String(), testInput.TokenKeeper.GetCoins(ctx, tokenPair.Owner).String())
+	feeCollector := testInput.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.Equal(t, GetOrderCostFee(orders[1], ctx).Add(GetOrderCostFee(orders[2], ctx)).String(),
+		feeCollector.GetCoins().String())
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/order/types"
 )
@@ -24,3 +26,23 @@ func (k Keeper) UnlockProduct(ctx sdk.Context, product string) {
 func (k Keeper) AnyProductLocked(ctx sdk.Context) bool {
 	return k.dexKeeper.IsAnyProductLocked(ctx)
 }
+
+// GetHaltedProducts returns the products halted by their price bands, sorted by product name
+func (k Keeper) GetHaltedProducts(ctx sdk.Context) []types.HaltedProduct {
+	halted := []types.HaltedProduct{}
+	for product, lock := range k.dexKeeper.GetLockedProductsCopy(ctx).Data {
+		if !lock.IsHalted() {
+			continue
+		}
+		halted = append(halted, types.HaltedProduct{
+			Product:       product,
+			BlockHeight:   lock.BlockHeight,
+			HaltEndHeight: lock.HaltEndHeight,
+			MatchPrice:    lock.Price,
+		})
+	}
+	sort.Slice(halted, func(i, j int) bool {
+		return halted[i].Product < halted[j].Product
+	})
+	return halted
+}
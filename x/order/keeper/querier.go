@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -14,6 +15,8 @@ import (
 // nolint
 const (
 	DefaultBookSize = 200
+	DefaultPage     = 1
+	DefaultPerPage  = 50
 )
 
 // NewQuerier is the module level router for state queries
@@ -31,6 +34,20 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 
 		case types.QueryDepthBookV2:
 			return queryDepthBookV2(ctx, path[1:], req, keeper)
+		case types.QueryTriggerBook:
+			return queryTriggerBook(ctx, path[1:], req, keeper)
+		case types.QueryHalted:
+			return queryHaltedProducts(ctx, keeper)
+		case types.QueryDepthBookDiff:
+			return queryDepthBookDiff(ctx, req, keeper)
+		case types.QueryBookL3:
+			return queryBookL3(ctx, req, keeper)
+		case types.QueryQueuePosition:
+			return queryQueuePosition(ctx, path[1:], keeper)
+		case types.QueryOpenOrders:
+			return queryOpenOrders(ctx, req, keeper)
+		case types.QueryOrderRent:
+			return queryOrderRent(ctx, path[1:], keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown order query endpoint")
 		}
@@ -48,6 +65,44 @@ func queryOrder(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	return bz, nil
 }
 
+// nolint: unparam
+func queryTriggerBook(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	product := path[0]
+	if keeper.GetDexKeeper().GetTokenPair(ctx, product) == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Non-exist product: %s", product))
+	}
+	orders := keeper.GetTriggerOrders(ctx, product)
+	if orders == nil {
+		orders = []*types.Order{}
+	}
+	bz := keeper.cdc.MustMarshalJSON(orders)
+	return bz, nil
+}
+
+// queryOrderRent returns the prepaid order rent balance of the address, a zero one if never deposited
+func queryOrderRent(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrInvalidAddress("address is empty")
+	}
+	addr, e := sdk.AccAddressFromBech32(path[0])
+	if e != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address %s", path[0]))
+	}
+	rent, found := keeper.GetOrderRent(ctx, addr)
+	if !found {
+		rentPerBlock := keeper.GetParams(ctx).OrderRentPerBlock
+		rent = types.NewOrderRent(addr, sdk.NewDecCoinFromDec(rentPerBlock.Denom, sdk.ZeroDec()))
+	}
+	bz := keeper.cdc.MustMarshalJSON(rent)
+	return bz, nil
+}
+
+func queryHaltedProducts(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	bz := keeper.cdc.MustMarshalJSON(keeper.GetHaltedProducts(ctx))
+	return bz, nil
+}
+
 // QueryDepthBookParams as input parameters when querying the depthBook
 type QueryDepthBookParams struct {
 	Product string
@@ -95,6 +150,13 @@ func queryDepthBook(ctx sdk.Context, path []string, req abci.RequestQuery, keepe
 	}
 	depthBook := keeper.GetDepthBookFromDB(ctx, params.Product)
 
+	bookRes := newBookRes(depthBook, params.Size)
+	bz := keeper.cdc.MustMarshalJSON(bookRes)
+	return bz, nil
+}
+
+// newBookRes returns the best levels of the depth book on each side
+func newBookRes(depthBook *types.DepthBook, size uint) BookRes {
 	var asks []BookResItem
 	var bids []BookResItem
 	for _, item := range depthBook.Items {
@@ -105,18 +167,287 @@ func queryDepthBook(ctx sdk.Context, path []string, req abci.RequestQuery, keepe
 			bids = append(bids, BookResItem{item.Price.String(), item.BuyQuantity.String()})
 		}
 	}
-	if uint(len(asks)) > params.Size {
-		asks = asks[:params.Size]
+	if uint(len(asks)) > size {
+		asks = asks[:size]
 	}
-	if uint(len(bids)) > params.Size {
-		bids = bids[:params.Size]
+	if uint(len(bids)) > size {
+		bids = bids[:size]
 	}
 
-	bookRes := BookRes{
+	return BookRes{
 		Asks: asks,
 		Bids: bids,
 	}
-	bz := keeper.cdc.MustMarshalJSON(bookRes)
+}
+
+// QueryDepthBookDiffParams as input parameters when querying the diffs of the depthBook
+type QueryDepthBookDiffParams struct {
+	Product  string
+	Sequence int64
+	Size     uint
+}
+
+// NewQueryDepthBookDiffParams creates a new instance of QueryDepthBookDiffParams
+func NewQueryDepthBookDiffParams(product string, sequence int64, size uint) QueryDepthBookDiffParams {
+	if size == 0 {
+		size = DefaultBookSize
+	}
+	return QueryDepthBookDiffParams{
+		Product:  product,
+		Sequence: sequence,
+		Size:     size,
+	}
+}
+
+// DepthBookDiffRes is used to return the result of queryDepthBookDiff.
+// Diffs are the changes after the queried sequence, if they are not available the Snapshot of the
+// best levels is returned instead. Checksum is calculated over the DepthBookChecksumLevels best levels
+// of the depth book at the Sequence, by DepthBook.Checksum.
+type DepthBookDiffRes struct {
+	Product  string                `json:"product"`
+	Sequence int64                 `json:"sequence"`
+	Diffs    []types.DepthBookDiff `json:"diffs"`
+	Snapshot *BookRes              `json:"snapshot,omitempty"`
+	Checksum uint32                `json:"checksum"`
+}
+
+func queryDepthBookDiff(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryDepthBookDiffParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(
+			sdk.AppendMsgToErr("incorrectly formatted request Data", err.Error()))
+	}
+	if params.Size == 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid param: size= %d", params.Size))
+	}
+	if keeper.GetDexKeeper().GetTokenPair(ctx, params.Product) == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Non-exist product: %s", params.Product))
+	}
+
+	depthBook := keeper.GetDepthBookFromDB(ctx, params.Product)
+	res := DepthBookDiffRes{
+		Product:  params.Product,
+		Sequence: keeper.GetDepthBookSequence(ctx, params.Product),
+		Diffs:    []types.DepthBookDiff{},
+		Checksum: depthBook.Checksum(types.DepthBookChecksumLevels),
+	}
+	// a zero sequence asks for the snapshot
+	if diffs, ok := keeper.GetDepthBookDiffs(ctx, params.Product, params.Sequence); ok && params.Sequence > 0 {
+		res.Diffs = diffs
+	} else {
+		bookRes := newBookRes(depthBook, params.Size)
+		res.Snapshot = &bookRes
+	}
+
+	bz := keeper.cdc.MustMarshalJSON(res)
+	return bz, nil
+}
+
+// QueryBookL3Params as input parameters when querying the open orders of a side of the depthBook
+type QueryBookL3Params struct {
+	Product string
+	Side    string
+	Page    int
+	PerPage int
+}
+
+// NewQueryBookL3Params creates a new instance of QueryBookL3Params
+func NewQueryBookL3Params(product, side string, page, perPage int) QueryBookL3Params {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QueryBookL3Params{
+		Product: product,
+		Side:    side,
+		Page:    page,
+		PerPage: perPage,
+	}
+}
+
+// BookL3Order is an open order in the queue of a price level
+type BookL3Order struct {
+	OrderID  string         `json:"order_id"`
+	Sender   sdk.AccAddress `json:"sender"`
+	Quantity sdk.Dec        `json:"quantity"`
+}
+
+// BookL3Level is a price level of a side of the depthBook, with the open orders in priority order
+type BookL3Level struct {
+	Price    sdk.Dec       `json:"price"`
+	Quantity sdk.Dec       `json:"quantity"`
+	Orders   []BookL3Order `json:"orders"`
+}
+
+// queryBookL3 returns a page of the price levels of a side from the best price, with their open orders
+func queryBookL3(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryBookL3Params
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(
+			sdk.AppendMsgToErr("incorrectly formatted request Data", err.Error()))
+	}
+	if params.Side != types.BuyOrder && params.Side != types.SellOrder {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Side should not be %s", params.Side))
+	}
+	if params.Page <= 0 || params.PerPage <= 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
+	}
+	if keeper.GetDexKeeper().GetTokenPair(ctx, params.Product) == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Non-exist product: %s", params.Product))
+	}
+
+	// buy levels from high price to low, sell levels from low price to high
+	depthBook := keeper.GetDepthBookFromDB(ctx, params.Product)
+	var prices []sdk.Dec
+	for i := range depthBook.Items {
+		item := depthBook.Items[i]
+		if params.Side == types.SellOrder {
+			item = depthBook.Items[len(depthBook.Items)-1-i]
+		}
+		if (params.Side == types.BuyOrder && item.BuyQuantity.IsPositive()) ||
+			(params.Side == types.SellOrder && item.SellQuantity.IsPositive()) {
+			prices = append(prices, item.Price)
+		}
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	var levels []BookL3Level
+	for i := offset; i < len(prices) && i < offset+limit; i++ {
+		level := BookL3Level{Price: prices[i], Quantity: sdk.ZeroDec(), Orders: []BookL3Order{}}
+		key := types.FormatOrderIDsKey(params.Product, prices[i], params.Side)
+		for _, orderID := range keeper.GetProductPriceOrderIDsFromDB(ctx, key) {
+			order := keeper.GetOrder(ctx, orderID)
+			if order == nil {
+				continue
+			}
+			level.Quantity = level.Quantity.Add(order.RemainQuantity)
+			level.Orders = append(level.Orders, BookL3Order{order.OrderID, order.Sender, order.RemainQuantity})
+		}
+		levels = append(levels, level)
+	}
+
+	var response *common.ListResponse
+	if len(levels) > 0 {
+		response = common.GetListResponse(len(prices), params.Page, params.PerPage, levels)
+	} else {
+		response = common.GetEmptyListResponse(len(prices), params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+// QueuePositionRes is used to return the position of an open order in the queue of its price level
+type QueuePositionRes struct {
+	OrderID       string  `json:"order_id"`
+	Product       string  `json:"product"`
+	Side          string  `json:"side"`
+	Price         sdk.Dec `json:"price"`
+	Position      int     `json:"position"` // 1 for the first order to be filled at the price
+	QuantityAhead sdk.Dec `json:"quantity_ahead"`
+	LevelOrders   int     `json:"level_orders"`
+}
+
+// nolint: unparam
+func queryQueuePosition(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("order id is empty")
+	}
+	order := keeper.GetOrder(ctx, path[0])
+	if order == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("order(%v) does not exist", path[0]))
+	}
+
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	orderIDs := keeper.GetProductPriceOrderIDsFromDB(ctx, key)
+	quantityAhead := sdk.ZeroDec()
+	for i, orderID := range orderIDs {
+		if orderID == order.OrderID {
+			res := QueuePositionRes{
+				OrderID:       order.OrderID,
+				Product:       order.Product,
+				Side:          order.Side,
+				Price:         order.Price,
+				Position:      i + 1,
+				QuantityAhead: quantityAhead,
+				LevelOrders:   len(orderIDs),
+			}
+			bz := keeper.cdc.MustMarshalJSON(res)
+			return bz, nil
+		}
+		if aheadOrder := keeper.GetOrder(ctx, orderID); aheadOrder != nil {
+			quantityAhead = quantityAhead.Add(aheadOrder.RemainQuantity)
+		}
+	}
+	return nil, sdk.ErrUnknownRequest(fmt.Sprintf("order(%v) is not in the depth book", order.OrderID))
+}
+
+// QueryOpenOrdersParams as input parameters when querying the open orders of an address
+type QueryOpenOrdersParams struct {
+	Address sdk.AccAddress
+	Product string
+	Page    int
+	PerPage int
+}
+
+// NewQueryOpenOrdersParams creates a new instance of QueryOpenOrdersParams
+func NewQueryOpenOrdersParams(address sdk.AccAddress, product string, page, perPage int) QueryOpenOrdersParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QueryOpenOrdersParams{
+		Address: address,
+		Product: product,
+		Page:    page,
+		PerPage: perPage,
+	}
+}
+
+// queryOpenOrders returns a page of the open and untriggered orders of the address from the sender index,
+// optionally filtered by product
+func queryOpenOrders(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryOpenOrdersParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(
+			sdk.AppendMsgToErr("incorrectly formatted request Data", err.Error()))
+	}
+	if params.Address.Empty() {
+		return nil, sdk.ErrInvalidAddress("address is empty")
+	}
+	if params.Page <= 0 || params.PerPage <= 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
+	}
+
+	var orders []*types.Order
+	for _, orderID := range keeper.GetSenderOpenOrderIDs(ctx, params.Address) {
+		order := keeper.GetOrder(ctx, orderID)
+		if order == nil || (params.Product != "" && order.Product != params.Product) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	var response *common.ListResponse
+	if offset < len(orders) {
+		end := offset + limit
+		if end > len(orders) {
+			end = len(orders)
+		}
+		response = common.GetListResponse(len(orders), params.Page, params.PerPage, orders[offset:end])
+	} else {
+		response = common.GetEmptyListResponse(len(orders), params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
 	return bz, nil
 }
 
@@ -194,27 +525,7 @@ func queryDepthBookV2(ctx sdk.Context, path []string, req abci.RequestQuery, kee
 	}
 	depthBook := keeper.GetDepthBookFromDB(ctx, params.Product)
 
-	var asks []BookResItem
-	var bids []BookResItem
-	for _, item := range depthBook.Items {
-		if item.SellQuantity.IsPositive() {
-			asks = append([]BookResItem{{item.Price.String(), item.SellQuantity.String()}}, asks...)
-		}
-		if item.BuyQuantity.IsPositive() {
-			bids = append(bids, BookResItem{item.Price.String(), item.BuyQuantity.String()})
-		}
-	}
-	if uint(len(asks)) > params.Size {
-		asks = asks[:params.Size]
-	}
-	if uint(len(bids)) > params.Size {
-		bids = bids[:params.Size]
-	}
-
-	bookRes := BookRes{
-		Asks: asks,
-		Bids: bids,
-	}
+	bookRes := newBookRes(depthBook, params.Size)
 
 	res, err := common.JSONMarshalV2(bookRes)
 	if err != nil {
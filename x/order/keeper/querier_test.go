@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/json"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -177,6 +178,179 @@ func TestQueryDepthBook(t *testing.T) {
 	require.NotNil(t, bookResBytes)
 }
 
+func TestQueryDepthBookDiff(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	querier := NewQuerier(keeper)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	product := types.TestTokenPair
+
+	// block 10: sequence 1
+	keeper.ResetCache(ctx)
+	order := mockOrder("", product, types.BuyOrder, "9.9", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	order = mockOrder("", product, types.SellOrder, "10.1", "1.0")
+	order.Sender = testInput.TestAddrs[1]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	keeper.Cache2Disk(ctx)
+	require.EqualValues(t, 1, keeper.GetDepthBookSequence(ctx, product))
+
+	// block 11: sequence 2
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	order = mockOrder("", product, types.BuyOrder, "9.9", "2.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	keeper.Cache2Disk(ctx)
+	require.EqualValues(t, 2, keeper.GetDepthBookSequence(ctx, product))
+
+	query := func(sequence int64) DepthBookDiffRes {
+		params := NewQueryDepthBookDiffParams(product, sequence, 0)
+		bz, err := querier(ctx, []string{types.QueryDepthBookDiff},
+			abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+		var res DepthBookDiffRes
+		keeper.cdc.MustUnmarshalJSON(bz, &res)
+		return res
+	}
+	checksum := keeper.GetDepthBookFromDB(ctx, product).Checksum(types.DepthBookChecksumLevels)
+
+	// diffs after sequence 1
+	res := query(1)
+	require.EqualValues(t, 2, res.Sequence)
+	require.Nil(t, res.Snapshot)
+	require.Equal(t, checksum, res.Checksum)
+	require.Len(t, res.Diffs, 1)
+	require.EqualValues(t, 2, res.Diffs[0].Sequence)
+	require.EqualValues(t, 11, res.Diffs[0].BlockHeight)
+	require.EqualValues(t, []types.DepthBookItem{{Price: sdk.MustNewDecFromStr("9.9"),
+		BuyQuantity: sdk.MustNewDecFromStr("3.0"), SellQuantity: sdk.ZeroDec()}}, res.Diffs[0].Items)
+
+	// up to date
+	res = query(2)
+	require.Nil(t, res.Snapshot)
+	require.Empty(t, res.Diffs)
+
+	// snapshot for zero or unknown sequence
+	for _, sequence := range []int64{0, 3} {
+		res = query(sequence)
+		require.Empty(t, res.Diffs)
+		require.NotNil(t, res.Snapshot)
+		require.Equal(t, checksum, res.Checksum)
+		require.EqualValues(t, []BookResItem{{sdk.MustNewDecFromStr("9.9").String(),
+			sdk.MustNewDecFromStr("3.0").String()}}, res.Snapshot.Bids)
+	}
+
+	// the diffs are kept in memory only, a restarted node returns the snapshot
+	keeper.depthBookDiffs.diffs = make(map[string][]types.DepthBookDiff)
+	res = query(1)
+	require.EqualValues(t, 2, res.Sequence)
+	require.Empty(t, res.Diffs)
+	require.NotNil(t, res.Snapshot)
+
+	// invalid product
+	params := NewQueryDepthBookDiffParams("invalid_product", 0, 0)
+	_, err = querier(ctx, []string{types.QueryDepthBookDiff},
+		abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.NotNil(t, err)
+}
+
+func TestQueryBookL3(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	querier := NewQuerier(keeper)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	product := types.TestTokenPair
+
+	keeper.ResetCache(ctx)
+	orders := []*types.Order{
+		mockOrder("", product, types.BuyOrder, "9.8", "1.0"),
+		mockOrder("", product, types.BuyOrder, "9.9", "2.0"),
+		mockOrder("", product, types.BuyOrder, "9.9", "3.0"),
+		mockOrder("", product, types.SellOrder, "10.1", "4.0"),
+	}
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[i%2]
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+	keeper.Cache2Disk(ctx)
+
+	query := func(side string, page, perPage int) (levels []BookL3Level, total int) {
+		params := NewQueryBookL3Params(product, side, page, perPage)
+		bz, err := querier(ctx, []string{types.QueryBookL3}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+		var res struct {
+			Data struct {
+				Data      []BookL3Level `json:"data"`
+				ParamPage struct {
+					Total int `json:"total"`
+				} `json:"param_page"`
+			} `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(bz, &res))
+		return res.Data.Data, res.Data.ParamPage.Total
+	}
+
+	// buy levels from the best price, orders in priority order
+	levels, total := query(types.BuyOrder, 0, 0)
+	require.Equal(t, 2, total)
+	require.Len(t, levels, 2)
+	require.Equal(t, sdk.MustNewDecFromStr("9.9"), levels[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("5.0"), levels[0].Quantity)
+	require.Equal(t, []BookL3Order{
+		{orders[1].OrderID, orders[1].Sender, orders[1].RemainQuantity},
+		{orders[2].OrderID, orders[2].Sender, orders[2].RemainQuantity},
+	}, levels[0].Orders)
+	require.Equal(t, sdk.MustNewDecFromStr("9.8"), levels[1].Price)
+
+	// pagination
+	levels, total = query(types.BuyOrder, 2, 1)
+	require.Equal(t, 2, total)
+	require.Len(t, levels, 1)
+	require.Equal(t, orders[0].OrderID, levels[0].Orders[0].OrderID)
+	levels, _ = query(types.BuyOrder, 3, 1)
+	require.Empty(t, levels)
+
+	levels, total = query(types.SellOrder, 1, 10)
+	require.Equal(t, 1, total)
+	require.Equal(t, orders[3].OrderID, levels[0].Orders[0].OrderID)
+
+	// invalid side and product
+	params := NewQueryBookL3Params(product, "", 1, 10)
+	_, err = querier(ctx, []string{types.QueryBookL3}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.NotNil(t, err)
+	params = NewQueryBookL3Params("invalid_product", types.BuyOrder, 1, 10)
+	_, err = querier(ctx, []string{types.QueryBookL3}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.NotNil(t, err)
+
+	// queue position
+	bz, err := querier(ctx, []string{types.QueryQueuePosition, orders[2].OrderID}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var position QueuePositionRes
+	keeper.cdc.MustUnmarshalJSON(bz, &position)
+	require.Equal(t, QueuePositionRes{
+		OrderID:       orders[2].OrderID,
+		Product:       product,
+		Side:          types.BuyOrder,
+		Price:         sdk.MustNewDecFromStr("9.9"),
+		Position:      2,
+		QuantityAhead: sdk.MustNewDecFromStr("2.0"),
+		LevelOrders:   2,
+	}, position)
+
+	_, err = querier(ctx, []string{types.QueryQueuePosition, "Non-existedID"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
 func TestQueryStore(t *testing.T) {
 	testInput := CreateTestInput(t)
 	keeper := testInput.OrderKeeper
@@ -225,10 +399,12 @@ func TestQueryParameters(t *testing.T) {
 	querier := NewQuerier(keeper)
 
 	params := &types.Params{
-		OrderExpireBlocks: 1000,
-		MaxDealsPerBlock:  10000,
-		FeePerBlock:       sdk.NewDecCoinFromDec(types.DefaultFeeDenomPerBlock, sdk.NewDec(1)),
-		TradeFeeRate:      sdk.MustNewDecFromStr("0.001"),
+		OrderExpireBlocks:   1000,
+		MaxDealsPerBlock:    10000,
+		FeePerBlock:         sdk.NewDecCoinFromDec(types.DefaultFeeDenomPerBlock, sdk.NewDec(1)),
+		TradeFeeRate:        sdk.MustNewDecFromStr("0.001"),
+		MarketOrderSlippage: sdk.MustNewDecFromStr("0.05"),
+		OrderRentPerBlock:   types.DefaultOrderRentPerBlock,
 	}
 	keeper.SetParams(ctx, params)
 	path := []string{types.QueryParameters}
@@ -251,3 +427,68 @@ func TestQueryInvalidPath(t *testing.T) {
 	require.NotNil(t, err)
 	require.EqualValues(t, sdk.CodeUnknownRequest, err.Code())
 }
+
+func TestQueryOpenOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	querier := NewQuerier(keeper)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	product := types.TestTokenPair
+
+	keeper.ResetCache(ctx)
+	orders := []*types.Order{
+		mockOrder("", product, types.BuyOrder, "9.8", "1.0"),
+		mockOrder("", product, types.SellOrder, "10.1", "2.0"),
+		mockOrder("", product, types.BuyOrder, "9.9", "3.0"),
+	}
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[i%2]
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+	keeper.Cache2Disk(ctx)
+
+	query := func(addr sdk.AccAddress, product string, page, perPage int) (orders []types.Order, total int) {
+		params := NewQueryOpenOrdersParams(addr, product, page, perPage)
+		bz, err := querier(ctx, []string{types.QueryOpenOrders}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+		var res struct {
+			Data struct {
+				Data      []types.Order `json:"data"`
+				ParamPage struct {
+					Total int `json:"total"`
+				} `json:"param_page"`
+			} `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(bz, &res))
+		return res.Data.Data, res.Data.ParamPage.Total
+	}
+
+	openOrders, total := query(testInput.TestAddrs[0], "", 0, 0)
+	require.Equal(t, 2, total)
+	require.Equal(t, orders[0].OrderID, openOrders[0].OrderID)
+	require.Equal(t, orders[2].OrderID, openOrders[1].OrderID)
+
+	// pagination and product filter
+	openOrders, total = query(testInput.TestAddrs[0], "", 2, 1)
+	require.Equal(t, 2, total)
+	require.Len(t, openOrders, 1)
+	require.Equal(t, orders[2].OrderID, openOrders[0].OrderID)
+	openOrders, _ = query(testInput.TestAddrs[0], "", 3, 1)
+	require.Empty(t, openOrders)
+	_, total = query(testInput.TestAddrs[0], "invalid_product", 1, 10)
+	require.Equal(t, 0, total)
+
+	// closed orders leave the index
+	keeper.CancelOrder(ctx, orders[1], ctx.Logger())
+	_, total = query(testInput.TestAddrs[1], "", 1, 10)
+	require.Equal(t, 0, total)
+
+	// empty address
+	params := NewQueryOpenOrdersParams(nil, product, 1, 10)
+	_, err = querier(ctx, []string{types.QueryOpenOrders}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.NotNil(t, err)
+}
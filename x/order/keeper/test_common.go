@@ -70,6 +70,7 @@ func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) Test
 
 	// order module
 	keyOrder := sdk.NewKVStoreKey(types.OrderStoreKey)
+	tkeyOrder := sdk.NewTransientStoreKey(types.TStoreKey)
 
 	// token module
 	keyToken := sdk.NewKVStoreKey(token.StoreKey)
@@ -87,6 +88,7 @@ func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) Test
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 
 	ms.MountStoreWithDB(keyOrder, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyOrder, sdk.StoreTypeTransient, db)
 
 	ms.MountStoreWithDB(keyToken, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyLock, sdk.StoreTypeIAVL, db)
@@ -112,6 +114,7 @@ func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) Test
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName: nil,
 		token.ModuleName:      {supply.Minter, supply.Burner},
+		types.ModuleName:      nil,
 	}
 	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
 	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.Coins{}))
@@ -130,7 +133,7 @@ func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) Test
 	// order keeper
 	orderKeeper := NewKeeper(tokenKeepr, supplyKeeper, dexKeeper,
 		paramsKeeper.Subspace(types.DefaultParamspace), auth.FeeCollectorName, keyOrder,
-		cdc, true, monitor.NopOrderMetrics())
+		tkeyOrder, cdc, true, monitor.NopOrderMetrics())
 
 	defaultParams := types.DefaultTestParams()
 	orderKeeper.SetParams(ctx, &defaultParams)
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/types"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// GetTradeVolume returns the traded volume (in quote asset) of the address on the product in the last
+// dex.FeeTierVolumeDays days, which decides the fee tier of the address
+func (k Keeper) GetTradeVolume(ctx sdk.Context, addr sdk.AccAddress, product string) sdk.Dec {
+	store := ctx.KVStore(k.orderStoreKey)
+	prefix := types.GetTradeVolumesKey(addr, product)
+	iter := store.Iterator(types.GetTradeVolumeKey(addr, product, getFirstVolumeDay(ctx)),
+		sdk.PrefixEndBytes(prefix))
+	defer iter.Close()
+
+	volume := sdk.ZeroDec()
+	for ; iter.Valid(); iter.Next() {
+		var dailyVolume sdk.Dec
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &dailyVolume)
+		volume = volume.Add(dailyVolume)
+	}
+	return volume
+}
+
+// addTradeVolume adds the volume to the address's traded volume of the product today.
+// The daily volumes out of the rolling window are pruned when the first deal of a day comes
+func (k Keeper) addTradeVolume(ctx sdk.Context, addr sdk.AccAddress, product string, volume sdk.Dec) {
+	store := ctx.KVStore(k.orderStoreKey)
+	key := types.GetTradeVolumeKey(addr, product, getVolumeDay(ctx))
+
+	dailyVolume := sdk.ZeroDec()
+	if bz := store.Get(key); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &dailyVolume)
+	} else {
+		k.pruneTradeVolumes(ctx, addr, product)
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(dailyVolume.Add(volume)))
+}
+
+func (k Keeper) pruneTradeVolumes(ctx sdk.Context, addr sdk.AccAddress, product string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := store.Iterator(types.GetTradeVolumesKey(addr, product),
+		types.GetTradeVolumeKey(addr, product, getFirstVolumeDay(ctx)))
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// getVolumeDay returns the number of days since the unix epoch of the block time
+func getVolumeDay(ctx sdk.Context) int64 {
+	day := ctx.BlockHeader().Time.Unix() / secondsPerDay
+	if day < 0 {
+		return 0
+	}
+	return day
+}
+
+// getFirstVolumeDay returns the first day of the rolling window of the traded volume
+func getFirstVolumeDay(ctx sdk.Context) int64 {
+	day := getVolumeDay(ctx) - dex.FeeTierVolumeDays + 1
+	if day < 0 {
+		return 0
+	}
+	return day
+}
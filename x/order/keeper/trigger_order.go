@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/types"
+)
+
+// PlaceTriggerOrder execute TryPlaceOrder, and set the conditional order into the trigger book.
+// The coins stay locked while the order is waiting to be triggered.
+func (k Keeper) PlaceTriggerOrder(ctx sdk.Context, order *types.Order) error {
+	fee, err := k.TryPlaceOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+	order.RecordOrderNewFee(fee)
+	k.AddFeeDetail(ctx, order.Sender, fee, types.FeeTypeOrderNew)
+
+	blockHeight := ctx.BlockHeight()
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	order.OrderID = types.FormatOrderID(blockHeight, orderNum+1)
+	order.Status = types.OrderStatusUntriggered
+
+	k.SetBlockOrderNum(ctx, blockHeight, orderNum+1)
+	k.SetOrder(ctx, order.OrderID, order)
+	k.InsertOrderIntoTriggerBook(ctx, order)
+	return nil
+}
+
+// InsertOrderIntoTriggerBook sets the untriggered order into the trigger book
+func (k Keeper) InsertOrderIntoTriggerBook(ctx sdk.Context, order *types.Order) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetTriggerOrderKey(order.Product, *order.TriggerPrice, order.OrderID), []byte(order.OrderID))
+	k.diskCache.addTriggerOrder()
+}
+
+// RemoveOrderFromTriggerBook removes the cancelled or expired untriggered order from the trigger book
+func (k Keeper) RemoveOrderFromTriggerBook(ctx sdk.Context, order *types.Order, feeType string) {
+	k.addUpdatedOrderID(order.OrderID)
+	if feeType == types.FeeTypeOrderCancel {
+		k.cache.IncreaseCancelNum()
+	} else if feeType == types.FeeTypeOrderExpire {
+		k.cache.IncreaseExpireNum()
+	}
+
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetTriggerOrderKey(order.Product, *order.TriggerPrice, order.OrderID))
+	k.diskCache.closeOrder(order.OrderID)
+}
+
+// GetTriggerOrders gets the untriggered orders of the product from the trigger book
+func (k Keeper) GetTriggerOrders(ctx sdk.Context, product string) []*types.Order {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetTriggerBookKey(product))
+	defer iter.Close()
+
+	var orders []*types.Order
+	for ; iter.Valid(); iter.Next() {
+		if order := k.GetOrder(ctx, string(iter.Value())); order != nil {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// GetTriggerBookProducts gets the products which have untriggered orders
+func (k Keeper) GetTriggerBookProducts(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.TriggerOrderKey)
+	defer iter.Close()
+
+	var products []string
+	for ; iter.Valid(); iter.Next() {
+		product := strings.SplitN(types.GetKey(iter), ":", 2)[0]
+		if len(products) == 0 || products[len(products)-1] != product {
+			products = append(products, product)
+		}
+	}
+	return products
+}
+
+// TriggerOrders converts the conditional orders of the product whose trigger prices are crossed by the last price
+// into regular limit orders, and inserts them into the depth book. It returns the triggered orders.
+func (k Keeper) TriggerOrders(ctx sdk.Context, product string) []*types.Order {
+	lastPrice := k.GetLastPrice(ctx, product)
+	store := ctx.KVStore(k.orderStoreKey)
+
+	var triggeredOrders []*types.Order
+	for _, order := range k.GetTriggerOrders(ctx, product) {
+		if !order.IsTriggered(lastPrice) {
+			continue
+		}
+		store.Delete(types.GetTriggerOrderKey(order.Product, *order.TriggerPrice, order.OrderID))
+
+		order.Status = types.OrderStatusOpen
+		k.SetOrder(ctx, order.OrderID, order)
+		k.addUpdatedOrderID(order.OrderID)
+		k.diskCache.insertOrderIntoDepthBook(order)
+		triggeredOrders = append(triggeredOrders, order)
+	}
+	return triggeredOrders
+}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerBookOrderedByPrice(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.NoError(t, err)
+
+	// the trigger prices are sorted numerically, e.g. 10.0 after 9.0, rather than by their strings
+	for _, triggerPrice := range []string{"10.0", "9.0", "100.0", "0.5", "9.5"} {
+		order := mockOrder("", types.TestTokenPair, types.SellOrder, "0.1", "0.1")
+		order.Sender = testInput.TestAddrs[0]
+		order.Type = types.TakeProfitOrder
+		price := sdk.MustNewDecFromStr(triggerPrice)
+		order.TriggerPrice = &price
+		require.NoError(t, keeper.PlaceTriggerOrder(ctx, order))
+	}
+
+	var triggerPrices []string
+	for _, order := range keeper.GetTriggerOrders(ctx, types.TestTokenPair) {
+		triggerPrices = append(triggerPrices, order.TriggerPrice.String())
+	}
+	require.Equal(t, []string{"0.50000000", "9.00000000", "9.50000000", "10.00000000", "100.00000000"}, triggerPrices)
+	require.Equal(t, []string{types.TestTokenPair}, keeper.GetTriggerBookProducts(ctx))
+
+	// removing the orders uses the same keys
+	for _, order := range keeper.GetTriggerOrders(ctx, types.TestTokenPair) {
+		keeper.RemoveOrderFromTriggerBook(ctx, order, types.FeeTypeOrderCancel)
+	}
+	require.Empty(t, keeper.GetTriggerOrders(ctx, types.TestTokenPair))
+}
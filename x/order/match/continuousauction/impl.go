@@ -4,13 +4,18 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine
 type CaEngine struct {
 }
 
-// nolint
+// Run does nothing, the orders of continuous auction products have been matched as soon as they were placed
 func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+}
+
+// MatchOrder matches the new order against the resting orders in the depth book
+func (e *CaEngine) MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) {
+	matchOrder(ctx, keeper, order)
 }
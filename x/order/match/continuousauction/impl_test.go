@@ -0,0 +1,162 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/dex"
+	orderkeeper "github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaEngine_MatchOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.AuctionType = dex.AuctionTypeContinuous
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// mock orders
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "2.5"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+
+	engine := &CaEngine{}
+	for i := 0; i < 3; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		engine.MatchOrder(ctx, keeper, orders[i])
+	}
+
+	// check order status
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, types.OrderStatusOpen, order1.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order1.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusFilled, order2.Status)
+	// filled at the maker prices: 0.5 * 9.0 + 0.5 * 10.0
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.5"), order2.FilledAvgPrice)
+
+	// check depth book
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), book.Items[0].Price)
+	require.True(t, book.Items[0].BuyQuantity.IsZero())
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), book.Items[0].SellQuantity)
+	require.EqualValues(t, []string{orders[1].OrderID},
+		keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, order1.Price, types.SellOrder)))
+	require.EqualValues(t, 0,
+		len(keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, order2.Price, types.BuyOrder))))
+
+	// check match result
+	result := keeper.GetBlockMatchResult().ResultMap[types.TestTokenPair]
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), result.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), result.Quantity)
+	require.EqualValues(t, 4, len(result.Deals))
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), result.Deals[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), result.Deals[3].Price)
+	// the last price is set once by the last deal
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// nothing to match in EndBlock
+	engine.Run(ctx, keeper)
+	require.EqualValues(t, 4, len(keeper.GetBlockMatchResult().ResultMap[types.TestTokenPair].Deals))
+}
+
+func TestCaEngine_MatchMarketOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.AuctionType = dex.AuctionTypeContinuous
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "2.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "11.0", "1.0"),
+		// market order at the protection price
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.5", "5.0"),
+	}
+	orders[3].Type = types.MarketOrder
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[1]
+	orders[3].Sender = testInput.TestAddrs[0]
+
+	engine := &CaEngine{}
+	for i := 0; i < 4; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		engine.MatchOrder(ctx, keeper, orders[i])
+	}
+
+	// the unfilled part of the market order is cancelled
+	order := keeper.GetOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order.RemainQuantity)
+	require.True(t, order.RemainLocked.IsZero())
+
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), book.Items[0].Price)
+	require.True(t, book.Items[0].BuyQuantity.IsZero())
+}
+
+func TestCaEngine_MatchTimeInForceOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.AuctionType = dex.AuctionTypeContinuous
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+	}
+	orders[2].TimeInForce = types.TimeInForceFOK
+	orders[3].TimeInForce = types.TimeInForceIOC
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+	orders[3].Sender = testInput.TestAddrs[0]
+
+	engine := &CaEngine{}
+	for i := 0; i < 4; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		engine.MatchOrder(ctx, keeper, orders[i])
+	}
+
+	// fill-or-kill order is killed because only 1.5 can be filled
+	order := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, order.Status)
+	require.True(t, order.RemainLocked.IsZero())
+
+	// immediate-or-cancel order is filled by 0.5 at price 9.0, the rest is cancelled
+	order = keeper.GetOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order.RemainQuantity)
+	require.True(t, order.RemainLocked.IsZero())
+
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), book.Items[0].Price)
+	require.True(t, book.Items[0].BuyQuantity.IsZero())
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), book.Items[0].SellQuantity)
+}
@@ -0,0 +1,182 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+// matchOrder matches the taker order against the resting maker orders by price-time priority:
+// the best price first, and the earliest order first at the same price. Every deal is executed
+// at the price of the maker order. The unfilled quantity of the taker order rests in the depth book,
+// unless the taker order is an immediate one.
+func matchOrder(ctx sdk.Context, k keeper.Keeper, taker *types.Order) {
+	logger := ctx.Logger().With("module", "order")
+	feeParams := k.GetParams(ctx)
+	product := taker.Product
+	makerSide := types.BuyOrder
+	if taker.Side == types.BuyOrder {
+		makerSide = types.SellOrder
+	}
+
+	// the taker order has been inserted into the depth book when placed, take it out while matching
+	book := k.GetDepthBookCopy(product)
+	book.RemoveOrder(taker)
+
+	// fill-or-kill order is killed without any deal if the makers cannot fill it in full
+	if taker.IsFillOrKill() && crossedQuantity(book, taker, makerSide).LT(taker.RemainQuantity) {
+		k.CancelOrder(ctx, taker, logger)
+		return
+	}
+
+	var deals []types.Deal
+	dealPrice := sdk.ZeroDec()
+	dealQuantity := sdk.ZeroDec()
+	for taker.RemainQuantity.IsPositive() {
+		index := bestMakerIndex(book, makerSide)
+		if index < 0 || !isCrossed(taker, book.Items[index].Price) {
+			break
+		}
+
+		price := book.Items[index].Price
+		levelDeals, filledQuantity := fillMakerOrders(ctx, k, taker, price, makerSide, feeParams)
+		if filledQuantity.IsZero() {
+			// depth book and orderIDsMap are out of sync, should never happen
+			logger.Error(fmt.Sprintf("no maker order filled at price level %s of %s", price, product))
+			break
+		}
+		deals = append(deals, levelDeals...)
+		dealPrice = price
+		dealQuantity = dealQuantity.Add(filledQuantity)
+
+		book.Sub(index, filledQuantity, makerSide)
+		book.RemoveIfEmpty(index)
+	}
+
+	if taker.Status == types.OrderStatusOpen {
+		// the rest of the taker order becomes a maker order
+		book.InsertOrder(taker)
+	} else {
+		removeOrderID(k, taker)
+	}
+	k.SetDepthBook(product, book)
+
+	// market, immediate-or-cancel and fill-or-kill orders never rest in the depth book
+	if taker.IsImmediateOrder() && taker.Status == types.OrderStatusOpen {
+		k.CancelOrder(ctx, taker, logger)
+	}
+
+	if len(deals) > 0 {
+		// the last price is the price of the last deal
+		k.SetLastPrice(ctx, product, dealPrice)
+		k.AddBlockMatchResult(ctx, product, types.MatchResult{
+			BlockHeight: ctx.BlockHeight(),
+			Price:       dealPrice,
+			Quantity:    dealQuantity,
+			Deals:       deals,
+		})
+		logger.Info(fmt.Sprintf("matchOrder(%d-%s): order: %s, lastPrice: %v, quantity: %v, dealsNum: %d",
+			ctx.BlockHeight(), product, taker.OrderID, dealPrice, dealQuantity, len(deals)))
+	}
+}
+
+// bestMakerIndex returns the index of the best price level on the maker side, -1 if there is none.
+// Items in depth book are sorted by price desc.
+func bestMakerIndex(book *types.DepthBook, makerSide string) int {
+	if makerSide == types.SellOrder {
+		for i := len(book.Items) - 1; i >= 0; i-- {
+			if book.Items[i].SellQuantity.IsPositive() {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i := 0; i < len(book.Items); i++ {
+		if book.Items[i].BuyQuantity.IsPositive() {
+			return i
+		}
+	}
+	return -1
+}
+
+// crossedQuantity returns the quantity of the maker orders whose prices are accepted by the taker order
+func crossedQuantity(book *types.DepthBook, taker *types.Order, makerSide string) sdk.Dec {
+	quantity := sdk.ZeroDec()
+	for _, item := range book.Items {
+		if !isCrossed(taker, item.Price) {
+			continue
+		}
+		if makerSide == types.SellOrder {
+			quantity = quantity.Add(item.SellQuantity)
+		} else {
+			quantity = quantity.Add(item.BuyQuantity)
+		}
+	}
+	return quantity
+}
+
+// isCrossed returns true if the taker order accepts the maker price
+func isCrossed(taker *types.Order, makerPrice sdk.Dec) bool {
+	if taker.Side == types.BuyOrder {
+		return taker.Price.GTE(makerPrice)
+	}
+	return taker.Price.LTE(makerPrice)
+}
+
+// fillMakerOrders fills the maker orders at the price level one by one until the taker order is fully filled,
+// returns the deals of both sides and the filled quantity
+func fillMakerOrders(ctx sdk.Context, k keeper.Keeper, taker *types.Order, price sdk.Dec, makerSide string,
+	feeParams *types.Params) ([]types.Deal, sdk.Dec) {
+
+	var deals []types.Deal
+	filledQuantity := sdk.ZeroDec()
+	key := types.FormatOrderIDsKey(taker.Product, price, makerSide)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+
+	index := 0
+	for index < len(orderIDs) && taker.RemainQuantity.IsPositive() {
+		maker := k.GetOrder(ctx, orderIDs[index])
+		if maker == nil {
+			ctx.Logger().Error("[Order] Not exist orderID: ", orderIDs[index])
+			index++
+			continue
+		}
+
+		fillQuantity := sdk.MinDec(maker.RemainQuantity, taker.RemainQuantity)
+		makerDeal := k.FillOrder(ctx, maker, price, fillQuantity, true, feeParams)
+		takerDeal := k.FillOrder(ctx, taker, price, fillQuantity, false, feeParams)
+		deals = append(deals, *makerDeal, *takerDeal)
+		filledQuantity = filledQuantity.Add(fillQuantity)
+
+		// a partially filled maker order keeps its position in the queue
+		if maker.Status == types.OrderStatusFilled {
+			index++
+		}
+	}
+
+	unfilledOrderIDs := orderIDs[index:]
+	// Note: orderIDs cannot be nil, we will use empty slice to remove Data on keeper
+	if len(unfilledOrderIDs) == 0 {
+		unfilledOrderIDs = []string{}
+	}
+	k.SetOrderIDs(key, unfilledOrderIDs)
+
+	return deals, filledQuantity
+}
+
+// removeOrderID removes the fully filled taker order from orderIDsMap
+func removeOrderID(k keeper.Keeper, order *types.Order) {
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+	unfilledOrderIDs := make([]string, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		if orderID != order.OrderID {
+			unfilledOrderIDs = append(unfilledOrderIDs, orderID)
+		}
+	}
+	k.SetOrderIDs(key, unfilledOrderIDs)
+}
@@ -1,38 +1,57 @@
 package match
 
 import (
-	"sync"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	dex "github.com/okex/okchain/x/dex/types"
 	"github.com/okex/okchain/x/order/keeper"
 	"github.com/okex/okchain/x/order/match/continuousauction"
 	"github.com/okex/okchain/x/order/match/periodicauction"
+	"github.com/okex/okchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = dex.DefaultAuctionType
 
-// nolint
+// engines are stateless, one instance for each auction type
 var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
+	engines = map[string]Engine{
+		dex.AuctionTypePeriodic:   &periodicauction.PaEngine{},
+		dex.AuctionTypeContinuous: &continuousauction.CaEngine{},
+	}
+
+	// the engines run in EndBlock in this fixed order to keep deterministic,
+	// periodic auction engine goes first because it cleans up the expired orders
+	auctionTypes = []string{dex.AuctionTypePeriodic, dex.AuctionTypeContinuous}
 )
 
-// GetEngine : periodic auction only today
-func GetEngine() Engine {
-	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
-		}
-	})
-	return engine
+// GetEngine returns the engine of the auction type, periodic auction engine as default
+func GetEngine(auctionType string) Engine {
+	if engine, ok := engines[auctionType]; ok {
+		return engine
+	}
+	return engines[DefaultAuctionType]
 }
 
-// nolint
+// Run runs all the engines in EndBlock.
+// The orders which don't fit the edited trading rules of their products are cancelled first. After the engines run,
+// the conditional orders crossed by the last prices, including the ones just set by the periodic auctions, are
+// triggered. The triggered orders of continuous auction products are matched right away, while the ones of periodic
+// auction products join the auction of the next block.
+func Run(ctx sdk.Context, keeper keeper.Keeper) {
+	cancelOrdersBreakingTradingRules(ctx, keeper)
+
+	for _, auctionType := range auctionTypes {
+		engines[auctionType].Run(ctx, keeper)
+	}
+
+	triggerOrders(ctx, keeper)
+}
+
+// Engine is the match engine of the products
 type Engine interface {
+	// Run is called in EndBlock
 	Run(ctx sdk.Context, keeper keeper.Keeper)
+	// MatchOrder is called in DeliverTx right after the order is placed
+	MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order)
 }
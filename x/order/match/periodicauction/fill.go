@@ -1,13 +1,9 @@
 package periodicauction
 
 import (
-	"fmt"
-	"strings"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	orderkeeper "github.com/okex/okchain/x/order/keeper"
 	"github.com/okex/okchain/x/order/types"
-	token "github.com/okex/okchain/x/token/types"
 )
 
 func fillBuyOrders(ctx sdk.Context, keeper orderkeeper.Keeper, product string,
@@ -156,14 +152,15 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 		}
 		if filledAmount.Add(order.RemainQuantity).LTE(needFillAmount) {
 			filledAmount = filledAmount.Add(order.RemainQuantity)
-			if deal := fillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams); deal != nil {
+			if deal := keeper.FillOrder(ctx, order, fillPrice, order.RemainQuantity, isMaker(ctx, order), feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 
 			filledDealsCnt++
 			index++
 		} else {
-			if deal := fillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams); deal != nil {
+			if deal := keeper.FillOrder(ctx, order, fillPrice, needFillAmount.Sub(filledAmount), isMaker(ctx, order),
+				feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 			filledAmount = needFillAmount
@@ -183,65 +180,8 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 	return deals, filledAmount, filledDealsCnt
 }
 
-func balanceAccount(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
-	fillPrice, fillQuantity sdk.Dec) {
-
-	symbols := strings.Split(order.Product, "_")
-	// transfer tokens
-	var outputCoins, inputCoins sdk.DecCoins
-	if order.Side == types.BuyOrder {
-		outputCoins = sdk.DecCoins{{Denom: symbols[1], Amount: fillPrice.Mul(fillQuantity)}}
-		inputCoins = sdk.DecCoins{{Denom: symbols[0], Amount: fillQuantity}}
-	} else {
-		outputCoins = sdk.DecCoins{{Denom: symbols[0], Amount: fillQuantity}}
-		inputCoins = sdk.DecCoins{{Denom: symbols[1], Amount: fillPrice.Mul(fillQuantity)}}
-	}
-	keeper.BalanceAccount(ctx, order.Sender, outputCoins, inputCoins)
-}
-
-func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, fillQuantity sdk.Dec,
-	feeParams *types.Params) (dealFee sdk.DecCoins, feeReceiver string) {
-	// charge fee
-	fee := orderkeeper.GetZeroFee()
-	if order.Status == types.OrderStatusFilled {
-		lockedFee := orderkeeper.GetOrderNewFee(order)
-		fee = orderkeeper.GetOrderCostFee(order, ctx)
-		receiveFee := lockedFee.Sub(fee)
-
-		keeper.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
-		keeper.AddFeeDetail(ctx, order.Sender, receiveFee, types.FeeTypeOrderReceive)
-		order.RecordOrderReceiveFee(receiveFee)
-
-		err := keeper.AddCollectedFees(ctx, fee, order.Sender, types.FeeTypeOrderNew, false)
-		if err != nil {
-			ctx.Logger().Error(fmt.Sprintf("Send fee failed:%s\n", err.Error()))
-		}
-	}
-	dealFee = orderkeeper.GetDealFee(order, fillQuantity, ctx, keeper, feeParams)
-	feeReceiver, err := keeper.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product)
-	if err == nil {
-		order.RecordOrderDealFee(fee)
-	}
-	return
-}
-
-// Fill an order. Update order, charge fee and transfer tokens. Return a deal.
-// If an order is fully filled but still lock some coins, unlock it.
-func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
-	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params) *types.Deal {
-
-	// update order
-	order.Fill(fillPrice, fillQuantity)
-
-	balanceAccount(order, ctx, keeper, fillPrice, fillQuantity)
-	// if fully filled and still need unlock coins
-	if order.Status == types.OrderStatusFilled && order.RemainLocked.IsPositive() {
-		needUnlockCoins := order.NeedUnlockCoins()
-		keeper.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
-		order.Unlock()
-	}
-
-	dealFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, feeParams)
-	keeper.UpdateOrder(order, ctx) // update order info on filled
-	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Quantity: fillQuantity, Fee: dealFee.String(), FeeReceiver: feeReceiver}
+// isMaker returns true if the order was resting in the order book before this block, which provided the
+// liquidity for the auction
+func isMaker(ctx sdk.Context, order *types.Order) bool {
+	return types.GetBlockHeightFromOrderID(order.OrderID) < ctx.BlockHeight()
 }
@@ -603,110 +603,3 @@ func TestFillOrderByKeyByNotExistKey(t *testing.T) {
 	require.EqualValues(t, filledAmount, sdk.ZeroDec())
 	require.EqualValues(t, filledDealsCnt, int64(0))
 }
-
-func TestFillOrder(t *testing.T) {
-	testInput := orderkeeper.CreateTestInput(t)
-	keeper := testInput.OrderKeeper
-	ctx := testInput.Ctx
-	tokenPair := dex.GetBuiltInTokenPair()
-	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
-	require.Nil(t, err)
-	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
-		Address:            tokenPair.Owner,
-		HandlingFeeAddress: tokenPair.Owner,
-	})
-
-	// mock orders, DepthBook, and orderIDsMap
-	orders := []*types.Order{
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0"),
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "3.0"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "10.2", "1.0"),
-	}
-	orders[0].Sender = testInput.TestAddrs[0]
-	orders[1].Sender = testInput.TestAddrs[0]
-	orders[2].Sender = testInput.TestAddrs[1]
-	orders[3].Sender = testInput.TestAddrs[1]
-
-	for i := 0; i < 4; i++ {
-		err := keeper.PlaceOrder(ctx, orders[i])
-		require.EqualValues(t, nil, err)
-	}
-
-	fillPrice := sdk.NewDec(10.0)
-	fillQuantity := sdk.NewDec(1.0)
-	feeParams := types.DefaultTestParams()
-
-	for _, order := range orders {
-		retDeals := fillOrder(order, ctx, keeper, fillPrice, fillQuantity, &feeParams)
-		require.NotEmpty(t, retDeals)
-	}
-}
-
-func TestTransferTokens(t *testing.T) {
-	testInput := orderkeeper.CreateTestInput(t)
-	keeper := testInput.OrderKeeper
-	ctx := testInput.Ctx
-	tokenPair := dex.GetBuiltInTokenPair()
-	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
-	require.Nil(t, err)
-
-	// mock orders, DepthBook, and orderIDsMap
-	orders := []*types.Order{
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0"),
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "3.0"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "10.2", "1.0"),
-	}
-	orders[0].Sender = testInput.TestAddrs[0]
-	orders[1].Sender = testInput.TestAddrs[0]
-	orders[2].Sender = testInput.TestAddrs[1]
-	orders[3].Sender = testInput.TestAddrs[1]
-
-	for i := 0; i < 4; i++ {
-		err := keeper.PlaceOrder(ctx, orders[i])
-		require.EqualValues(t, nil, err)
-	}
-
-	fillPrice := sdk.NewDec(10.0)
-	fillQuantity := sdk.NewDec(1.0)
-	for _, order := range orders {
-		balanceAccount(order, ctx, keeper, fillPrice, fillQuantity)
-	}
-}
-
-func TestChargeFee(t *testing.T) {
-	testInput := orderkeeper.CreateTestInput(t)
-	keeper := testInput.OrderKeeper
-	ctx := testInput.Ctx
-	tokenPair := dex.GetBuiltInTokenPair()
-	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
-	require.Nil(t, err)
-	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
-		Address:            tokenPair.Owner,
-		HandlingFeeAddress: tokenPair.Owner,
-	})
-
-	keeper.ResetCache(ctx)
-	orders := []*types.Order{
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0"),
-		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.1"),
-		mockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.1"),
-	}
-	orders[0].Sender = testInput.TestAddrs[0]
-	orders[1].Sender = testInput.TestAddrs[0]
-	orders[1].Status = types.OrderStatusFilled
-	orders[2].Sender = testInput.TestAddrs[1]
-	orders[2].Status = types.OrderStatusFilled
-	orders[3].Sender = testInput.TestAddrs[1]
-
-	fillQuantity := sdk.NewDec(1.0)
-	feeParams := types.DefaultTestParams()
-
-	for _, order := range orders {
-		retFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, &feeParams)
-		require.NotEmpty(t, retFee)
-		require.NotEmpty(t, feeReceiver)
-	}
-}
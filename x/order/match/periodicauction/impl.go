@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
 )
 
 // PaEngine is the periodic auction match engine
@@ -16,3 +17,7 @@ func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
 	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
 	matchOrders(ctx, keeper)
 }
+
+// MatchOrder does nothing, the orders of periodic auction products are matched together in EndBlock
+func (e *PaEngine) MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) {
+}
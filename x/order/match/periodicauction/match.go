@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	dex "github.com/okex/okchain/x/dex/types"
 	"github.com/okex/okchain/x/order/keeper"
 	"github.com/okex/okchain/x/order/types"
 )
@@ -171,6 +172,19 @@ func markCurBlockToFutureExpireBlockList(ctx sdk.Context, keeper keeper.Keeper)
 	futureExpireHeightList := keeper.GetExpireBlockHeight(ctx, futureHeight)
 	futureExpireHeightList = append(futureExpireHeightList, curBlockHeight)
 	keeper.SetExpireBlockHeight(ctx, futureHeight, futureExpireHeightList)
+
+	// good-til-block orders expire at their own height, which is not later than the future height above.
+	// They are registered whatever the status, the untriggered ones may wait in the trigger book till then
+	orderNum := keeper.GetBlockOrderNum(ctx, curBlockHeight)
+	for i := int64(1); i <= orderNum; i++ {
+		order := keeper.GetOrder(ctx, types.FormatOrderID(curBlockHeight, i))
+		if order == nil || order.TimeInForce != types.TimeInForceGTB {
+			continue
+		}
+		expireHeight := curBlockHeight + order.OrderExpireBlocks
+		expireOrderIDs := keeper.GetExpireOrderIDs(ctx, expireHeight)
+		keeper.SetExpireOrderIDs(ctx, expireHeight, append(expireOrderIDs, order.OrderID))
+	}
 }
 
 func cleanLastBlockClosedOrders(ctx sdk.Context, keeper keeper.Keeper) {
@@ -202,6 +216,7 @@ func cacheExpiredBlockToCurrentHeight(ctx sdk.Context, keeper keeper.Keeper) {
 			logger.Info(fmt.Sprintf("currentHeight(%d), expire orders at blockHeight(%d)",
 				curBlockHeight, expiredHeight))
 		}
+		keeper.DropExpiredOrdersByOrderIDs(ctx, keeper.GetExpireOrderIDs(ctx, height))
 	}
 
 	if !keeper.AnyProductLocked(ctx) {
@@ -215,6 +230,7 @@ func cacheExpiredBlockToCurrentHeight(ctx sdk.Context, keeper keeper.Keeper) {
 						curBlockHeight, expiredHeight))
 				}
 				keeper.DropExpireBlockHeight(ctx, height)
+				keeper.DropExpireOrderIDs(ctx, height)
 			}
 		}
 		keeper.SetLastExpiredBlockHeight(ctx, height)
@@ -229,6 +245,17 @@ func cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx sdk.Context, keeper keeper.
 			cleanupOrdersByProduct(ctx, keeper, product)
 		}
 	}
+
+	// untriggered orders are not in the depth book
+	for _, product := range keeper.GetTriggerBookProducts(ctx) {
+		if keeper.GetDexKeeper().GetTokenPair(ctx, product) == nil {
+			var orderIDList []string
+			for _, order := range keeper.GetTriggerOrders(ctx, product) {
+				orderIDList = append(orderIDList, order.OrderID)
+			}
+			cleanOrdersByOrderIDList(ctx, keeper, orderIDList)
+		}
+	}
 }
 
 func cleanupOrdersByProduct(ctx sdk.Context, keeper keeper.Keeper, product string) {
@@ -264,17 +291,32 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 
 func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
-	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
-	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
+	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	// no new orders or triggered orders in this block & no product lock in previous blocks, skip match
+	if len(products) == 0 && !keeper.AnyProductLocked(ctx) {
 		return
 	}
 
-	// step0: get active products
-	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	// step0: get active products, including the halted ones which are resumed in this block
+	resumedProducts := resumeHaltedProducts(ctx, keeper)
+	for _, product := range resumedProducts {
+		if !containsProduct(products, product) {
+			products = append(products, product)
+		}
+	}
 	products = keeper.FilterDelistedProducts(ctx, products)
+	products = filterPeriodicAuctionProducts(ctx, keeper, products)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
+	// step0.1: cancel or decrease the orders which would trade with the orders of the same sender
+	preventSelfTrades(ctx, keeper, products, keeper.GetParams(ctx).SelfTradePrevention)
+
+	// step0.2: kill the fill-or-kill orders which can only be partially filled
+	killFillOrKillOrders(ctx, keeper, blockHeight, products)
+
+	// step0.3: halt the products whose match prices break their price bands, they are not matched in this block
+	products = haltProductsOutOfPriceBand(ctx, keeper, products, resumedProducts)
+
 	// step1: calc best price and max execution for every active product, save latest price
 	//updatedProductsBaseprice := make(map[string]types.MatchResult)
 	updatedProductsBasePrice := calcMatchPriceAndExecution(ctx, keeper, products)
@@ -289,15 +331,63 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
 	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
 
+	// step2.1: market, immediate-or-cancel and fill-or-kill orders never rest in the depth book,
+	// cancel the unfilled ones
+	cancelUnfilledImmediateOrders(ctx, keeper, blockHeight, "")
+
 	// step3: save match results for querying
-	if len(updatedProductsBasePrice) > 0 {
-		blockMatchResult := &types.BlockMatchResult{
-			BlockHeight: blockHeight,
-			ResultMap:   updatedProductsBasePrice,
-			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+	// merged with the results of continuous auction products which were matched in DeliverTx
+	for _, product := range products {
+		if result, ok := updatedProductsBasePrice[product]; ok {
+			keeper.AddBlockMatchResult(ctx, product, result)
+		}
+	}
+}
+
+func containsProduct(products []string, product string) bool {
+	for _, p := range products {
+		if p == product {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPeriodicAuctionProducts drops the products matched by other engines.
+// The depth book of a product switched to continuous auction in this block may still be crossed,
+// it is matched by periodic auction for the last time.
+func filterPeriodicAuctionProducts(ctx sdk.Context, keeper keeper.Keeper, products []string) []string {
+	var periodicProducts []string
+	for _, product := range products {
+		tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil {
+			continue
+		}
+		if tokenPair.GetAuctionType() == dex.AuctionTypePeriodic || isDepthBookCrossed(keeper.GetDepthBookCopy(product)) {
+			periodicProducts = append(periodicProducts, product)
 		}
-		keeper.SetBlockMatchResult(blockMatchResult)
 	}
+	return periodicProducts
+}
+
+// isDepthBookCrossed returns true if the best buy price is not lower than the best sell price
+func isDepthBookCrossed(book *types.DepthBook) bool {
+	buyIndex := -1
+	for i := 0; i < len(book.Items); i++ {
+		if book.Items[i].BuyQuantity.IsPositive() {
+			buyIndex = i
+			break
+		}
+	}
+	sellIndex := -1
+	for i := len(book.Items) - 1; i >= 0; i-- {
+		if book.Items[i].SellQuantity.IsPositive() {
+			sellIndex = i
+			break
+		}
+	}
+	// items are sorted by price desc
+	return buyIndex >= 0 && sellIndex >= 0 && buyIndex <= sellIndex
 }
 
 func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []string) map[string]types.MatchResult {
@@ -372,14 +462,15 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 	feeParams *types.Params, blockRemainDeals int64, product string,
 	logger log.Logger) int64 {
 
-	if blockRemainDeals <= 0 {
-		return blockRemainDeals
-	}
-
 	// fill locked product
 	blockHeight := ctx.BlockHeight()
 	lock := lockMap.Data[product]
 
+	// halted products are not matched until the halts end
+	if blockRemainDeals <= 0 || lock.IsHalted() {
+		return blockRemainDeals
+	}
+
 	buyExecuted := lock.BuyExecuted
 	sellExecuted := lock.SellExecuted
 	deals, blockRemainDeals := fillDepthBook(ctx, k, product,
@@ -407,6 +498,7 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 		k.UnlockProduct(ctx, product)
 		logger.Info(fmt.Sprintf("BlockHeight<%d> unlock product(%s<%d>)", blockHeight,
 			product, lock.BlockHeight))
+		cancelUnfilledImmediateOrders(ctx, k, lock.BlockHeight, product)
 	} else {
 		// update product lock
 		k.SetProductLock(ctx, product, lock)
@@ -415,6 +507,109 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 	return blockRemainDeals
 }
 
+// cancelUnfilledImmediateOrders cancels the open market, immediate-or-cancel and fill-or-kill orders
+// placed at the block height.
+// Orders of the locked products are skipped, they are cancelled when the product is unlocked.
+// If product is not empty, only the orders of the product are cancelled.
+func cancelUnfilledImmediateOrders(ctx sdk.Context, k keeper.Keeper, blockHeight int64, product string) {
+	logger := ctx.Logger().With("module", "order")
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	for i := int64(1); i <= orderNum; i++ {
+		order := k.GetOrder(ctx, types.FormatOrderID(blockHeight, i))
+		if order == nil || !order.IsImmediateOrder() || order.Status != types.OrderStatusOpen {
+			continue
+		}
+		if (product != "" && order.Product != product) || k.IsProductLocked(ctx, order.Product) {
+			continue
+		}
+		k.CancelOrder(ctx, order, logger)
+	}
+}
+
+// killFillOrKillOrders cancels the fill-or-kill orders placed at the block height which would be partially filled
+// by the match of this block. Cancelling an order changes the match price, so the match price is recalculated
+// after each kill, until every fill-or-kill order left is either fully filled or not filled at all.
+func killFillOrKillOrders(ctx sdk.Context, k keeper.Keeper, blockHeight int64, products []string) {
+	fokOrders := make(map[string][]*types.Order)
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	for i := int64(1); i <= orderNum; i++ {
+		order := k.GetOrder(ctx, types.FormatOrderID(blockHeight, i))
+		if order != nil && order.IsFillOrKill() && order.Status == types.OrderStatusOpen {
+			fokOrders[order.Product] = append(fokOrders[order.Product], order)
+		}
+	}
+	if len(fokOrders) == 0 {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	for _, product := range products {
+		orders := fokOrders[product]
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if len(orders) == 0 || tokenPair == nil || k.IsProductLocked(ctx, product) {
+			continue
+		}
+
+		for {
+			book := k.GetDepthBookCopy(product)
+			bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product))
+
+			killedIndex := -1
+			for i, order := range orders {
+				filledQuantity := expectedFilledQuantity(ctx, k, book, order, bestPrice, maxExecution)
+				if filledQuantity.IsPositive() && filledQuantity.LT(order.RemainQuantity) {
+					killedIndex = i
+					break
+				}
+			}
+			if killedIndex < 0 {
+				break
+			}
+			k.CancelOrder(ctx, orders[killedIndex], logger)
+			orders = append(orders[:killedIndex], orders[killedIndex+1:]...)
+		}
+	}
+}
+
+// expectedFilledQuantity returns the quantity of the order to be filled at the match price.
+// The orders are filled in the same price-time priority as fillDepthBook does.
+func expectedFilledQuantity(ctx sdk.Context, k keeper.Keeper, book *types.DepthBook, order *types.Order,
+	bestPrice, maxExecution sdk.Dec) sdk.Dec {
+	aheadQuantity := sdk.ZeroDec()
+	for i := range book.Items {
+		// buy orders are filled from high price to low, sell orders are filled from low price to high
+		item := book.Items[i]
+		quantity := item.BuyQuantity
+		if order.Side == types.SellOrder {
+			item = book.Items[len(book.Items)-1-i]
+			quantity = item.SellQuantity
+		}
+		if (order.Side == types.BuyOrder && item.Price.LT(bestPrice)) ||
+			(order.Side == types.SellOrder && item.Price.GT(bestPrice)) ||
+			aheadQuantity.GTE(maxExecution) {
+			break
+		}
+		if !item.Price.Equal(order.Price) {
+			aheadQuantity = aheadQuantity.Add(quantity)
+			continue
+		}
+
+		// the orders at the same price are filled by time priority
+		key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+		for _, orderID := range k.GetProductPriceOrderIDs(key) {
+			if orderID == order.OrderID {
+				return sdk.MinDec(order.RemainQuantity, sdk.MaxDec(maxExecution.Sub(aheadQuantity), sdk.ZeroDec()))
+			}
+			if aheadOrder := k.GetOrder(ctx, orderID); aheadOrder != nil {
+				aheadQuantity = aheadQuantity.Add(aheadOrder.RemainQuantity)
+			}
+		}
+		break
+	}
+	return sdk.ZeroDec()
+}
+
 func executeMatch(ctx sdk.Context, k keeper.Keeper, products []string,
 	updatedProductsBasePrice map[string]types.MatchResult, lockMap *types.ProductLockMap) {
 	logger := ctx.Logger().With("module", "order")
@@ -518,6 +518,42 @@ func TestMatchOrders(t *testing.T) {
 	require.EqualValues(t, sdk.MustNewDecFromStr("2"), depthBook.Items[0].SellQuantity)
 }
 
+func TestFilterPeriodicAuctionProducts(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	for i := 0; i < 2; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.Nil(t, err)
+	}
+	products := []string{types.TestTokenPair}
+	require.EqualValues(t, products, filterPeriodicAuctionProducts(ctx, keeper, products))
+
+	// uncrossed depth book of continuous auction product
+	tokenPair.AuctionType = dex.AuctionTypeContinuous
+	testInput.DexKeeper.UpdateTokenPair(ctx, tokenPair.Name(), tokenPair)
+	require.False(t, isDepthBookCrossed(keeper.GetDepthBookCopy(types.TestTokenPair)))
+	require.EqualValues(t, 0, len(filterPeriodicAuctionProducts(ctx, keeper, products)))
+
+	// crossed depth book left by periodic auction, match it for the last time
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	err = keeper.PlaceOrder(ctx, order)
+	require.Nil(t, err)
+	require.True(t, isDepthBookCrossed(keeper.GetDepthBookCopy(types.TestTokenPair)))
+	require.EqualValues(t, products, filterPeriodicAuctionProducts(ctx, keeper, products))
+}
+
 func TestMatchOrdersByEmptyBlock(t *testing.T) {
 	testInput := orderkeeper.CreateTestInput(t)
 	keeper := testInput.OrderKeeper
@@ -0,0 +1,107 @@
+package periodicauction
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+// resumeHaltedProducts unlocks the products whose halts end at the current block height, and returns them.
+// The resumed products are matched in this block without the price band check, so that a new price can be
+// discovered by the reopening auction.
+func resumeHaltedProducts(ctx sdk.Context, k keeper.Keeper) []string {
+	logger := ctx.Logger().With("module", "order")
+	lockMap := k.GetDexKeeper().GetLockedProductsCopy(ctx)
+
+	var resumed []string
+	for product, lock := range lockMap.Data {
+		if lock.IsHalted() && lock.HaltEndHeight <= ctx.BlockHeight() {
+			resumed = append(resumed, product)
+		}
+	}
+	sort.Strings(resumed)
+
+	for _, product := range resumed {
+		k.UnlockProduct(ctx, product)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeProductResumed,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyProduct, product),
+		))
+		logger.Info(fmt.Sprintf("BlockHeight<%d> resume halted product(%s<%d>)", ctx.BlockHeight(),
+			product, lockMap.Data[product].BlockHeight))
+	}
+	return resumed
+}
+
+// haltProductsOutOfPriceBand halts the products whose match prices break their price bands around the last prices,
+// and returns the other products to be matched in this block. The resumed products are not checked.
+func haltProductsOutOfPriceBand(ctx sdk.Context, k keeper.Keeper, products []string, resumed []string) []string {
+	resumedSet := make(map[string]bool, len(resumed))
+	for _, product := range resumed {
+		resumedSet[product] = true
+	}
+
+	var matchProducts []string
+	for _, product := range products {
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil || !tokenPair.GetPriceBand().IsPositive() || resumedSet[product] ||
+			k.IsProductLocked(ctx, product) {
+			matchProducts = append(matchProducts, product)
+			continue
+		}
+
+		lastPrice := k.GetLastPrice(ctx, product)
+		bestPrice, maxExecution := periodicAuctionMatchPrice(k.GetDepthBookCopy(product), tokenPair.MaxPriceDigit,
+			lastPrice)
+		if !maxExecution.IsPositive() || !lastPrice.IsPositive() ||
+			isWithinPriceBand(bestPrice, lastPrice, tokenPair.GetPriceBand()) {
+			matchProducts = append(matchProducts, product)
+			continue
+		}
+		haltProduct(ctx, k, product, bestPrice, lastPrice)
+	}
+	return matchProducts
+}
+
+// isWithinPriceBand returns true if the price deviates from the last price by no more than the price band
+func isWithinPriceBand(price, lastPrice, priceBand sdk.Dec) bool {
+	return price.Sub(lastPrice).Abs().LTE(lastPrice.Mul(priceBand))
+}
+
+// haltProduct locks the product without any execution for PriceBandHaltBlocks blocks.
+// The market, immediate-or-cancel and fill-or-kill orders of this block can't wait for the reopening auction,
+// they are cancelled at once.
+func haltProduct(ctx sdk.Context, k keeper.Keeper, product string, matchPrice, lastPrice sdk.Dec) {
+	logger := ctx.Logger().With("module", "order")
+	haltBlocks := k.GetParams(ctx).PriceBandHaltBlocks
+	if haltBlocks < 1 {
+		haltBlocks = 1
+	}
+
+	cancelUnfilledImmediateOrders(ctx, k, ctx.BlockHeight(), product)
+
+	lock := &types.ProductLock{
+		BlockHeight:   ctx.BlockHeight(),
+		Price:         matchPrice,
+		Quantity:      sdk.ZeroDec(),
+		BuyExecuted:   sdk.ZeroDec(),
+		SellExecuted:  sdk.ZeroDec(),
+		HaltEndHeight: ctx.BlockHeight() + haltBlocks,
+	}
+	k.SetProductLock(ctx, product, lock)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeProductHalted,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyProduct, product),
+		sdk.NewAttribute(types.AttributeKeyMatchPrice, matchPrice.String()),
+		sdk.NewAttribute(types.AttributeKeyLastPrice, lastPrice.String()),
+		sdk.NewAttribute(types.AttributeKeyHaltEndHeight, strconv.FormatInt(lock.HaltEndHeight, 10)),
+	))
+	logger.Info(fmt.Sprintf("BlockHeight<%d> halt product(%s) until BlockHeight<%d>: match price %s is out of "+
+		"the price band around last price %s", ctx.BlockHeight(), product, lock.HaltEndHeight, matchPrice, lastPrice))
+}
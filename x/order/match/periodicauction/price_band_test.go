@@ -0,0 +1,85 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okchain/x/dex"
+	orderkeeper "github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+func TestHaltProductOutOfPriceBand(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	priceBand := sdk.MustNewDecFromStr("0.1")
+	tokenPair.PriceBand = &priceBand
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0"))
+	haltBlocks := keeper.GetParams(ctx).PriceBandHaltBlocks
+
+	// the match price 12.0 is 20% higher than the last price
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "12.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "12.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "12.0", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+	orders[2].TimeInForce = types.TimeInForceIOC
+	for _, order := range orders {
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	matchOrders(ctx, keeper)
+
+	// the product is halted without execution, the immediate-or-cancel order is cancelled at once
+	require.True(t, keeper.IsProductLocked(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.EqualValues(t, []types.HaltedProduct{{
+		Product:       types.TestTokenPair,
+		BlockHeight:   10,
+		HaltEndHeight: 10 + haltBlocks,
+		MatchPrice:    sdk.MustNewDecFromStr("12.0"),
+	}}, keeper.GetHaltedProducts(ctx))
+
+	halted := false
+	for _, event := range ctx.EventManager().Events() {
+		halted = halted || event.Type == types.EventTypeProductHalted
+	}
+	require.True(t, halted)
+
+	// still halted before the halt ends
+	ctx = ctx.WithBlockHeight(10 + haltBlocks - 1)
+	keeper.ResetCache(ctx)
+	matchOrders(ctx, keeper)
+	require.True(t, keeper.IsProductLocked(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+
+	// the reopening auction is matched without the price band check
+	ctx = ctx.WithBlockHeight(10 + haltBlocks)
+	keeper.ResetCache(ctx)
+	matchOrders(ctx, keeper)
+	require.False(t, keeper.IsProductLocked(ctx, types.TestTokenPair))
+	require.Empty(t, keeper.GetHaltedProducts(ctx))
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("12.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+}
+
+func TestIsWithinPriceBand(t *testing.T) {
+	lastPrice := sdk.MustNewDecFromStr("10.0")
+	priceBand := sdk.MustNewDecFromStr("0.1")
+	require.True(t, isWithinPriceBand(sdk.MustNewDecFromStr("11.0"), lastPrice, priceBand))
+	require.True(t, isWithinPriceBand(sdk.MustNewDecFromStr("9.0"), lastPrice, priceBand))
+	require.False(t, isWithinPriceBand(sdk.MustNewDecFromStr("11.01"), lastPrice, priceBand))
+	require.False(t, isWithinPriceBand(sdk.MustNewDecFromStr("8.99"), lastPrice, priceBand))
+}
@@ -0,0 +1,142 @@
+package periodicauction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+// preventSelfTrades applies the self-trade prevention mode to the products before the match.
+// The orders to be filled are walked through the depth book by the same price-time priority as fillDepthBook does.
+// Once a sender's buy order and sell order would both be filled, the mode is applied to the pair of orders.
+// Cancelling or decreasing orders changes the match price, so the match price is recalculated after each
+// prevention, until no sender would trade with itself.
+func preventSelfTrades(ctx sdk.Context, k keeper.Keeper, products []string, mode string) {
+	logger := ctx.Logger().With("module", "order")
+	switch mode {
+	case types.SelfTradePreventionCancelNewest, types.SelfTradePreventionCancelOldest,
+		types.SelfTradePreventionDecrementBoth:
+	case "", types.SelfTradePreventionNone:
+		return
+	default:
+		logger.Error(fmt.Sprintf("unknown self-trade prevention mode: %s", mode))
+		return
+	}
+
+	for _, product := range products {
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil || k.IsProductLocked(ctx, product) {
+			continue
+		}
+
+		for {
+			book := k.GetDepthBookCopy(product)
+			bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product))
+			buyOrder, sellOrder := findSelfTrade(ctx, k, product, book, bestPrice, maxExecution)
+			if buyOrder == nil {
+				break
+			}
+			applySelfTradePrevention(ctx, k, mode, buyOrder, sellOrder, logger)
+		}
+	}
+}
+
+// findSelfTrade returns the first buy order in the fill priority, which would be filled together with
+// a sell order of the same sender, and the first such sell order
+func findSelfTrade(ctx sdk.Context, k keeper.Keeper, product string, book *types.DepthBook,
+	bestPrice, maxExecution sdk.Dec) (buyOrder, sellOrder *types.Order) {
+	if !maxExecution.IsPositive() {
+		return nil, nil
+	}
+
+	sellOrders := make(map[string]*types.Order)
+	walkFilledOrders(ctx, k, product, book, types.SellOrder, bestPrice, maxExecution, func(order *types.Order) bool {
+		if _, ok := sellOrders[order.Sender.String()]; !ok {
+			sellOrders[order.Sender.String()] = order
+		}
+		return false
+	})
+	if len(sellOrders) == 0 {
+		return nil, nil
+	}
+
+	walkFilledOrders(ctx, k, product, book, types.BuyOrder, bestPrice, maxExecution, func(order *types.Order) bool {
+		if sell, ok := sellOrders[order.Sender.String()]; ok {
+			buyOrder, sellOrder = order, sell
+			return true
+		}
+		return false
+	})
+	return buyOrder, sellOrder
+}
+
+// walkFilledOrders calls fn with the orders of the side which would be filled at the match price,
+// buy orders from high price to low, sell orders from low price to high, and by time at the same price.
+// It stops when fn returns true.
+func walkFilledOrders(ctx sdk.Context, k keeper.Keeper, product string, book *types.DepthBook, side string,
+	bestPrice, maxExecution sdk.Dec, fn func(order *types.Order) bool) {
+	filledQuantity := sdk.ZeroDec()
+	for i := range book.Items {
+		item := book.Items[i]
+		if side == types.SellOrder {
+			item = book.Items[len(book.Items)-1-i]
+		}
+		if (side == types.BuyOrder && item.Price.LT(bestPrice)) ||
+			(side == types.SellOrder && item.Price.GT(bestPrice)) {
+			return
+		}
+
+		key := types.FormatOrderIDsKey(product, item.Price, side)
+		for _, orderID := range k.GetProductPriceOrderIDs(key) {
+			if filledQuantity.GTE(maxExecution) {
+				return
+			}
+			order := k.GetOrder(ctx, orderID)
+			if order == nil {
+				continue
+			}
+			if fn(order) {
+				return
+			}
+			filledQuantity = filledQuantity.Add(order.RemainQuantity)
+		}
+	}
+}
+
+func applySelfTradePrevention(ctx sdk.Context, k keeper.Keeper, mode string, buyOrder, sellOrder *types.Order,
+	logger log.Logger) {
+	newer, older := buyOrder, sellOrder
+	if isPlacedBefore(buyOrder.OrderID, sellOrder.OrderID) {
+		newer, older = sellOrder, buyOrder
+	}
+
+	switch mode {
+	case types.SelfTradePreventionCancelNewest:
+		k.CancelOrder(ctx, newer, logger)
+	case types.SelfTradePreventionCancelOldest:
+		k.CancelOrder(ctx, older, logger)
+	case types.SelfTradePreventionDecrementBoth:
+		quantity := sdk.MinDec(buyOrder.RemainQuantity, sellOrder.RemainQuantity)
+		k.DecreaseOrder(ctx, buyOrder, quantity, logger)
+		k.DecreaseOrder(ctx, sellOrder, quantity, logger)
+	}
+	logger.Info(fmt.Sprintf("BlockHeight<%d> prevent self-trade(%s) between order(%s) and order(%s)",
+		ctx.BlockHeight(), mode, buyOrder.OrderID, sellOrder.OrderID))
+}
+
+// isPlacedBefore returns true if the order with orderID was placed before the order with otherID
+func isPlacedBefore(orderID, otherID string) bool {
+	var height, num, otherHeight, otherNum int64
+	if _, err := fmt.Sscanf(orderID, "ID%d-%d", &height, &num); err != nil {
+		return false
+	}
+	if _, err := fmt.Sscanf(otherID, "ID%d-%d", &otherHeight, &otherNum); err != nil {
+		return true
+	}
+	return height < otherHeight || (height == otherHeight && num < otherNum)
+}
@@ -0,0 +1,84 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okchain/x/dex"
+	orderkeeper "github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+func TestPreventSelfTrades(t *testing.T) {
+	tests := []struct {
+		mode         string
+		statuses     []int64
+		remains      []string
+		decreased    []string
+		bookQuantity []string // buy quantity at 10.1, sell quantity at 9.9
+	}{
+		{types.SelfTradePreventionNone,
+			[]int64{types.OrderStatusFilled, types.OrderStatusFilled, types.OrderStatusOpen},
+			[]string{"0", "0", "2"}, []string{"", "", ""}, []string{"0", "2"}},
+		{types.SelfTradePreventionCancelNewest,
+			[]int64{types.OrderStatusOpen, types.OrderStatusFilled, types.OrderStatusCancelled},
+			[]string{"1", "0", "3"}, []string{"", "", ""}, []string{"1", "0"}},
+		{types.SelfTradePreventionCancelOldest,
+			[]int64{types.OrderStatusCancelled, types.OrderStatusOpen, types.OrderStatusOpen},
+			[]string{"2", "1", "3"}, []string{"", "", ""}, []string{"0", "4"}},
+		{types.SelfTradePreventionDecrementBoth,
+			[]int64{types.OrderStatusCancelled, types.OrderStatusOpen, types.OrderStatusOpen},
+			[]string{"2", "1", "1"}, []string{"", "", "2.00000000"}, []string{"0", "2"}},
+	}
+
+	for _, tt := range tests {
+		testInput := orderkeeper.CreateTestInput(t)
+		keeper := testInput.OrderKeeper
+		ctx := testInput.Ctx.WithBlockHeight(10)
+		tokenPair := dex.GetBuiltInTokenPair()
+		require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+		params := keeper.GetParams(ctx)
+		params.SelfTradePrevention = tt.mode
+		keeper.SetParams(ctx, params)
+
+		// the sell order of addr0 would be filled together with the buy order of addr0
+		orders := []*types.Order{
+			mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
+			mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0"),
+			mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "3.0"),
+		}
+		orders[0].Sender = testInput.TestAddrs[0]
+		orders[1].Sender = testInput.TestAddrs[1]
+		orders[2].Sender = testInput.TestAddrs[0]
+		for _, order := range orders {
+			require.Nil(t, keeper.PlaceOrder(ctx, order))
+		}
+
+		matchOrders(ctx, keeper)
+
+		for i, order := range orders {
+			order = keeper.GetOrder(ctx, order.OrderID)
+			require.EqualValues(t, tt.statuses[i], order.Status, tt.mode)
+			require.EqualValues(t, sdk.MustNewDecFromStr(tt.remains[i]), order.RemainQuantity, tt.mode)
+			require.EqualValues(t, tt.decreased[i], order.GetExtraInfoWithKey(types.OrderExtraInfoKeyDecreased),
+				tt.mode)
+		}
+
+		buyQuantity, sellQuantity := sdk.ZeroDec(), sdk.ZeroDec()
+		for _, item := range keeper.GetDepthBookCopy(types.TestTokenPair).Items {
+			buyQuantity = buyQuantity.Add(item.BuyQuantity)
+			sellQuantity = sellQuantity.Add(item.SellQuantity)
+		}
+		require.EqualValues(t, sdk.MustNewDecFromStr(tt.bookQuantity[0]), buyQuantity, tt.mode)
+		require.EqualValues(t, sdk.MustNewDecFromStr(tt.bookQuantity[1]), sellQuantity, tt.mode)
+	}
+}
+
+func TestIsPlacedBefore(t *testing.T) {
+	require.True(t, isPlacedBefore(types.FormatOrderID(10, 9), types.FormatOrderID(10, 10)))
+	require.True(t, isPlacedBefore(types.FormatOrderID(9, 10), types.FormatOrderID(10, 1)))
+	require.False(t, isPlacedBefore(types.FormatOrderID(10, 1), types.FormatOrderID(10, 1)))
+	require.False(t, isPlacedBefore(types.FormatOrderID(10, 2), types.FormatOrderID(10, 1)))
+}
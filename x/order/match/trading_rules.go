@@ -0,0 +1,65 @@
+package match
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+)
+
+// cancelOrdersBreakingTradingRules cancels the open and untriggered orders of the token pairs edited in the block,
+// whose prices or quantities don't fit the new trading rules. The locked coins are refunded
+func cancelOrdersBreakingTradingRules(ctx sdk.Context, keeper keeper.Keeper) {
+	products := keeper.GetDexKeeper().GetEditedTokenPairs(ctx)
+	if len(products) == 0 {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	for _, product := range products {
+		tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil {
+			continue
+		}
+
+		var orders []*types.Order
+		depthBook := keeper.GetDepthBookCopy(product)
+		for _, item := range depthBook.Items {
+			buyKey := types.FormatOrderIDsKey(product, item.Price, types.BuyOrder)
+			orderIDList := keeper.GetProductPriceOrderIDs(buyKey)
+			sellKey := types.FormatOrderIDsKey(product, item.Price, types.SellOrder)
+			orderIDList = append(orderIDList, keeper.GetProductPriceOrderIDs(sellKey)...)
+			for _, orderID := range orderIDList {
+				if order := keeper.GetOrder(ctx, orderID); order != nil {
+					orders = append(orders, order)
+				}
+			}
+		}
+		orders = append(orders, keeper.GetTriggerOrders(ctx, product)...)
+
+		for _, order := range orders {
+			if fitsTradingRules(order, tokenPair) {
+				continue
+			}
+			keeper.CancelOrder(ctx, order, logger)
+			logger.Info(fmt.Sprintf("BlockHeight<%d> order(%s) cancelled by the new trading rules of %s",
+				ctx.BlockHeight(), order.OrderID, product))
+		}
+	}
+	keeper.GetDexKeeper().ClearEditedTokenPairs(ctx)
+}
+
+// fitsTradingRules returns true if the price and quantity of the order fit the precisions of the token pair, and
+// the quantity is not less than the min quantity. A partially filled order may remain less than the min quantity
+func fitsTradingRules(order *types.Order, tokenPair *dex.TokenPair) bool {
+	if !order.Price.RoundDecimal(tokenPair.MaxPriceDigit).Equal(order.Price) {
+		return false
+	}
+	if !order.Quantity.RoundDecimal(tokenPair.MaxQuantityDigit).Equal(order.Quantity) {
+		return false
+	}
+	return order.Quantity.GTE(tokenPair.MinQuantity)
+}
@@ -0,0 +1,35 @@
+package match
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/keeper"
+)
+
+// triggerOrders converts the conditional orders into regular orders when the last prices cross their trigger prices.
+// The triggered orders of continuous auction products are matched at once, which may move the last price and
+// trigger more orders. The triggered orders of periodic auction products are matched by the periodic auction later.
+func triggerOrders(ctx sdk.Context, keeper keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	for _, product := range keeper.GetTriggerBookProducts(ctx) {
+		tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil || keeper.IsProductLocked(ctx, product) {
+			continue
+		}
+
+		engine := GetEngine(tokenPair.GetAuctionType())
+		for {
+			orders := keeper.TriggerOrders(ctx, product)
+			if len(orders) == 0 {
+				break
+			}
+			for _, order := range orders {
+				logger.Info(fmt.Sprintf("BlockHeight<%d> order(%s) triggered at last price %v",
+					ctx.BlockHeight(), order.OrderID, keeper.GetLastPrice(ctx, product)))
+				engine.MatchOrder(ctx, keeper, order)
+			}
+		}
+	}
+}
@@ -8,6 +8,10 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgNewOrders{}, "okchain/order/MsgNew", nil)
 	cdc.RegisterConcrete(MsgCancelOrders{}, "okchain/order/MsgCancel", nil)
+	cdc.RegisterConcrete(MsgCancelAllOrders{}, "okchain/order/MsgCancelAll", nil)
+	cdc.RegisterConcrete(MsgAmendOrders{}, "okchain/order/MsgAmend", nil)
+	cdc.RegisterConcrete(MsgDepositOrderRent{}, "okchain/order/MsgDepositRent", nil)
+	cdc.RegisterConcrete(MsgWithdrawOrderRent{}, "okchain/order/MsgWithdrawRent", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout this module
@@ -12,7 +12,17 @@ const (
 	FeeTypeOrderExpire  = "expire"
 	FeeTypeOrderDeal    = "deal"
 	FeeTypeOrderReceive = "receive"
+	FeeTypeOrderRent    = "rent"
 	TestTokenPair       = common.TestToken + "_" + sdk.DefaultBondDenom
 	BuyOrder            = "BUY"
 	SellOrder           = "SELL"
+	LimitOrder          = "LIMIT"
+	MarketOrder         = "MARKET"
+	StopLossOrder       = "STOP_LOSS"
+	TakeProfitOrder     = "TAKE_PROFIT"
+	TimeInForceGTC      = "GTC"       // good-til-cancel, the default one
+	TimeInForceIOC      = "IOC"       // immediate-or-cancel
+	TimeInForceFOK      = "FOK"       // fill-or-kill
+	TimeInForcePostOnly = "POST_ONLY" // post-only
+	TimeInForceGTB      = "GTB"       // good-til-block
 )
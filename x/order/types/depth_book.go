@@ -1,7 +1,10 @@
 package types
 
 import (
+	"fmt"
+	"hash/crc32"
 	"sort"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -13,6 +16,7 @@ type Deal struct {
 	Quantity    sdk.Dec `json:"quantity"`
 	Fee         string  `json:"fee"`
 	FeeReceiver string  `json:"fee_receiver"`
+	Price       sdk.Dec `json:"price"`
 }
 
 // nolint
@@ -82,6 +86,11 @@ func (depthBook *DepthBook) InsertOrder(order *Order) {
 
 // RemoveOrder : remove an order from depth book when order cancelled/expired
 func (depthBook *DepthBook) RemoveOrder(order *Order) {
+	depthBook.DecreaseOrder(order, order.RemainQuantity)
+}
+
+// DecreaseOrder : subtract the decreased quantity of an order from depth book
+func (depthBook *DepthBook) DecreaseOrder(order *Order, quantity sdk.Dec) {
 	bookLen := len(depthBook.Items)
 	// find first index, s.t. order.Price >= depthBook[index].Price
 	// i.e. order.Price == depthBook[index].Price
@@ -90,14 +99,7 @@ func (depthBook *DepthBook) RemoveOrder(order *Order) {
 	})
 
 	if index < bookLen && depthBook.Items[index].Price.Equal(order.Price) {
-		if order.Side == BuyOrder {
-			depthBook.Items[index].BuyQuantity =
-				depthBook.Items[index].BuyQuantity.Sub(order.RemainQuantity)
-		} else if order.Side == SellOrder {
-			depthBook.Items[index].SellQuantity =
-				depthBook.Items[index].SellQuantity.Sub(order.RemainQuantity)
-		}
-
+		depthBook.Sub(index, quantity, order.Side)
 		depthBook.RemoveIfEmpty(index)
 	}
 }
@@ -126,3 +128,76 @@ func (depthBook *DepthBook) Copy() *DepthBook {
 	itemList = append(itemList, depthBook.Items...)
 	return &DepthBook{Items: itemList}
 }
+
+// DepthBookChecksumLevels is the number of the best levels on each side covered by the depth book checksum
+const DepthBookChecksumLevels = 25
+
+// DepthBookDiff records the price levels of a depth book changed in a block.
+// The levels are the new quantities at the prices, zero quantities on both sides mean the level is removed
+type DepthBookDiff struct {
+	Sequence    int64           `json:"sequence"`
+	BlockHeight int64           `json:"block_height"`
+	Items       []DepthBookItem `json:"items"`
+}
+
+// DiffDepthBooks returns the items of the new depth book which differ from the old one, sorted by price desc.
+// The price levels removed from the old depth book are returned with zero quantities
+func DiffDepthBooks(oldBook, newBook *DepthBook) []DepthBookItem {
+	var oldItems, newItems []DepthBookItem
+	if oldBook != nil {
+		oldItems = oldBook.Items
+	}
+	if newBook != nil {
+		newItems = newBook.Items
+	}
+
+	var diff []DepthBookItem
+	i, j := 0, 0
+	for i < len(oldItems) || j < len(newItems) {
+		switch {
+		case j >= len(newItems) || (i < len(oldItems) && oldItems[i].Price.GT(newItems[j].Price)):
+			diff = append(diff, DepthBookItem{Price: oldItems[i].Price, BuyQuantity: sdk.ZeroDec(),
+				SellQuantity: sdk.ZeroDec()})
+			i++
+		case i >= len(oldItems) || newItems[j].Price.GT(oldItems[i].Price):
+			diff = append(diff, newItems[j])
+			j++
+		default:
+			if !oldItems[i].BuyQuantity.Equal(newItems[j].BuyQuantity) ||
+				!oldItems[i].SellQuantity.Equal(newItems[j].SellQuantity) {
+				diff = append(diff, newItems[j])
+			}
+			i++
+			j++
+		}
+	}
+	return diff
+}
+
+// Checksum returns the CRC32 (IEEE) checksum of the best levels of the depth book.
+// The checksummed string joins the bids and asks from the best price alternately as
+// "bidPrice:bidQuantity:askPrice:askQuantity:...", a side is skipped once it has no more levels
+func (depthBook *DepthBook) Checksum(levels int) uint32 {
+	var bids, asks []DepthBookItem
+	for _, item := range depthBook.Items {
+		if item.BuyQuantity.IsPositive() && len(bids) < levels {
+			bids = append(bids, item)
+		}
+	}
+	for i := len(depthBook.Items) - 1; i >= 0; i-- {
+		if item := depthBook.Items[i]; item.SellQuantity.IsPositive() && len(asks) < levels {
+			asks = append(asks, item)
+		}
+	}
+
+	var fields []string
+	for i := 0; i < len(bids) || i < len(asks); i++ {
+		if i < len(bids) {
+			fields = append(fields, fmt.Sprintf("%s:%s", bids[i].Price, bids[i].BuyQuantity))
+		}
+		if i < len(asks) {
+			fields = append(fields, fmt.Sprintf("%s:%s", asks[i].Price, asks[i].SellQuantity))
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(strings.Join(fields, ":")))
+}
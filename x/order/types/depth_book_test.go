@@ -1,6 +1,7 @@
 package types
 
 import (
+	"hash/crc32"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -96,3 +97,38 @@ func TestCopy(t *testing.T) {
 	require.EqualValues(t, 1, len(depthBook.Items))
 	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[0].Price)
 }
+
+func TestDiffDepthBooks(t *testing.T) {
+	oldBook := &DepthBook{}
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.2", "1.0"))
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.1", "1.0"))
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.9", "1.0"))
+
+	newBook := oldBook.Copy()
+	newBook.RemoveOrder(MockOrder("", TestTokenPair, SellOrder, "10.2", "1.0"))
+	newBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.1", "2.0"))
+	newBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.8", "3.0"))
+
+	expected := []DepthBookItem{
+		{sdk.MustNewDecFromStr("10.2"), sdk.ZeroDec(), sdk.ZeroDec()},
+		{sdk.MustNewDecFromStr("10.1"), sdk.ZeroDec(), sdk.MustNewDecFromStr("3.0")},
+		{sdk.MustNewDecFromStr("9.8"), sdk.MustNewDecFromStr("3.0"), sdk.ZeroDec()},
+	}
+	require.EqualValues(t, expected, DiffDepthBooks(oldBook, newBook))
+	require.Empty(t, DiffDepthBooks(oldBook, oldBook))
+	require.EqualValues(t, oldBook.Items, DiffDepthBooks(nil, oldBook))
+	require.Len(t, DiffDepthBooks(oldBook, nil), 3)
+}
+
+func TestDepthBookChecksum(t *testing.T) {
+	book := &DepthBook{}
+	book.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.2", "1.0"))
+	book.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.1", "2.0"))
+	book.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.9", "3.0"))
+
+	expected := crc32.ChecksumIEEE([]byte("9.90000000:3.00000000:10.10000000:2.00000000:10.20000000:1.00000000"))
+	require.Equal(t, expected, book.Checksum(DepthBookChecksumLevels))
+	expected = crc32.ChecksumIEEE([]byte("9.90000000:3.00000000:10.10000000:2.00000000"))
+	require.Equal(t, expected, book.Checksum(1))
+	require.Equal(t, crc32.ChecksumIEEE(nil), (&DepthBook{}).Checksum(DepthBookChecksumLevels))
+}
@@ -20,13 +20,22 @@ const (
 	RouterKey = ModuleName
 
 	// QueryOrderDetail query endpoints supported by the governance Querier
-	QueryOrderDetail = "detail"
-	QueryDepthBook   = "depthbook"
-	QueryParameters  = "params"
-	QueryStore       = "store"
-	QueryDepthBookV2 = "depthbookV2"
+	QueryOrderDetail   = "detail"
+	QueryDepthBook     = "depthbook"
+	QueryParameters    = "params"
+	QueryStore         = "store"
+	QueryDepthBookV2   = "depthbookV2"
+	QueryTriggerBook   = "triggerbook"
+	QueryHalted        = "halted"
+	QueryDepthBookDiff = "depthbookdiff"
+	QueryBookL3        = "bookl3"
+	QueryQueuePosition = "queueposition"
+	QueryOpenOrders    = "openorders"
+	QueryOrderRent     = "orderrent"
 
 	OrderStoreKey = ModuleName
+	// TStoreKey is the string transient store representation
+	TStoreKey = "transient_" + ModuleName
 )
 
 // nolint
@@ -40,12 +49,24 @@ var (
 	PriceKey             = []byte{0x14}
 	ExpireBlockHeightKey = []byte{0x15}
 	OrderNumPerBlockKey  = []byte{0x16}
+	ExpireOrderIDsKey    = []byte{0x21}
+	TriggerOrderKey      = []byte{0x22}
+	SenderOrderKey       = []byte{0x23}
+	TradeVolumeKey       = []byte{0x24}
+	DepthBookSeqKey      = []byte{0x25}
+	OrderRentKey         = []byte{0x27}
+	OrderRentDueKey      = []byte{0x29}
 
 	// none iterator keys
 	RecentlyClosedOrderIDsKey = []byte{0x17}
 	LastExpiredBlockHeightKey = []byte{0x18}
 	OpenOrderNumKey           = []byte{0x19}
 	StoreOrderNumKey          = []byte{0x20}
+	OrderRentCursorKey        = []byte{0x28}
+	SenderOrderIndexBuiltKey  = []byte{0x2a}
+
+	// transient store keys
+	CacheJournalKey = []byte{0x01}
 )
 
 // nolint
@@ -78,6 +99,66 @@ func GetExpireBlockHeightKey(blockHeight int64) []byte {
 	return append(ExpireBlockHeightKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
 }
 
+// nolint
+func GetExpireOrderIDsKey(blockHeight int64) []byte {
+	return append(ExpireOrderIDsKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
+}
+
+// GetCacheJournalKey returns the transient key marking that the memory cache changes of the journal are written
+func GetCacheJournalKey(seq int64) []byte {
+	return append(CacheJournalKey, sdk.Uint64ToBigEndian(uint64(seq))...)
+}
+
+// GetTriggerOrderKey returns the key of the conditional order in the trigger book.
+// The positive trigger price is encoded in length-prefixed big-endian bytes, so the orders of the product are sorted
+// by the numeric trigger prices, then by the order IDs
+func GetTriggerOrderKey(product string, triggerPrice sdk.Dec, orderID string) []byte {
+	priceBytes := triggerPrice.Int.Bytes()
+	key := append(GetTriggerBookKey(product), byte(len(priceBytes)))
+	key = append(key, priceBytes...)
+	return append(key, []byte(":"+orderID)...)
+}
+
+// GetTriggerBookKey returns the prefix key of the trigger book of the product
+func GetTriggerBookKey(product string) []byte {
+	return append(TriggerOrderKey, []byte(product+":")...)
+}
+
+// GetSenderOrderKey returns the key of the open order in the index of the sender's open orders
+func GetSenderOrderKey(sender sdk.AccAddress, orderID string) []byte {
+	return append(GetSenderOrdersKey(sender), []byte(orderID)...)
+}
+
+// GetSenderOrdersKey returns the prefix key of the sender's open orders
+func GetSenderOrdersKey(sender sdk.AccAddress) []byte {
+	return append(SenderOrderKey, sender.Bytes()...)
+}
+
+// GetTradeVolumeKey returns the key of the address's traded volume of the product on the day
+func GetTradeVolumeKey(addr sdk.AccAddress, product string, day int64) []byte {
+	return append(GetTradeVolumesKey(addr, product), sdk.Uint64ToBigEndian(uint64(day))...)
+}
+
+// GetTradeVolumesKey returns the prefix key of the address's daily traded volumes of the product
+func GetTradeVolumesKey(addr sdk.AccAddress, product string) []byte {
+	return append(append(TradeVolumeKey, addr.Bytes()...), []byte(product+":")...)
+}
+
+// GetDepthBookSeqKey returns the key of the sequence number of the product's depth book
+func GetDepthBookSeqKey(product string) []byte {
+	return append(DepthBookSeqKey, []byte(product)...)
+}
+
+// GetOrderRentKey returns the key of the prepaid order rent balance of the address
+func GetOrderRentKey(addr sdk.AccAddress) []byte {
+	return append(OrderRentKey, addr.Bytes()...)
+}
+
+// GetOrderRentDueKey returns the key marking that some resting rent orders of the address are unpaid
+func GetOrderRentDueKey(addr sdk.AccAddress) []byte {
+	return append(OrderRentDueKey, addr.Bytes()...)
+}
+
 // nolint
 func FormatOrderIDsKey(product string, price sdk.Dec, side string) string {
 	return fmt.Sprintf("%v:%v:%v", product, price.String(), side)
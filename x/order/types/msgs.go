@@ -17,11 +17,15 @@ const (
 
 // nolint
 type MsgNewOrder struct {
-	Sender   sdk.AccAddress `json:"sender"`   // order maker address
-	Product  string         `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string         `json:"side"`     // BUY/SELL
-	Price    sdk.Dec        `json:"price"`    // price of the order
-	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+	Sender       sdk.AccAddress `json:"sender"`        // order maker address
+	Product      string         `json:"product"`       // product for trading pair in full name of the tokens
+	Side         string         `json:"side"`          // BUY/SELL
+	Price        sdk.Dec        `json:"price"`         // price of the order
+	Quantity     sdk.Dec        `json:"quantity"`      // quantity of the order
+	Type         string         `json:"type"`          // LIMIT/MARKET
+	TimeInForce  string         `json:"time_in_force"` // GTC/IOC/FOK/POST_ONLY/GTB
+	ExpireHeight int64          `json:"expire_height"` // expire height of good-til-block order
+	TriggerPrice sdk.Dec        `json:"trigger_price"` // trigger price of stop-loss/take-profit order
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -61,14 +65,19 @@ func NewMsgCancelOrder(sender sdk.AccAddress, orderID string) MsgCancelOrders {
 type MsgNewOrders struct {
 	Sender     sdk.AccAddress `json:"sender"` // order maker address
 	OrderItems []OrderItem    `json:"order_items"`
+	Atomic     bool           `json:"atomic,omitempty"` // the whole batch is rejected if any item fails
 }
 
 // nolint
 type OrderItem struct {
-	Product  string  `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string  `json:"side"`     // BUY/SELL
-	Price    sdk.Dec `json:"price"`    // price of the order
-	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+	Product      string  `json:"product"`                 // product for trading pair in full name of the tokens
+	Side         string  `json:"side"`                    // BUY/SELL
+	Price        sdk.Dec `json:"price"`                   // price of the order, zero for market order
+	Quantity     sdk.Dec `json:"quantity"`                // quantity of the order, amount of quote token for market buy order
+	Type         string  `json:"type,omitempty"`          // LIMIT/MARKET, LIMIT if empty
+	TimeInForce  string  `json:"time_in_force,omitempty"` // GTC/IOC/FOK/POST_ONLY/GTB, GTC if empty
+	ExpireHeight int64   `json:"expire_height,omitempty"` // expire height of good-til-block order
+	TriggerPrice sdk.Dec `json:"trigger_price,omitempty"` // trigger price of stop-loss/take-profit order
 }
 
 // nolint
@@ -82,6 +91,32 @@ func NewOrderItem(product string, side string, price string,
 	}
 }
 
+// NewMarketOrderItem creates a market order item, quantity is the amount of quote token to spend at most for buy side,
+// and the quantity of base token to sell for sell side
+func NewMarketOrderItem(product string, side string, quantity string) OrderItem {
+	return OrderItem{
+		Product:  product,
+		Side:     side,
+		Price:    sdk.ZeroDec(),
+		Quantity: sdk.MustNewDecFromStr(quantity),
+		Type:     MarketOrder,
+	}
+}
+
+// NewConditionalOrderItem creates a stop-loss or take-profit order item, which is converted into
+// a limit order when the last price crosses the trigger price
+func NewConditionalOrderItem(orderType string, product string, side string, triggerPrice string, price string,
+	quantity string) OrderItem {
+	return OrderItem{
+		Product:      product,
+		Side:         side,
+		Price:        sdk.MustNewDecFromStr(price),
+		Quantity:     sdk.MustNewDecFromStr(quantity),
+		Type:         orderType,
+		TriggerPrice: sdk.MustNewDecFromStr(triggerPrice),
+	}
+}
+
 // NewMsgNewOrders is a constructor function for MsgNewOrder
 func NewMsgNewOrders(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders {
 	return MsgNewOrders{
@@ -90,6 +125,13 @@ func NewMsgNewOrders(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders
 	}
 }
 
+// NewMsgNewOrdersAtomic creates a MsgNewOrders whose order items are placed all or nothing
+func NewMsgNewOrdersAtomic(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders {
+	msg := NewMsgNewOrders(sender, orderItems)
+	msg.Atomic = true
+	return msg
+}
+
 // nolint
 func (msg MsgNewOrders) Route() string { return "order" }
 
@@ -122,14 +164,67 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 			return sdk.ErrUnknownRequest(
 				fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", item.Side))
 		}
-		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
-			return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+		switch item.Type {
+		case "", LimitOrder:
+			if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+				return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+			}
+		case MarketOrder:
+			if !(!item.Price.IsNil() && item.Price.IsZero() && item.Quantity.IsPositive()) {
+				return sdk.ErrUnknownRequest("Price of market order must be zero and Quantity must be positive")
+			}
+		case StopLossOrder, TakeProfitOrder:
+			if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+				return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+			}
+			if item.TriggerPrice.IsNil() || !item.TriggerPrice.IsPositive() {
+				return sdk.ErrUnknownRequest("TriggerPrice must be positive")
+			}
+			if item.TimeInForce != "" && item.TimeInForce != TimeInForceGTC && item.TimeInForce != TimeInForceGTB {
+				return sdk.ErrUnknownRequest("TimeInForce of stop-loss/take-profit order must be \"GTC\" or \"GTB\"")
+			}
+		default:
+			return sdk.ErrUnknownRequest(
+				fmt.Sprintf("Type is expected to be \"LIMIT\", \"MARKET\", \"STOP_LOSS\" or \"TAKE_PROFIT\", "+
+					"but got \"%s\"", item.Type))
+		}
+		if item.Type != StopLossOrder && item.Type != TakeProfitOrder &&
+			!item.TriggerPrice.IsNil() && !item.TriggerPrice.IsZero() {
+			return sdk.ErrUnknownRequest("TriggerPrice is only allowed for stop-loss/take-profit order")
+		}
+		if err := validateTimeInForce(item); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func validateTimeInForce(item OrderItem) sdk.Error {
+	switch item.TimeInForce {
+	case "", TimeInForceGTC, TimeInForcePostOnly, TimeInForceGTB:
+		if item.Type == MarketOrder && item.TimeInForce != "" {
+			return sdk.ErrUnknownRequest(
+				fmt.Sprintf("TimeInForce of market order is expected to be \"IOC\" or \"FOK\", but got \"%s\"",
+					item.TimeInForce))
+		}
+	case TimeInForceIOC, TimeInForceFOK:
+	default:
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("TimeInForce is expected to be \"GTC\", \"IOC\", \"FOK\", \"POST_ONLY\" or \"GTB\", "+
+				"but got \"%s\"", item.TimeInForce))
+	}
+
+	if item.TimeInForce == TimeInForceGTB {
+		if item.ExpireHeight <= 0 {
+			return sdk.ErrUnknownRequest("ExpireHeight of good-til-block order must be positive")
+		}
+	} else if item.ExpireHeight != 0 {
+		return sdk.ErrUnknownRequest("ExpireHeight is only allowed for good-til-block order")
+	}
+	return nil
+}
+
 // GetSignBytes : encodes the message for signing
 func (msg MsgNewOrders) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(msg)
@@ -218,6 +313,224 @@ func (msg MsgCancelOrders) CalculateGas(gasUnit uint64) uint64 {
 	return uint64(len(msg.OrderIDs)) * gasUnit
 }
 
+//********************MsgCancelAllOrders*************
+// nolint
+type MsgCancelAllOrders struct {
+	Sender  sdk.AccAddress `json:"sender"`            // order maker address
+	Product string         `json:"product,omitempty"` // cancel the orders of all products if empty
+	Side    string         `json:"side,omitempty"`    // BUY/SELL, cancel the orders of both sides if empty
+}
+
+// NewMsgCancelAllOrders is a constructor function for MsgCancelAllOrders
+func NewMsgCancelAllOrders(sender sdk.AccAddress, product string, side string) MsgCancelAllOrders {
+	return MsgCancelAllOrders{
+		Sender:  sender,
+		Product: product,
+		Side:    side,
+	}
+}
+
+// nolint
+func (msg MsgCancelAllOrders) Route() string { return "order" }
+
+// nolint
+func (msg MsgCancelAllOrders) Type() string { return "cancel_all" }
+
+// nolint
+func (msg MsgCancelAllOrders) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Side != "" && msg.Side != BuyOrder && msg.Side != SellOrder {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", msg.Side))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelAllOrders) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelAllOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// IsMatched returns true if the order is filtered by the product and side of the msg
+func (msg MsgCancelAllOrders) IsMatched(order *Order) bool {
+	return (msg.Product == "" || msg.Product == order.Product) && (msg.Side == "" || msg.Side == order.Side)
+}
+
+//********************MsgAmendOrders*************
+// nolint
+type MsgAmendOrders struct {
+	Sender     sdk.AccAddress   `json:"sender"` // order maker address
+	AmendItems []AmendOrderItem `json:"amend_items"`
+}
+
+// AmendOrderItem replaces the open order with a new one at the new price and quantity
+type AmendOrderItem struct {
+	OrderID  string  `json:"order_id"` // id of the order to be amended
+	Price    sdk.Dec `json:"price"`    // new price of the order
+	Quantity sdk.Dec `json:"quantity"` // new quantity of the order
+}
+
+// NewAmendOrderItem is a constructor function for AmendOrderItem
+func NewAmendOrderItem(orderID string, price string, quantity string) AmendOrderItem {
+	return AmendOrderItem{
+		OrderID:  orderID,
+		Price:    sdk.MustNewDecFromStr(price),
+		Quantity: sdk.MustNewDecFromStr(quantity),
+	}
+}
+
+// NewMsgAmendOrders is a constructor function for MsgAmendOrders
+func NewMsgAmendOrders(sender sdk.AccAddress, amendItems []AmendOrderItem) MsgAmendOrders {
+	return MsgAmendOrders{
+		Sender:     sender,
+		AmendItems: amendItems,
+	}
+}
+
+// nolint
+func (msg MsgAmendOrders) Route() string { return "order" }
+
+// nolint
+func (msg MsgAmendOrders) Type() string { return "amend" }
+
+// nolint
+func (msg MsgAmendOrders) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if len(msg.AmendItems) == 0 {
+		return sdk.ErrUnknownRequest("invalid AmendItems")
+	}
+	if len(msg.AmendItems) > OrderItemLimit {
+		return sdk.ErrUnknownRequest("Numbers of AmendOrderItem should not be more than " + strconv.Itoa(OrderItemLimit))
+	}
+
+	orderIDs := make([]string, 0, len(msg.AmendItems))
+	for _, item := range msg.AmendItems {
+		if item.OrderID == "" {
+			return sdk.ErrUnauthorized("orderID cannot be empty")
+		}
+		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+			return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+		}
+		orderIDs = append(orderIDs, item.OrderID)
+	}
+	if hasDuplicatedID(orderIDs) {
+		return sdk.ErrUnknownRequest("Duplicated order ids detected")
+	}
+
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAmendOrders) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAmendOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgAmendOrders) CalculateGas(gasUnit uint64) uint64 {
+	return uint64(len(msg.AmendItems)) * gasUnit
+}
+
+//********************MsgDepositOrderRent*************
+// MsgDepositOrderRent tops up the prepaid order rent balance of the sender
+type MsgDepositOrderRent struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Amount sdk.DecCoin    `json:"amount"`
+}
+
+// NewMsgDepositOrderRent is a constructor function for MsgDepositOrderRent
+func NewMsgDepositOrderRent(sender sdk.AccAddress, amount sdk.DecCoin) MsgDepositOrderRent {
+	return MsgDepositOrderRent{
+		Sender: sender,
+		Amount: amount,
+	}
+}
+
+// nolint
+func (msg MsgDepositOrderRent) Route() string { return "order" }
+
+// nolint
+func (msg MsgDepositOrderRent) Type() string { return "deposit_rent" }
+
+// nolint
+func (msg MsgDepositOrderRent) ValidateBasic() sdk.Error {
+	return validateOrderRentMsg(msg.Sender, msg.Amount)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgDepositOrderRent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgDepositOrderRent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+//********************MsgWithdrawOrderRent*************
+// MsgWithdrawOrderRent withdraws from the prepaid order rent balance of the sender. The resting rent orders
+// are cancelled at the end of the block if the balance left is not enough for their rent
+type MsgWithdrawOrderRent struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Amount sdk.DecCoin    `json:"amount"`
+}
+
+// NewMsgWithdrawOrderRent is a constructor function for MsgWithdrawOrderRent
+func NewMsgWithdrawOrderRent(sender sdk.AccAddress, amount sdk.DecCoin) MsgWithdrawOrderRent {
+	return MsgWithdrawOrderRent{
+		Sender: sender,
+		Amount: amount,
+	}
+}
+
+// nolint
+func (msg MsgWithdrawOrderRent) Route() string { return "order" }
+
+// nolint
+func (msg MsgWithdrawOrderRent) Type() string { return "withdraw_rent" }
+
+// nolint
+func (msg MsgWithdrawOrderRent) ValidateBasic() sdk.Error {
+	return validateOrderRentMsg(msg.Sender, msg.Amount)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgWithdrawOrderRent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgWithdrawOrderRent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func validateOrderRentMsg(sender sdk.AccAddress, amount sdk.DecCoin) sdk.Error {
+	if sender.Empty() {
+		return sdk.ErrInvalidAddress(sender.String())
+	}
+	if amount.Amount.IsNil() || !amount.IsValid() || !amount.IsPositive() {
+		return sdk.ErrInvalidCoins("amount must be positive")
+	}
+	return nil
+}
+
 // nolint
 type OrderResult struct {
 	Code    sdk.CodeType `json:"code"`    // order return code
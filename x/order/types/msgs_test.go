@@ -4,10 +4,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/okex/okchain/x/common"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -144,6 +147,12 @@ func TestMsgMultiNewOrder(t *testing.T) {
 	require.Nil(t, err)
 	resAddr := orderMsg.GetSigners()[0]
 	require.EqualValues(t, addr, resAddr)
+
+	// the sign bytes of the non-atomic msg are left unchanged
+	require.False(t, strings.Contains(string(bytesMsg), "atomic"))
+	atomicMsg := NewMsgNewOrdersAtomic(addr, []OrderItem{orderItems})
+	require.Nil(t, atomicMsg.ValidateBasic())
+	require.True(t, strings.Contains(string(atomicMsg.GetSignBytes()), `"atomic":true`))
 }
 
 func TestMsgMultiNewOrderInvalid(t *testing.T) {
@@ -229,6 +238,62 @@ func TestMsgMultiNewOrderInvalid(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestMsgNewMarketOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	orderMsg := NewMsgNewOrders(addr, []OrderItem{NewMarketOrderItem("btc_"+common.NativeToken, BuyOrder, testQuantity)})
+	require.Nil(t, orderMsg.ValidateBasic())
+
+	// non-zero price
+	orderMsg.OrderItems[0].Price = sdk.MustNewDecFromStr(testPrice)
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// zero quantity
+	orderMsg = NewMsgNewOrders(addr, []OrderItem{NewMarketOrderItem("btc_"+common.NativeToken, SellOrder, "0")})
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// invalid type
+	orderMsg = NewMsgNewOrder(addr, "btc_"+common.NativeToken, BuyOrder, testPrice, testQuantity)
+	orderMsg.OrderItems[0].Type = "STOP"
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// limit type
+	orderMsg.OrderItems[0].Type = LimitOrder
+	require.Nil(t, orderMsg.ValidateBasic())
+}
+
+func TestMsgNewOrderTimeInForce(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	orderMsg := NewMsgNewOrder(addr, "btc_"+common.NativeToken, BuyOrder, testPrice, testQuantity)
+	for _, timeInForce := range []string{"", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly} {
+		orderMsg.OrderItems[0].TimeInForce = timeInForce
+		require.Nil(t, orderMsg.ValidateBasic())
+	}
+
+	// invalid time in force
+	orderMsg.OrderItems[0].TimeInForce = "GTD"
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// expire height is only for good-til-block order
+	orderMsg.OrderItems[0].TimeInForce = TimeInForceIOC
+	orderMsg.OrderItems[0].ExpireHeight = 100
+	require.NotNil(t, orderMsg.ValidateBasic())
+	orderMsg.OrderItems[0].TimeInForce = TimeInForceGTB
+	require.Nil(t, orderMsg.ValidateBasic())
+	orderMsg.OrderItems[0].ExpireHeight = 0
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// market order is either immediate-or-cancel or fill-or-kill
+	orderMsg = NewMsgNewOrders(addr, []OrderItem{NewMarketOrderItem("btc_"+common.NativeToken, BuyOrder, testQuantity)})
+	orderMsg.OrderItems[0].TimeInForce = TimeInForceFOK
+	require.Nil(t, orderMsg.ValidateBasic())
+	orderMsg.OrderItems[0].TimeInForce = TimeInForcePostOnly
+	require.NotNil(t, orderMsg.ValidateBasic())
+}
+
 func TestMsgMultiCancelOrder(t *testing.T) {
 	orderID := testOrderID
 	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
@@ -313,3 +378,109 @@ func TestHasDuplicatedID(t *testing.T) {
 	result2 := hasDuplicatedID(ids2)
 	require.EqualValues(t, true, result2)
 }
+
+func TestMsgNewConditionalOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	item := NewConditionalOrderItem(StopLossOrder, "btc_"+common.NativeToken, SellOrder, "9.0", "8.5", testQuantity)
+	orderMsg := NewMsgNewOrders(addr, []OrderItem{item})
+	require.Nil(t, orderMsg.ValidateBasic())
+
+	// stop-loss/take-profit order is good-til-cancel or good-til-block
+	orderMsg.OrderItems[0].TimeInForce = TimeInForceIOC
+	require.NotNil(t, orderMsg.ValidateBasic())
+	orderMsg.OrderItems[0].TimeInForce = TimeInForceGTB
+	orderMsg.OrderItems[0].ExpireHeight = 100
+	require.Nil(t, orderMsg.ValidateBasic())
+	orderMsg.OrderItems[0].ExpireHeight = 0
+
+	// trigger price must be positive
+	orderMsg.OrderItems[0].TimeInForce = ""
+	orderMsg.OrderItems[0].TriggerPrice = sdk.ZeroDec()
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// trigger price is only for stop-loss/take-profit order
+	orderMsg = NewMsgNewOrder(addr, "btc_"+common.NativeToken, BuyOrder, testPrice, testQuantity)
+	orderMsg.OrderItems[0].TriggerPrice = sdk.MustNewDecFromStr("9.0")
+	require.NotNil(t, orderMsg.ValidateBasic())
+}
+
+func TestMsgAmendOrders(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	orderMsg := NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem(testOrderID, testPrice, testQuantity)})
+	require.Equal(t, "order", orderMsg.Route())
+	require.Equal(t, "amend", orderMsg.Type())
+	require.Nil(t, orderMsg.ValidateBasic())
+	require.Equal(t, uint64(10), orderMsg.CalculateGas(10))
+
+	// empty items
+	require.NotNil(t, NewMsgAmendOrders(addr, nil).ValidateBasic())
+
+	// duplicated order ids
+	orderMsg.AmendItems = append(orderMsg.AmendItems, NewAmendOrderItem(testOrderID, testPrice, testQuantity))
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// price/quantity must be positive
+	orderMsg = NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem(testOrderID, "0", testQuantity)})
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// empty order id
+	orderMsg = NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem("", testPrice, testQuantity)})
+	require.NotNil(t, orderMsg.ValidateBasic())
+}
+
+func TestMsgCancelAllOrders(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	orderMsg := NewMsgCancelAllOrders(addr, "", "")
+	require.Equal(t, "order", orderMsg.Route())
+	require.Equal(t, "cancel_all", orderMsg.Type())
+	require.Nil(t, orderMsg.ValidateBasic())
+
+	orderMsg = NewMsgCancelAllOrders(addr, "btc_"+common.NativeToken, SellOrder)
+	require.Nil(t, orderMsg.ValidateBasic())
+	require.True(t, orderMsg.IsMatched(&Order{Product: "btc_" + common.NativeToken, Side: SellOrder}))
+	require.False(t, orderMsg.IsMatched(&Order{Product: "btc_" + common.NativeToken, Side: BuyOrder}))
+	require.False(t, orderMsg.IsMatched(&Order{Product: "eth_" + common.NativeToken, Side: SellOrder}))
+
+	// invalid side
+	orderMsg.Side = "HOLD"
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// empty sender
+	require.NotNil(t, NewMsgCancelAllOrders(nil, "", "").ValidateBasic())
+}
+
+func TestMsgOrderRent(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.OneDec())
+
+	depositMsg := NewMsgDepositOrderRent(addr, amount)
+	require.Equal(t, "order", depositMsg.Route())
+	require.Equal(t, "deposit_rent", depositMsg.Type())
+	require.Nil(t, depositMsg.ValidateBasic())
+	require.EqualValues(t, addr, depositMsg.GetSigners()[0])
+	require.NotEmpty(t, depositMsg.GetSignBytes())
+
+	withdrawMsg := NewMsgWithdrawOrderRent(addr, amount)
+	require.Equal(t, "order", withdrawMsg.Route())
+	require.Equal(t, "withdraw_rent", withdrawMsg.Type())
+	require.Nil(t, withdrawMsg.ValidateBasic())
+	require.EqualValues(t, addr, withdrawMsg.GetSigners()[0])
+	require.NotEmpty(t, withdrawMsg.GetSignBytes())
+
+	// empty sender
+	require.NotNil(t, NewMsgDepositOrderRent(nil, amount).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawOrderRent(nil, amount).ValidateBasic())
+
+	// invalid amount
+	zero := sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
+	require.NotNil(t, NewMsgDepositOrderRent(addr, zero).ValidateBasic())
+	require.NotNil(t, NewMsgWithdrawOrderRent(addr, zero).ValidateBasic())
+	require.NotNil(t, NewMsgDepositOrderRent(addr, sdk.DecCoin{Denom: common.NativeToken}).ValidateBasic())
+}
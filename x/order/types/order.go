@@ -20,6 +20,10 @@ const (
 	Expired
 	PartialFilledCancelled
 	PartialFilledExpired
+	_ // PartialFilled, not used
+	Untriggered
+	UntriggeredCancelled
+	UntriggeredExpired
 )
 
 func (p OrderStatus) String() string {
@@ -36,6 +40,12 @@ func (p OrderStatus) String() string {
 		return "PartialFilledCancelled"
 	case PartialFilledExpired:
 		return "PartialFilledExpired"
+	case Untriggered:
+		return "Untriggered"
+	case UntriggeredCancelled:
+		return "UntriggeredCancelled"
+	case UntriggeredExpired:
+		return "UntriggeredExpired"
 	default:
 		return "Unknown"
 	}
@@ -50,6 +60,9 @@ const (
 	OrderStatusPartialFilledCancelled = 4
 	OrderStatusPartialFilledExpired   = 5
 	//OrderStatusPartialFilled          = 6
+	OrderStatusUntriggered          = 7 // conditional order waiting in the trigger book
+	OrderStatusUntriggeredCancelled = 8
+	OrderStatusUntriggeredExpired   = 9
 )
 
 // nolint
@@ -59,6 +72,10 @@ const (
 	OrderExtraInfoKeyExpireFee  = "expireFee"
 	OrderExtraInfoKeyDealFee    = "dealFee"
 	OrderExtraInfoKeyReceiveFee = "receiveFee"
+	OrderExtraInfoKeyAmendFrom  = "amendFrom"
+	OrderExtraInfoKeyAmendTo    = "amendTo"
+	OrderExtraInfoKeyDecreased  = "decreased"
+	OrderExtraInfoKeyRent       = "rent"
 )
 
 // nolint
@@ -77,7 +94,10 @@ type Order struct {
 	Timestamp         int64          `json:"timestamp"`        // created timestamp
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.DecCoin    `json:"fee_per_block"`
-	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	ExtraInfo         string         `json:"extra_info"`              // extra info of order in json format
+	Type              string         `json:"type,omitempty"`          // LIMIT/MARKET, LIMIT if empty
+	TimeInForce       string         `json:"time_in_force,omitempty"` // GTC/IOC/FOK/POST_ONLY/GTB, GTC if empty
+	TriggerPrice      *sdk.Dec       `json:"trigger_price,omitempty"` // trigger price of the conditional order
 }
 
 // nolint
@@ -92,6 +112,7 @@ func NewOrder(txHash string, sender sdk.AccAddress, product, side string, price,
 		Quantity:          quantity,
 		Status:            OrderStatusOpen,
 		RemainQuantity:    quantity,
+		FilledAvgPrice:    sdk.ZeroDec(),
 		Timestamp:         timestamp,
 		OrderExpireBlocks: orderExpireBlocks,
 		FeePerBlock:       feePerBlock,
@@ -104,6 +125,79 @@ func NewOrder(txHash string, sender sdk.AccAddress, product, side string, price,
 	return order
 }
 
+// IsMarketOrder returns true if the order is a market order
+func (order *Order) IsMarketOrder() bool {
+	return order.Type == MarketOrder
+}
+
+// IsConditionalOrder returns true if the order is a stop-loss or take-profit order
+func (order *Order) IsConditionalOrder() bool {
+	return order.Type == StopLossOrder || order.Type == TakeProfitOrder
+}
+
+// IsTriggered returns true if the last price crosses the trigger price of the conditional order.
+// A stop-loss order sells when the price falls to the trigger price, or buys when the price rises to it.
+// A take-profit order is on the contrary.
+func (order *Order) IsTriggered(lastPrice sdk.Dec) bool {
+	if !order.IsConditionalOrder() || order.TriggerPrice == nil || !lastPrice.IsPositive() {
+		return false
+	}
+	fallen := lastPrice.LTE(*order.TriggerPrice)
+	risen := lastPrice.GTE(*order.TriggerPrice)
+	if (order.Type == StopLossOrder) == (order.Side == SellOrder) {
+		return fallen
+	}
+	return risen
+}
+
+// IsFillOrKill returns true if the order must be filled in full or not at all
+func (order *Order) IsFillOrKill() bool {
+	return order.TimeInForce == TimeInForceFOK
+}
+
+// IsImmediateOrder returns true if the unfilled quantity of the order never rests in the depth book,
+// it's cancelled after the order is matched
+func (order *Order) IsImmediateOrder() bool {
+	return order.IsMarketOrder() || order.TimeInForce == TimeInForceIOC || order.IsFillOrKill()
+}
+
+// GetMarketOrderPriceAndQuantity converts a market order to a limit order at the protection price.
+// The protection price is the last price moved by the slippage cap, it is the worst price the order accepts.
+// A market buy order spends at most the amount of quote token, a market sell order sells the quantity of base token.
+// The quantity of a market buy order is the amount divided by the protection price, so it doesn't spend the whole
+// amount unless filled at the protection price: filled at the last price, it spends about amount / (1 + slippage),
+// and the rest of the amount is unlocked when the order is closed.
+func GetMarketOrderPriceAndQuantity(side string, amount, lastPrice, slippage sdk.Dec,
+	priceDigit, quantityDigit int64) (price, quantity sdk.Dec) {
+	if side == BuyOrder {
+		price = truncateDecimal(lastPrice.Mul(sdk.OneDec().Add(slippage)), priceDigit)
+		if !price.IsPositive() {
+			return price, sdk.ZeroDec()
+		}
+		return price, truncateDecimal(amount.Quo(price), quantityDigit)
+	}
+
+	price = lastPrice.Mul(sdk.OneDec().Sub(slippage))
+	// round up to the price digit to keep the price within the slippage cap
+	if rounded := truncateDecimal(price, priceDigit); rounded.LT(price) {
+		price = rounded.Add(sdk.OneDec().QuoInt64(pow10(priceDigit)))
+	}
+	return price, amount
+}
+
+func truncateDecimal(d sdk.Dec, precision int64) sdk.Dec {
+	precisionMul := pow10(precision)
+	return d.MulInt64(precisionMul).TruncateDec().QuoInt64(precisionMul)
+}
+
+func pow10(n int64) int64 {
+	res := int64(1)
+	for i := int64(0); i < n; i++ {
+		res *= 10
+	}
+	return res
+}
+
 func (order *Order) String() string {
 	if orderJSON, err := json.Marshal(order); err != nil {
 		panic(err)
@@ -166,6 +260,40 @@ func (order *Order) RecordOrderReceiveFee(fee sdk.DecCoins) {
 	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyReceiveFee, fee.String())
 }
 
+// RecordOrderAmendFrom records the id of the order replaced by this one
+func (order *Order) RecordOrderAmendFrom(orderID string) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyAmendFrom, orderID)
+}
+
+// RecordOrderAmendTo records the id of the order which replaces this one
+func (order *Order) RecordOrderAmendTo(orderID string) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyAmendTo, orderID)
+}
+
+// RecordOrderRent records the rent per block paid by the order from the prepaid balance of the sender
+func (order *Order) RecordOrderRent(rentPerBlock sdk.DecCoin) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyRent, rentPerBlock.String())
+}
+
+// IsRentOrder returns true if the order pays the rent per block instead of locking the fee when placed
+func (order *Order) IsRentOrder() bool {
+	return order.GetExtraInfoWithKey(OrderExtraInfoKeyRent) != ""
+}
+
+// RecordOrderDecreased : An order may be decreased several times by self-trade prevention
+func (order *Order) RecordOrderDecreased(quantity sdk.Dec) {
+	oldValue := order.GetExtraInfoWithKey(OrderExtraInfoKeyDecreased)
+	if oldValue != "" {
+		oldQuantity, err := sdk.NewDecFromStr(oldValue)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		quantity = quantity.Add(oldQuantity)
+	}
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyDecreased, quantity.String())
+}
+
 // RecordOrderDealFee : An order may have several deals
 func (order *Order) RecordOrderDealFee(fee sdk.DecCoins) {
 	oldValue := order.GetExtraInfoWithKey(OrderExtraInfoKeyDealFee)
@@ -198,9 +326,25 @@ func (order *Order) Fill(price, fillAmount sdk.Dec) {
 	}
 }
 
+// Decrease : reduce the quantity of the order without filling it, returns the coins which should be unlocked
+func (order *Order) Decrease(quantity sdk.Dec) sdk.DecCoins {
+	order.Quantity = order.Quantity.Sub(quantity)
+	order.RemainQuantity = order.RemainQuantity.Sub(quantity)
+	symbols := strings.Split(order.Product, "_")
+	unlockCoins := sdk.DecCoins{{Denom: symbols[0], Amount: quantity}}
+	if order.Side == BuyOrder {
+		unlockCoins = sdk.DecCoins{{Denom: symbols[1], Amount: order.Price.Mul(quantity)}}
+	}
+	order.RemainLocked = order.RemainLocked.Sub(unlockCoins[0].Amount)
+	order.RecordOrderDecreased(quantity)
+	return unlockCoins
+}
+
 // nolint
 func (order *Order) Cancel() {
-	if order.RemainQuantity.Equal(order.Quantity) {
+	if order.Status == OrderStatusUntriggered {
+		order.Status = OrderStatusUntriggeredCancelled
+	} else if order.RemainQuantity.Equal(order.Quantity) {
 		order.Status = OrderStatusCancelled
 	} else {
 		order.Status = OrderStatusPartialFilledCancelled
@@ -209,7 +353,9 @@ func (order *Order) Cancel() {
 
 // nolint
 func (order *Order) Expire() {
-	if order.RemainQuantity.Equal(order.Quantity) {
+	if order.Status == OrderStatusUntriggered {
+		order.Status = OrderStatusUntriggeredExpired
+	} else if order.RemainQuantity.Equal(order.Quantity) {
 		order.Status = OrderStatusExpired
 	} else {
 		order.Status = OrderStatusPartialFilledExpired
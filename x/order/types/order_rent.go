@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxOrderRentChargesPerBlock is the max number of the prepaid balances whose rent is charged at the end of a block
+const MaxOrderRentChargesPerBlock = 100
+
+// OrderRent is the prepaid balance of an address, which is debited per block for each of its resting rent orders.
+// The rent is charged lazily: when the number of the resting rent orders changes, or when the balance is visited at
+// the end of a block
+type OrderRent struct {
+	Address       sdk.AccAddress `json:"address"`
+	Balance       sdk.DecCoin    `json:"balance"`
+	OrderNum      int64          `json:"order_num"`      // number of the resting rent orders of the address
+	ChargedHeight int64          `json:"charged_height"` // the rent is charged up to this block height
+}
+
+// NewOrderRent creates a new instance of OrderRent
+func NewOrderRent(address sdk.AccAddress, balance sdk.DecCoin) OrderRent {
+	return OrderRent{
+		Address: address,
+		Balance: balance,
+	}
+}
+
+// String implements the stringer interface
+func (r OrderRent) String() string {
+	return fmt.Sprintf(`OrderRent:
+  Address:       %s
+  Balance:       %s
+  OrderNum:      %d
+  ChargedHeight: %d`, r.Address, r.Balance, r.OrderNum, r.ChargedHeight)
+}
@@ -26,7 +26,7 @@ func TestNewOrder(t *testing.T) {
 	require.Equal(t, sdk.ZeroDec().String(), order1.RemainLocked.String())
 
 	// test order string
-	expected := `{"txhash":"hash1","order_id":"","sender":"","product":"xxb_` + common.NativeToken + `","side":"SELL","price":"1.10000000","quantity":"10.00000000","status":0,"filled_avg_price":"0","remain_quantity":"10.00000000","remain_locked":"0.00000000","timestamp":123,"order_expire_blocks":259200,"fee_per_block":{"denom":"` + common.NativeToken + `","amount":"0.00000100"},"extra_info":""}`
+	expected := `{"txhash":"hash1","order_id":"","sender":"","product":"xxb_` + common.NativeToken + `","side":"SELL","price":"1.10000000","quantity":"10.00000000","status":0,"filled_avg_price":"0.00000000","remain_quantity":"10.00000000","remain_locked":"0.00000000","timestamp":123,"order_expire_blocks":259200,"fee_per_block":{"denom":"` + common.NativeToken + `","amount":"0.00000100"},"extra_info":""}`
 
 	require.Equal(t, expected, order1.String())
 
@@ -113,6 +113,20 @@ func TestOrderCancel(t *testing.T) {
 	require.EqualValues(t, "PartialFilledCancelled", OrderStatus(order2.Status).String())
 }
 
+func TestOrderDecrease(t *testing.T) {
+	order := MockOrder("", TestTokenPair, BuyOrder, "0.1", "10.0")
+	order.Fill(sdk.MustNewDecFromStr("0.1"), sdk.MustNewDecFromStr("2"))
+	unlockCoins := order.Decrease(sdk.MustNewDecFromStr("3"))
+	require.EqualValues(t, sdk.DecCoins{{Denom: common.NativeToken, Amount: sdk.MustNewDecFromStr("0.3")}}, unlockCoins)
+	require.EqualValues(t, sdk.MustNewDecFromStr("7"), order.Quantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("5"), order.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order.RemainLocked)
+	require.EqualValues(t, OrderStatusOpen, order.Status)
+
+	order.Decrease(sdk.MustNewDecFromStr("1"))
+	require.EqualValues(t, "4.00000000", order.GetExtraInfoWithKey(OrderExtraInfoKeyDecreased))
+}
+
 func TestOrderExpire(t *testing.T) {
 	// Full expire
 	order := MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
@@ -166,3 +180,44 @@ func TestGetBlockHeightFromOrderID(t *testing.T) {
 	num = GetBlockHeightFromOrderID(orderID)
 	require.Equal(t, blockHeight, num)
 }
+
+func TestGetMarketOrderPriceAndQuantity(t *testing.T) {
+	slippage := sdk.MustNewDecFromStr("0.05")
+	lastPrice := sdk.MustNewDecFromStr("1.23")
+
+	// buy: 1.23 * 1.05 = 1.2915, truncated to 1.29
+	price, quantity := GetMarketOrderPriceAndQuantity(BuyOrder, sdk.MustNewDecFromStr("10"), lastPrice, slippage, 2, 3)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.29"), price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("7.751"), quantity)
+	// filled at the last price, it spends 9.53373 of the 10 to spend, about 10 / 1.05
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.53373"), quantity.Mul(lastPrice))
+
+	// sell: 1.23 * 0.95 = 1.1685, rounded up to 1.17
+	price, quantity = GetMarketOrderPriceAndQuantity(SellOrder, sdk.MustNewDecFromStr("10"), lastPrice, slippage, 2, 3)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.17"), price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10"), quantity)
+
+	// no valid price
+	price, quantity = GetMarketOrderPriceAndQuantity(BuyOrder, sdk.MustNewDecFromStr("10"), sdk.ZeroDec(), slippage, 2, 3)
+	require.True(t, price.IsZero())
+	require.True(t, quantity.IsZero())
+}
+
+func TestOrderIsTriggered(t *testing.T) {
+	triggerPrice := sdk.MustNewDecFromStr("10.0")
+	order := Order{Type: StopLossOrder, Side: SellOrder, TriggerPrice: &triggerPrice}
+	require.False(t, order.IsTriggered(sdk.MustNewDecFromStr("10.1")))
+	require.True(t, order.IsTriggered(sdk.MustNewDecFromStr("10.0")))
+	require.False(t, order.IsTriggered(sdk.ZeroDec()))
+
+	order.Side = BuyOrder
+	require.False(t, order.IsTriggered(sdk.MustNewDecFromStr("9.9")))
+	require.True(t, order.IsTriggered(sdk.MustNewDecFromStr("10.1")))
+
+	order.Type = TakeProfitOrder
+	require.True(t, order.IsTriggered(sdk.MustNewDecFromStr("9.9")))
+	require.False(t, order.IsTriggered(sdk.MustNewDecFromStr("10.1")))
+
+	order.Type = LimitOrder
+	require.False(t, order.IsTriggered(sdk.MustNewDecFromStr("9.9")))
+}
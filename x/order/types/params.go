@@ -20,6 +20,19 @@ const (
 	DefaultFeeRateTrade          = "0.001" // percentage
 	DefaultNewOrderMsgGasUnit    = 40000
 	DefaultCancelOrderMsgGasUnit = 30000
+	DefaultMarketOrderSlippage   = "0.05" // percentage
+	DefaultSelfTradePrevention   = SelfTradePreventionNone
+	DefaultPriceBandHaltBlocks   = 10  // blocks to halt the product whose match price breaks its price band
+	DefaultOrderRentAmount       = "0" // okt, the order rent is disabled if zero
+)
+
+// self-trade prevention modes, which decide what to do when a sender's buy order and sell order
+// would be filled in the same periodic auction
+const (
+	SelfTradePreventionNone          = "none"           // let the orders trade with each other
+	SelfTradePreventionCancelNewest  = "cancel_newest"  // cancel the order placed later
+	SelfTradePreventionCancelOldest  = "cancel_oldest"  // cancel the order placed earlier
+	SelfTradePreventionDecrementBoth = "decrement_both" // decrease both orders by the smaller remain quantity
 )
 
 // nolint : Parameter keys
@@ -30,7 +43,12 @@ var (
 	KeyTradeFeeRate          = []byte("TradeFeeRate")
 	KeyNewOrderMsgGasUnit    = []byte("NewOrderMsgGasUnit")
 	KeyCancelOrderMsgGasUnit = []byte("CancelOrderMsgGasUnit")
+	KeyMarketOrderSlippage   = []byte("MarketOrderSlippage")
+	KeySelfTradePrevention   = []byte("SelfTradePrevention")
+	KeyPriceBandHaltBlocks   = []byte("PriceBandHaltBlocks")
+	KeyOrderRentPerBlock     = []byte("OrderRentPerBlock")
 	DefaultFeePerBlock       = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr(DefaultFeeAmountPerBlock))
+	DefaultOrderRentPerBlock = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr(DefaultOrderRentAmount))
 )
 
 // nolint
@@ -44,6 +62,10 @@ type Params struct {
 	TradeFeeRate          sdk.Dec     `json:"trade_fee_rate"`
 	NewOrderMsgGasUnit    uint64      `json:"new_order_msg_gas_unit"`
 	CancelOrderMsgGasUnit uint64      `json:"cancel_order_msg_gas_unit"`
+	MarketOrderSlippage   sdk.Dec     `json:"market_order_slippage"`
+	SelfTradePrevention   string      `json:"self_trade_prevention"`
+	PriceBandHaltBlocks   int64       `json:"price_band_halt_blocks"`
+	OrderRentPerBlock     sdk.DecCoin `json:"order_rent_per_block"` // rent debited from the prepaid balance per resting order
 }
 
 // ParamKeyTable for auth module
@@ -62,6 +84,10 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{KeyTradeFeeRate, &p.TradeFeeRate},
 		{KeyNewOrderMsgGasUnit, &p.NewOrderMsgGasUnit},
 		{KeyCancelOrderMsgGasUnit, &p.CancelOrderMsgGasUnit},
+		{KeyMarketOrderSlippage, &p.MarketOrderSlippage},
+		{KeySelfTradePrevention, &p.SelfTradePrevention},
+		{KeyPriceBandHaltBlocks, &p.PriceBandHaltBlocks},
+		{KeyOrderRentPerBlock, &p.OrderRentPerBlock},
 	}
 }
 
@@ -74,9 +100,19 @@ func DefaultParams() Params {
 		TradeFeeRate:          sdk.MustNewDecFromStr(DefaultFeeRateTrade),
 		NewOrderMsgGasUnit:    DefaultNewOrderMsgGasUnit,
 		CancelOrderMsgGasUnit: DefaultCancelOrderMsgGasUnit,
+		MarketOrderSlippage:   sdk.MustNewDecFromStr(DefaultMarketOrderSlippage),
+		SelfTradePrevention:   DefaultSelfTradePrevention,
+		PriceBandHaltBlocks:   DefaultPriceBandHaltBlocks,
+		OrderRentPerBlock:     DefaultOrderRentPerBlock,
 	}
 }
 
+// IsOrderRentEnabled returns true if the new orders pay the rent per block from the prepaid balance of the sender,
+// instead of locking the fee of all the blocks until expired
+func (p Params) IsOrderRentEnabled() bool {
+	return !p.OrderRentPerBlock.Amount.IsNil() && p.OrderRentPerBlock.IsPositive()
+}
+
 // String implements the stringer interface.
 func (p Params) String() string {
 	return fmt.Sprintf(`Order Params:
@@ -85,7 +121,12 @@ func (p Params) String() string {
   FeePerBlock: %s
   TradeFeeRate: %s
   NewOrderMsgGasUnit: %d
-  CancelOrderMsgGasUnit: %d`, p.OrderExpireBlocks,
+  CancelOrderMsgGasUnit: %d
+  MarketOrderSlippage: %s
+  SelfTradePrevention: %s
+  PriceBandHaltBlocks: %d
+  OrderRentPerBlock: %s`, p.OrderExpireBlocks,
 		p.MaxDealsPerBlock, p.FeePerBlock,
-		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit)
+		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit, p.MarketOrderSlippage,
+		p.SelfTradePrevention, p.PriceBandHaltBlocks, p.OrderRentPerBlock)
 }
@@ -18,6 +18,10 @@ func TestParamSetPairs(t *testing.T) {
 			TradeFeeRate:          sdk.MustNewDecFromStr("0.001"),
 			NewOrderMsgGasUnit:    123,
 			CancelOrderMsgGasUnit: 456,
+			MarketOrderSlippage:   sdk.MustNewDecFromStr("0.1"),
+			SelfTradePrevention:   SelfTradePreventionCancelNewest,
+			PriceBandHaltBlocks:   20,
+			OrderRentPerBlock:     sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr("0.0001")),
 		},
 	}
 
@@ -41,6 +45,16 @@ func TestParamSetPairs(t *testing.T) {
 				require.EqualValues(t, test.NewOrderMsgGasUnit, *(v.Value.(*uint64)))
 			case string(KeyCancelOrderMsgGasUnit):
 				require.EqualValues(t, test.CancelOrderMsgGasUnit, *(v.Value.(*uint64)))
+			case string(KeyMarketOrderSlippage):
+				if !v.Value.(*sdk.Dec).Equal(test.MarketOrderSlippage) {
+					t.Errorf("key(%s) -> %x, want %x", v.Key, test.MarketOrderSlippage, v.Value)
+				}
+			case string(KeySelfTradePrevention):
+				require.EqualValues(t, test.SelfTradePrevention, *(v.Value.(*string)))
+			case string(KeyPriceBandHaltBlocks):
+				require.EqualValues(t, test.PriceBandHaltBlocks, *(v.Value.(*int64)))
+			case string(KeyOrderRentPerBlock):
+				require.True(t, v.Value.(*sdk.DecCoin).IsEqual(test.OrderRentPerBlock))
 			}
 		}
 	}
@@ -54,6 +68,15 @@ func TestParamsString(t *testing.T) {
   FeePerBlock: 0.00000000` + common.NativeToken + `
   TradeFeeRate: 0.00100000
   NewOrderMsgGasUnit: 40000
-  CancelOrderMsgGasUnit: 30000`
+  CancelOrderMsgGasUnit: 30000
+  MarketOrderSlippage: 0.05000000
+  SelfTradePrevention: none
+  PriceBandHaltBlocks: 10
+  OrderRentPerBlock: 0.00000000` + common.NativeToken
 	require.EqualValues(t, expectString, param.String())
+	require.False(t, param.IsOrderRentEnabled())
+	require.False(t, Params{}.IsOrderRentEnabled())
+
+	param.OrderRentPerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.0001"))
+	require.True(t, param.IsOrderRentEnabled())
 }
@@ -4,6 +4,17 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// events of the products halted and resumed by their price bands
+const (
+	EventTypeProductHalted  = "product_halted"
+	EventTypeProductResumed = "product_resumed"
+
+	AttributeKeyProduct       = "product"
+	AttributeKeyMatchPrice    = "match_price"
+	AttributeKeyLastPrice     = "last_price"
+	AttributeKeyHaltEndHeight = "halt_end_height"
+)
+
 // nolint
 type ProductLock struct {
 	BlockHeight  int64
@@ -11,6 +22,22 @@ type ProductLock struct {
 	Quantity     sdk.Dec
 	BuyExecuted  sdk.Dec
 	SellExecuted sdk.Dec
+	// HaltEndHeight is set if the product is halted because the match price broke its price band,
+	// the product is matched again at this height
+	HaltEndHeight int64
+}
+
+// IsHalted returns true if the product is locked by the price band rather than a partially executed match
+func (lock *ProductLock) IsHalted() bool {
+	return lock.HaltEndHeight > 0
+}
+
+// HaltedProduct is the query result of a product halted by its price band
+type HaltedProduct struct {
+	Product       string  `json:"product"`
+	BlockHeight   int64   `json:"block_height"`
+	HaltEndHeight int64   `json:"halt_end_height"`
+	MatchPrice    sdk.Dec `json:"match_price"`
 }
 
 // nolint
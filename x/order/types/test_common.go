@@ -8,10 +8,13 @@ var DefaultTestFeePerBlock = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.
 
 func DefaultTestParams() Params {
 	return Params{
-		OrderExpireBlocks: DefaultOrderExpireBlocks,
-		MaxDealsPerBlock:  DefaultMaxDealsPerBlock,
-		FeePerBlock:       DefaultTestFeePerBlock,
-		TradeFeeRate:      sdk.MustNewDecFromStr(DefaultFeeRateTrade),
+		OrderExpireBlocks:   DefaultOrderExpireBlocks,
+		MaxDealsPerBlock:    DefaultMaxDealsPerBlock,
+		FeePerBlock:         DefaultTestFeePerBlock,
+		TradeFeeRate:        sdk.MustNewDecFromStr(DefaultFeeRateTrade),
+		MarketOrderSlippage: sdk.MustNewDecFromStr(DefaultMarketOrderSlippage),
+		PriceBandHaltBlocks: DefaultPriceBandHaltBlocks,
+		OrderRentPerBlock:   DefaultOrderRentPerBlock,
 	}
 }
 